@@ -43,13 +43,14 @@ import (
 )
 
 const (
-	statsAlertsTriggered = "alerts_triggered"
-	statsAlertsInhibited = "alerts_inhibited"
-	statsOKsTriggered    = "oks_triggered"
-	statsInfosTriggered  = "infos_triggered"
-	statsWarnsTriggered  = "warns_triggered"
-	statsCritsTriggered  = "crits_triggered"
-	statsEventsDropped   = "events_dropped"
+	statsAlertsTriggered  = "alerts_triggered"
+	statsAlertsInhibited  = "alerts_inhibited"
+	statsAlertsSuppressed = "alerts_suppressed"
+	statsOKsTriggered     = "oks_triggered"
+	statsInfosTriggered   = "infos_triggered"
+	statsWarnsTriggered   = "warns_triggered"
+	statsCritsTriggered   = "crits_triggered"
+	statsEventsDropped    = "events_dropped"
 )
 
 // The newest state change is weighted 'weightDiff' times more than oldest state change.
@@ -70,18 +71,24 @@ type AlertNode struct {
 	messageTmpl *text.Template
 	detailsTmpl *html.Template
 
-	alertsTriggered *expvar.Int
-	alertsInhibited *expvar.Int
-	oksTriggered    *expvar.Int
-	infosTriggered  *expvar.Int
-	warnsTriggered  *expvar.Int
-	critsTriggered  *expvar.Int
-	eventsDropped   *expvar.Int
+	alertsTriggered  *expvar.Int
+	alertsInhibited  *expvar.Int
+	alertsSuppressed *expvar.Int
+	oksTriggered     *expvar.Int
+	infosTriggered   *expvar.Int
+	warnsTriggered   *expvar.Int
+	critsTriggered   *expvar.Int
+	eventsDropped    *expvar.Int
 
 	bufPool sync.Pool
 
 	levelResets  []stateful.Expression
 	lrScopePools []stateful.ScopePool
+
+	// mu protects the alert states so they can be snapshotted.
+	mu       sync.Mutex
+	states   map[models.GroupID]*alertState
+	restored map[models.GroupID]alertStateSnapshot
 }
 
 // Create a new  AlertNode which caches the most recent item and exposes it over the HTTP API.
@@ -92,8 +99,9 @@ func newAlertNode(et *ExecutingTask, n *pipeline.AlertNode, d NodeDiagnostic) (a
 	}
 
 	an = &AlertNode{
-		node: node{Node: n, et: et, diag: d},
-		a:    n,
+		node:   node{Node: n, et: et, diag: d},
+		a:      n,
+		states: make(map[models.GroupID]*alertState),
 	}
 	an.node.runF = an.runAlert
 
@@ -694,6 +702,18 @@ func newAlertNode(et *ExecutingTask, n *pipeline.AlertNode, d NodeDiagnostic) (a
 		}
 	}
 
+	// Check the calendars exist
+	for _, calendars := range [][]string{n.ActiveCalendars, n.InactiveCalendars} {
+		for _, name := range calendars {
+			if et.tm.CalendarService == nil {
+				return nil, errors.New("calendar service is not available")
+			}
+			if _, err := et.tm.CalendarService.Calendar(name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Setup states
 	if n.History < 2 {
 		n.History = 2
@@ -709,7 +729,13 @@ func newAlertNode(et *ExecutingTask, n *pipeline.AlertNode, d NodeDiagnostic) (a
 	return
 }
 
-func (n *AlertNode) runAlert([]byte) error {
+func (n *AlertNode) runAlert(snapshot []byte) error {
+	if snapshot != nil {
+		if err := n.restore(snapshot); err != nil {
+			n.diag.Error("failed to restore alert state from snapshot", err)
+		}
+	}
+
 	// Register delete hook
 	if n.hasAnonTopic() {
 		n.et.tm.registerDeleteHookForTask(n.et.Task.ID, deleteAlertHook(n.anonTopic))
@@ -729,6 +755,9 @@ func (n *AlertNode) runAlert([]byte) error {
 	n.alertsInhibited = &expvar.Int{}
 	n.statMap.Set(statsAlertsInhibited, n.alertsInhibited)
 
+	n.alertsSuppressed = &expvar.Int{}
+	n.statMap.Set(statsAlertsSuppressed, n.alertsSuppressed)
+
 	n.oksTriggered = &expvar.Int{}
 	n.statMap.Set(statsOKsTriggered, n.oksTriggered)
 
@@ -774,16 +803,49 @@ func (n *AlertNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.R
 	t := first.Time()
 
 	state := n.restoreEventState(id, t, group.Tags)
+	n.addState(group.ID, state)
 
 	return edge.NewReceiverFromForwardReceiverWithStats(
 		n.outs,
 		edge.NewTimedForwardReceiver(
 			n.timer,
-			state,
+			edge.NewLockedForwardReceiver(&n.mu, state),
 		),
 	), nil
 }
 
+// addState tracks the state of the group, restoring it from the snapshot if any.
+func (n *AlertNode) addState(id models.GroupID, state *alertState) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if s, ok := n.restored[id]; ok {
+		state.restore(s)
+		delete(n.restored, id)
+	}
+	n.states[id] = state
+}
+
+func (n *AlertNode) snapshot() ([]byte, error) {
+	n.mu.Lock()
+	states := make(map[models.GroupID]alertStateSnapshot, len(n.states))
+	for id, state := range n.states {
+		states[id] = state.snapshot()
+	}
+	n.mu.Unlock()
+	return encodeNodeSnapshot(states)
+}
+
+func (n *AlertNode) restore(data []byte) error {
+	states := make(map[models.GroupID]alertStateSnapshot)
+	if err := decodeNodeSnapshot(data, &states); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.restored = states
+	n.mu.Unlock()
+	return nil
+}
+
 func (n *AlertNode) restoreEventState(id string, t time.Time, tags models.Tags) *alertState {
 	state := n.newAlertState(tags)
 	currentLevel, triggered := n.restoreEvent(id)
@@ -872,6 +934,10 @@ func (n *AlertNode) hasTopic() bool {
 }
 
 func (n *AlertNode) handleEvent(event alert.Event) {
+	// Track the event as a source of inhibitions before checking whether it is inhibited,
+	// so that the recovery of an inhibited source still ends the inhibitions of the source.
+	n.et.tm.AlertService.UpdateInhibitions(event)
+
 	// Check if alert is inhibited
 	if n.et.tm.AlertService.IsInhibited(event.Data.Category, event.Data.Tags) {
 		n.alertsInhibited.Add(1)
@@ -912,6 +978,34 @@ func (n *AlertNode) handleEvent(event alert.Event) {
 	}
 }
 
+// isActive reports whether events are sent at time t according to the calendars of the alert.
+func (n *AlertNode) isActive(t time.Time) bool {
+	for _, name := range n.a.InactiveCalendars {
+		active, err := n.et.tm.CalendarService.Active(name, t)
+		if err != nil {
+			n.diag.Error("failed to check calendar", err, keyvalue.KV("calendar", name))
+			continue
+		}
+		if active {
+			return false
+		}
+	}
+	if len(n.a.ActiveCalendars) == 0 {
+		return true
+	}
+	for _, name := range n.a.ActiveCalendars {
+		active, err := n.et.tm.CalendarService.Active(name, t)
+		if err != nil {
+			n.diag.Error("failed to check calendar", err, keyvalue.KV("calendar", name))
+			continue
+		}
+		if active {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *AlertNode) determineLevel(p edge.FieldsTagsTimeGetter, currentLevel alert.Level) alert.Level {
 	if higherLevel, found := n.findFirstMatchLevel(alert.Critical, currentLevel-1, p); found {
 		return higherLevel
@@ -1059,6 +1153,13 @@ func (a *alertState) BufferedBatch(b edge.BufferedBatchMessage) (edge.Message, e
 	}
 	t = t.UTC()
 
+	// Ignore the batch while the calendars of the alert mute it,
+	// so that the state is evaluated again once events are sent.
+	if !a.n.isActive(t) {
+		a.n.alertsSuppressed.Add(1)
+		return nil, nil
+	}
+
 	a.addEvent(t, l)
 
 	// Trigger alert only if:
@@ -1117,6 +1218,12 @@ func (a *alertState) Point(p edge.PointMessage) (edge.Message, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Ignore the point while the calendars of the alert mute it,
+	// so that the state is evaluated again once events are sent.
+	if !a.n.isActive(p.Time()) {
+		a.n.alertsSuppressed.Add(1)
+		return nil, nil
+	}
 	l := a.n.determineLevel(p, a.currentLevel())
 
 	a.addEvent(p.Time(), l)
@@ -1196,6 +1303,7 @@ func (a *alertState) Barrier(b edge.BarrierMessage) (edge.Message, error) {
 }
 
 func (a *alertState) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	delete(a.n.states, d.GroupID())
 	return d, nil
 }
 func (a *alertState) Done() {
@@ -1204,6 +1312,51 @@ func (a *alertState) Done() {
 	}
 }
 
+// alertStateSnapshot is the persisted state of a single alert group.
+type alertStateSnapshot struct {
+	History        []alert.Level
+	Idx            int
+	Flapping       bool
+	Changed        bool
+	Expired        bool
+	FirstTriggered time.Time
+	LastTriggered  time.Time
+}
+
+func (a *alertState) snapshot() alertStateSnapshot {
+	history := make([]alert.Level, len(a.history))
+	copy(history, a.history)
+	return alertStateSnapshot{
+		History:        history,
+		Idx:            a.idx,
+		Flapping:       a.flapping,
+		Changed:        a.changed,
+		Expired:        a.expired,
+		FirstTriggered: a.firstTriggered,
+		LastTriggered:  a.lastTriggered,
+	}
+}
+
+// restore replaces the state with the snapshot.
+// Snapshots with a different history size are ignored.
+func (a *alertState) restore(s alertStateSnapshot) {
+	if len(s.History) != len(a.history) || s.Idx < 0 || s.Idx >= len(a.history) {
+		return
+	}
+	copy(a.history, s.History)
+	a.idx = s.Idx
+	a.flapping = s.Flapping
+	a.changed = s.Changed
+	a.expired = s.Expired
+	a.firstTriggered = s.FirstTriggered
+	a.lastTriggered = s.LastTriggered
+
+	inhibited := a.currentLevel() != alert.OK
+	for _, in := range a.inhibitors {
+		in.Set(inhibited)
+	}
+}
+
 // Return the duration of the current alert state.
 func (a *alertState) duration() time.Duration {
 	return a.lastTriggered.Sub(a.firstTriggered)
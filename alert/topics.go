@@ -5,6 +5,7 @@ import (
 	"path"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/influxdata/kapacitor/expvar"
 	"github.com/influxdata/kapacitor/server/vars"
@@ -80,7 +81,18 @@ func (s *Topics) UpdateEvent(topicID string, event EventState) {
 	if !ok {
 		s.topics[topicID] = s.newTopic(topicID)
 	}
-	t.updateEvent(event)
+	t.updateEvent(&event)
+}
+
+// AckEvent acknowledges the event, and returns its new state and if it exists or not.
+func (s *Topics) AckEvent(topic, event, author string, now time.Time) (EventState, bool) {
+	s.mu.RLock()
+	t, ok := s.topics[topic]
+	s.mu.RUnlock()
+	if !ok {
+		return EventState{}, false
+	}
+	return t.ackEvent(event, author, now)
 }
 
 func (s *Topics) EventState(topic, event string) (EventState, bool) {
@@ -94,7 +106,8 @@ func (s *Topics) EventState(topic, event string) (EventState, bool) {
 }
 
 // Collect collects an event and handles the event.
-func (s *Topics) Collect(event Event) error {
+// It returns the previous state of the event, if the event existed.
+func (s *Topics) Collect(event Event) (EventState, bool, error) {
 	s.mu.RLock()
 	topic := s.topics[event.Topic]
 	s.mu.RUnlock()
@@ -201,6 +214,8 @@ type Topic struct {
 	bufferLength int
 	events       map[string]*EventState
 	sorted       []*EventState
+	// notified is the set of events whose current non OK state was passed to the handlers.
+	notified map[string]bool
 
 	collected *expvar.Int
 	statsKey  string
@@ -212,6 +227,7 @@ func (s *Topics) newTopic(id string) *Topic {
 	t := &Topic{
 		id:           id,
 		events:       make(map[string]*EventState),
+		notified:     make(map[string]bool),
 		collected:    new(expvar.Int),
 		bufferLength: s.eventBufferSize,
 	}
@@ -276,9 +292,15 @@ func (t *Topic) restoreEventStatesNoCopy(eventStates map[string]*EventState) {
 	defer t.mu.Unlock()
 	t.events = make(map[string]*EventState, len(eventStates))
 	t.sorted = make([]*EventState, 0, len(eventStates))
+	t.notified = make(map[string]bool, len(eventStates))
 	for id, state := range eventStates {
 		t.events[id] = state
 		t.sorted = append(t.sorted, state)
+		// The handlers are assumed to have been notified of the restored states,
+		// so that their recoveries are not lost.
+		if state.Level != OK {
+			t.notified[id] = true
+		}
 	}
 	sort.Sort(sortedStates(t.sorted))
 }
@@ -306,6 +328,20 @@ func (t *Topic) EventState(event string) (EventState, bool) {
 	return EventState{}, false
 }
 
+// ackEvent acknowledges the current state of the event.
+// The acknowledgement is kept until the level of the event changes.
+func (t *Topic) ackEvent(event, author string, now time.Time) (EventState, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.events[event]
+	if !ok {
+		return EventState{}, false
+	}
+	state.AckedBy = author
+	state.AckedAt = now
+	return *state, true
+}
+
 func (t *Topic) close() {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -317,16 +353,40 @@ func (t *Topic) close() {
 	vars.DeleteStatistic(t.statsKey)
 }
 
-func (t *Topic) collect(event Event) error {
+func (t *Topic) collect(event Event) (EventState, bool, error) {
 
-	prev, ok := t.updateEvent(event.State)
+	prev, ok := t.updateEvent(&event.State)
 	if ok {
 		event.previousState = prev
 	}
 
 	t.collected.Add(1)
 
-	return t.handleEvent(event)
+	// Acknowledged and silenced events are not handled,
+	// unless they recover an event the handlers were notified of.
+	if (event.Silenced || event.State.Acked()) &&
+		(event.State.Level != OK || !t.isNotified(event.State.ID)) {
+		return prev, ok, nil
+	}
+
+	t.setNotified(event.State.ID, event.State.Level != OK)
+	return prev, ok, t.handleEvent(event)
+}
+
+func (t *Topic) isNotified(id string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.notified[id]
+}
+
+func (t *Topic) setNotified(id string, notified bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if notified {
+		t.notified[id] = true
+	} else {
+		delete(t.notified, id)
+	}
 }
 
 func (t *Topic) handleEvent(event Event) error {
@@ -354,7 +414,8 @@ func (t *Topic) Collected() int64 {
 }
 
 // updateEvent will store the latest state for the given ID.
-func (t *Topic) updateEvent(state EventState) (EventState, bool) {
+// The acknowledgement of the previous state is kept if the level has not changed.
+func (t *Topic) updateEvent(state *EventState) (EventState, bool) {
 	var hasPrev, needSort bool
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -369,8 +430,13 @@ func (t *Topic) updateEvent(state EventState) (EventState, bool) {
 	}
 	needSort = needSort || cur.Level != state.Level
 
+	if hasPrev && cur.Acked() && !state.Acked() && cur.Level == state.Level {
+		state.AckedBy = cur.AckedBy
+		state.AckedAt = cur.AckedAt
+	}
+
 	prev := *cur
-	*cur = state
+	*cur = *state
 
 	if needSort {
 		sort.Sort(sortedStates(t.sorted))
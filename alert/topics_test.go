@@ -0,0 +1,58 @@
+package alert_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/influxdata/kapacitor/alert"
+)
+
+type recordingHandler struct {
+	mu     sync.Mutex
+	events []alert.Event
+}
+
+func (h *recordingHandler) Handle(event alert.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+}
+
+func TestTopics_Collect_SilencedRecovery(t *testing.T) {
+	topics := alert.NewTopics(0)
+	h := new(recordingHandler)
+	topics.RegisterHandler("topic", h)
+
+	collect := func(id string, level alert.Level, silenced bool) {
+		event := alert.Event{
+			Topic:    "topic",
+			State:    alert.EventState{ID: id, Level: level},
+			Silenced: silenced,
+		}
+		if _, _, err := topics.Collect(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The recovery of a notified event is handled during a silence.
+	collect("notified", alert.Critical, false)
+	collect("notified", alert.Critical, true)
+	collect("notified", alert.OK, true)
+	// An event silenced since it started is not handled.
+	collect("silenced", alert.Critical, true)
+	collect("silenced", alert.OK, true)
+	// Another recovery is not handled once the handlers were told.
+	collect("notified", alert.OK, true)
+
+	if err := topics.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range h.events {
+		got = append(got, e.State.ID+":"+e.State.Level.String())
+	}
+	exp := []string{"notified:CRITICAL", "notified:OK"}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected handled events: got %v exp %v", got, exp)
+	}
+}
@@ -10,10 +10,12 @@ import (
 )
 
 type Event struct {
-	Topic         string
-	State         EventState
-	Data          EventData
-	NoExternal    bool
+	Topic      string
+	State      EventState
+	Data       EventData
+	NoExternal bool
+	// Silenced events update the state of the topic but are not passed to its handlers.
+	Silenced      bool
 	previousState EventState
 }
 
@@ -63,6 +65,15 @@ type EventState struct {
 	Time     time.Time
 	Duration time.Duration
 	Level    Level
+	// AckedBy is the author of the acknowledgement of the event.
+	AckedBy string
+	// AckedAt is the time the event was acknowledged, zero if the event is not acknowledged.
+	AckedAt time.Time
+}
+
+// Acked reports whether the event has been acknowledged.
+func (s EventState) Acked() bool {
+	return !s.AckedAt.IsZero()
 }
 
 type EventData struct {
@@ -0,0 +1,206 @@
+package kapacitor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/keyvalue"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+type AnomalyNode struct {
+	node
+	a *pipeline.AnomalyNode
+
+	// mu protects the groups so they can be snapshotted.
+	mu       sync.Mutex
+	groups   map[models.GroupID]*anomalyGroup
+	restored map[models.GroupID]map[int]anomalyBaseline
+}
+
+// Create a new anomaly node.
+func newAnomalyNode(et *ExecutingTask, n *pipeline.AnomalyNode, d NodeDiagnostic) (*AnomalyNode, error) {
+	an := &AnomalyNode{
+		node:   node{Node: n, et: et, diag: d},
+		a:      n,
+		groups: make(map[models.GroupID]*anomalyGroup),
+	}
+	an.node.runF = an.runAnomaly
+	return an, nil
+}
+
+func (n *AnomalyNode) runAnomaly(snapshot []byte) error {
+	if snapshot != nil {
+		if err := n.restore(snapshot); err != nil {
+			n.diag.Error("failed to restore anomaly baselines from snapshot", err)
+		}
+	}
+	consumer := edge.NewGroupedConsumer(
+		n.ins[0],
+		n,
+	)
+	n.statMap.Set(statCardinalityGauge, consumer.CardinalityVar())
+	return consumer.Consume()
+}
+
+func (n *AnomalyNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.Receiver, error) {
+	g := &anomalyGroup{
+		n:         n,
+		baselines: make(map[int]*anomalyBaseline),
+	}
+	n.mu.Lock()
+	if s, ok := n.restored[group.ID]; ok {
+		for k, b := range s {
+			b := b
+			g.baselines[k] = &b
+		}
+		delete(n.restored, group.ID)
+	}
+	n.groups[group.ID] = g
+	n.mu.Unlock()
+	return edge.NewReceiverFromForwardReceiverWithStats(
+		n.outs,
+		edge.NewTimedForwardReceiver(n.timer, edge.NewLockedForwardReceiver(&n.mu, g)),
+	), nil
+}
+
+func (n *AnomalyNode) snapshot() ([]byte, error) {
+	n.mu.Lock()
+	groups := make(map[models.GroupID]map[int]anomalyBaseline, len(n.groups))
+	for id, g := range n.groups {
+		baselines := make(map[int]anomalyBaseline, len(g.baselines))
+		for k, b := range g.baselines {
+			baselines[k] = *b
+		}
+		groups[id] = baselines
+	}
+	n.mu.Unlock()
+	return encodeNodeSnapshot(groups)
+}
+
+func (n *AnomalyNode) restore(data []byte) error {
+	groups := make(map[models.GroupID]map[int]anomalyBaseline)
+	if err := decodeNodeSnapshot(data, &groups); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.restored = groups
+	n.mu.Unlock()
+	return nil
+}
+
+// baselineKey returns the key of the baseline a point at time t is scored against.
+func (n *AnomalyNode) baselineKey(t time.Time) int {
+	if n.a.Method != pipeline.AnomalySeasonal {
+		return 0
+	}
+	// Hour of the week
+	t = t.UTC()
+	return int(t.Weekday())*24 + t.Hour()
+}
+
+type anomalyGroup struct {
+	n         *AnomalyNode
+	baselines map[int]*anomalyBaseline
+}
+
+func (g *anomalyGroup) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	return begin, nil
+}
+
+func (g *anomalyGroup) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	bp = bp.ShallowCopy()
+	if !g.score(bp) {
+		return nil, nil
+	}
+	return bp, nil
+}
+
+func (g *anomalyGroup) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	return end, nil
+}
+
+func (g *anomalyGroup) Point(p edge.PointMessage) (edge.Message, error) {
+	p = p.ShallowCopy()
+	if !g.score(p) {
+		return nil, nil
+	}
+	return p, nil
+}
+
+// score adds the score and expected band fields to p and updates the baseline.
+// Points without a numeric value for the field are dropped.
+func (g *anomalyGroup) score(p edge.FieldsTagsTimeSetter) bool {
+	a := g.n.a
+	value, ok := numToFloat(p.Fields()[a.Field])
+	if !ok {
+		g.n.diag.Error("cannot score anomaly",
+			errors.New("field is missing or the wrong type"),
+			keyvalue.KV("field", a.Field),
+			keyvalue.KV("type", fmt.Sprintf("%T", p.Fields()[a.Field])),
+		)
+		return false
+	}
+	key := g.n.baselineKey(p.Time())
+	b, ok := g.baselines[key]
+	if !ok {
+		b = &anomalyBaseline{}
+		g.baselines[key] = b
+	}
+	score, expected, stddev := b.score(value)
+	b.update(value, a.Alpha)
+
+	fields := p.Fields().Copy()
+	fields[a.ScoreAs] = score
+	fields[a.ExpectedAs] = expected
+	fields[a.LowerAs] = expected - a.Threshold*stddev
+	fields[a.UpperAs] = expected + a.Threshold*stddev
+	p.SetFields(fields)
+	return true
+}
+
+func (g *anomalyGroup) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	return b, nil
+}
+func (g *anomalyGroup) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	delete(g.n.groups, d.GroupID())
+	return d, nil
+}
+func (g *anomalyGroup) Done() {}
+
+// anomalyBaseline is an exponentially weighted moving average and variance.
+type anomalyBaseline struct {
+	Mean     float64
+	Variance float64
+	Count    int64
+}
+
+// score returns the z-score of value and the current mean and standard deviation.
+func (b *anomalyBaseline) score(value float64) (score, mean, stddev float64) {
+	if b.Count == 0 {
+		return 0, value, 0
+	}
+	stddev = math.Sqrt(b.Variance)
+	if stddev > 0 {
+		score = (value - b.Mean) / stddev
+	}
+	return score, b.Mean, stddev
+}
+
+// update adds value to the baseline with smoothing factor alpha.
+func (b *anomalyBaseline) update(value, alpha float64) {
+	b.Count++
+	if b.Count == 1 {
+		b.Mean = value
+		return
+	}
+	diff := value - b.Mean
+	incr := alpha * diff
+	b.Mean += incr
+	b.Variance = (1 - alpha) * (b.Variance + diff*incr)
+}
@@ -175,6 +175,9 @@ type QueryNode struct {
 	closing  chan struct{}
 	aborting chan struct{}
 
+	// calendar is the interval of calendar schedules, nil if the schedule is not aligned to the calendar.
+	calendar *calendarInterval
+
 	batchesQueried *expvar.Int
 	pointsQueried  *expvar.Int
 	byName         bool
@@ -229,6 +232,9 @@ func newQueryNode(et *ExecutingTask, n *pipeline.QueryNode, d NodeDiagnostic) (*
 	if n.Every != 0 && n.Cron != "" {
 		return nil, errors.New("must not set both 'every' and 'cron' properties")
 	}
+	if n.Calendar != "" && (n.Every != 0 || n.Cron != "") {
+		return nil, errors.New("must not set 'calendar' with 'every' or 'cron' properties")
+	}
 	switch {
 	case n.Every > 0:
 		bn.ticker = newTimeTicker(n.Every, n.AlignFlag)
@@ -238,15 +244,30 @@ func newQueryNode(et *ExecutingTask, n *pipeline.QueryNode, d NodeDiagnostic) (*
 		if err != nil {
 			return nil, err
 		}
+	case n.Calendar != "":
+		var err error
+		bn.calendar, err = newCalendarInterval(n.Calendar, n.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		bn.ticker = newCalendarTicker(bn.calendar, n.Offset)
 	case n.Every < 0:
 		return nil, errors.New("'every' duration must must non-negative")
 	default:
-		return nil, errors.New("must define one of 'every' or 'cron'")
+		return nil, errors.New("must define one of 'every', 'cron' or 'calendar'")
 	}
 
 	return bn, nil
 }
 
+// startTime returns the start time of the query that stops at stop.
+func (n *QueryNode) startTime(stop time.Time) time.Time {
+	if n.calendar != nil {
+		return n.calendar.start(stop.Add(-1))
+	}
+	return stop.Add(-1 * n.b.Period)
+}
+
 func (n *QueryNode) GroupByMeasurement() bool {
 	return n.byName
 }
@@ -297,7 +318,7 @@ func (n *QueryNode) Queries(start, stop time.Time) ([]*Query, error) {
 		if err != nil {
 			return nil, err
 		}
-		q.SetStartTime(qstop.Add(-1 * n.b.Period))
+		q.SetStartTime(n.startTime(qstop))
 		q.SetStopTime(qstop)
 		queries = append(queries, q)
 	}
@@ -332,7 +353,7 @@ func (n *QueryNode) doQuery(in edge.Edge) error {
 			n.timer.Start()
 			// Update times for query
 			stop := now.Add(-1 * n.b.Offset)
-			n.query.SetStartTime(stop.Add(-1 * n.b.Period))
+			n.query.SetStartTime(n.startTime(stop))
 			n.query.SetStopTime(stop)
 
 			qStr := n.query.String()
@@ -563,6 +584,52 @@ func (c *cronTicker) Next(now time.Time) time.Time {
 	return c.expr.Next(now)
 }
 
+// calendarTicker ticks at the start of each calendar interval, delayed by the offset.
+type calendarTicker struct {
+	interval *calendarInterval
+	offset   time.Duration
+	ticker   chan time.Time
+	closing  chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newCalendarTicker(interval *calendarInterval, offset time.Duration) *calendarTicker {
+	return &calendarTicker{
+		interval: interval,
+		offset:   offset,
+		ticker:   make(chan time.Time),
+		closing:  make(chan struct{}),
+	}
+}
+
+func (c *calendarTicker) Start() <-chan time.Time {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		for {
+			now := time.Now()
+			next := c.Next(now)
+			diff := next.Sub(now)
+			select {
+			case <-time.After(diff):
+				c.ticker <- next
+			case <-c.closing:
+				return
+			}
+		}
+	}()
+	return c.ticker
+}
+
+func (c *calendarTicker) Stop() {
+	close(c.closing)
+	c.wg.Wait()
+}
+
+func (c *calendarTicker) Next(now time.Time) time.Time {
+	return c.interval.next(now.Add(-1 * c.offset)).Add(c.offset)
+}
+
 // FluxQueryNode is a node for making flux queries
 type FluxQueryNode struct {
 	node
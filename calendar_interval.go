@@ -0,0 +1,89 @@
+package kapacitor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+// calendarInterval is a number of calendar days, weeks, months or years in a time zone.
+// Intervals are aligned to the Unix epoch in the time zone so that consecutive intervals
+// do not depend on the time the task was started.
+type calendarInterval struct {
+	count    int
+	unit     string
+	location *time.Location
+}
+
+// epochMonday is the first Monday after the Unix epoch, weeks are counted from it.
+var epochMonday = time.Date(1970, 1, 5, 0, 0, 0, 0, time.UTC)
+
+func newCalendarInterval(interval, timezone string) (*calendarInterval, error) {
+	c, err := pipeline.ParseCalendarInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %v", timezone, err)
+	}
+	return &calendarInterval{
+		count:    c.Count,
+		unit:     c.Unit,
+		location: loc,
+	}, nil
+}
+
+// start returns the start of the interval containing t.
+func (c *calendarInterval) start(t time.Time) time.Time {
+	y, m, d := t.In(c.location).Date()
+	// The civil date of t, independent of the time zone offset.
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	switch c.unit {
+	case pipeline.CalendarDay:
+		days := floorDiv(date.Unix(), 24*60*60)
+		days -= floorMod(days, int64(c.count))
+		return time.Date(1970, 1, 1+int(days), 0, 0, 0, 0, c.location)
+	case pipeline.CalendarWeek:
+		weeks := floorDiv(date.Unix()-epochMonday.Unix(), 7*24*60*60)
+		weeks -= floorMod(weeks, int64(c.count))
+		return time.Date(1970, 1, 5+7*int(weeks), 0, 0, 0, 0, c.location)
+	case pipeline.CalendarMonth:
+		months := int64(y-1970)*12 + int64(m) - 1
+		months -= floorMod(months, int64(c.count))
+		return time.Date(1970, time.Month(1+months), 1, 0, 0, 0, 0, c.location)
+	default:
+		years := int64(y)
+		years -= floorMod(years, int64(c.count))
+		return time.Date(int(years), 1, 1, 0, 0, 0, 0, c.location)
+	}
+}
+
+// next returns the start of the interval following the interval containing t.
+func (c *calendarInterval) next(t time.Time) time.Time {
+	y, m, d := c.start(t).In(c.location).Date()
+	switch c.unit {
+	case pipeline.CalendarDay:
+		d += c.count
+	case pipeline.CalendarWeek:
+		d += 7 * c.count
+	case pipeline.CalendarMonth:
+		m += time.Month(c.count)
+	default:
+		y += c.count
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, c.location)
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+func floorMod(a, b int64) int64 {
+	return a - floorDiv(a, b)*b
+}
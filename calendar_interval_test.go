@@ -0,0 +1,76 @@
+package kapacitor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarInterval(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		interval string
+		timezone string
+		t        time.Time
+		start    time.Time
+		next     time.Time
+	}{
+		{
+			interval: "1d",
+			timezone: "Europe/Berlin",
+			t:        time.Date(2026, 3, 29, 12, 0, 0, 0, berlin),
+			start:    time.Date(2026, 3, 29, 0, 0, 0, 0, berlin),
+			next:     time.Date(2026, 3, 30, 0, 0, 0, 0, berlin),
+		},
+		{
+			// The day before daylight saving time ends is 25 hours long.
+			interval: "1d",
+			timezone: "Europe/Berlin",
+			t:        time.Date(2026, 10, 24, 23, 30, 0, 0, time.UTC),
+			start:    time.Date(2026, 10, 25, 0, 0, 0, 0, berlin),
+			next:     time.Date(2026, 10, 26, 0, 0, 0, 0, berlin),
+		},
+		{
+			interval: "1w",
+			timezone: "UTC",
+			t:        time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+			start:    time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+			next:     time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			interval: "1mo",
+			timezone: "Europe/Berlin",
+			t:        time.Date(2026, 2, 28, 23, 30, 0, 0, time.UTC),
+			start:    time.Date(2026, 3, 1, 0, 0, 0, 0, berlin),
+			next:     time.Date(2026, 4, 1, 0, 0, 0, 0, berlin),
+		},
+		{
+			interval: "3mo",
+			timezone: "UTC",
+			t:        time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC),
+			start:    time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+			next:     time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			interval: "1y",
+			timezone: "UTC",
+			t:        time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC),
+			start:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			next:     time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range testCases {
+		c, err := newCalendarInterval(tc.interval, tc.timezone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.start(tc.t); !got.Equal(tc.start) {
+			t.Errorf("unexpected start of %s interval for %v: got %v exp %v", tc.interval, tc.t, got, tc.start)
+		}
+		if got := c.next(tc.t); !got.Equal(tc.next) {
+			t.Errorf("unexpected next %s interval for %v: got %v exp %v", tc.interval, tc.t, got, tc.next)
+		}
+	}
+}
@@ -52,10 +52,15 @@ const (
 	alertsPath        = basePath + "/alerts"
 	topicsPath        = alertsPath + "/topics"
 	topicEventsPath   = "events"
+	topicEventAckPath = "ack"
 	topicHandlersPath = "handlers"
+	silencesPath      = alertsPath + "/silences"
+	historyPath       = alertsPath + "/history"
+	inhibitionsPath   = alertsPath + "/inhibitions"
 	storagePath       = basePath + "/storage"
 	storesPath        = storagePath + "/stores"
 	backupPath        = storagePath + "/backup"
+	calendarsPath     = basePath + "/calendars"
 )
 
 type UserType int
@@ -1069,6 +1074,9 @@ func (c *Client) TopicEventsLink(topic string) Link {
 func (c *Client) TopicEventLink(topic, event string) Link {
 	return Link{Relation: Self, Href: path.Join(topicsPath, topic, topicEventsPath, event)}
 }
+func (c *Client) TopicEventAckLink(topic, event string) Link {
+	return Link{Relation: Self, Href: path.Join(topicsPath, topic, topicEventsPath, event, topicEventAckPath)}
+}
 
 func (c *Client) TopicHandlersLink(topic string) Link {
 	return Link{Relation: Self, Href: path.Join(topicsPath, topic, topicHandlersPath)}
@@ -2221,6 +2229,9 @@ type EventState struct {
 	Time     time.Time `json:"time"`
 	Duration Duration  `json:"duration"`
 	Level    string    `json:"level"`
+	Acked    bool      `json:"acked"`
+	AckedBy  string    `json:"acked-by"`
+	AckedAt  time.Time `json:"acked-at"`
 }
 
 // TopicEvent retrieves details for a single event of a topic
@@ -2243,6 +2254,37 @@ func (c *Client) TopicEvent(link Link) (TopicEvent, error) {
 	return e, err
 }
 
+type TopicEventAckOptions struct {
+	Author string `json:"author"`
+}
+
+// AckTopicEvent acknowledges the current state of an event.
+// The handlers of the topic are not called for the event until its level changes.
+func (c *Client) AckTopicEvent(link Link, opt TopicEventAckOptions) (TopicEvent, error) {
+	e := TopicEvent{}
+	if link.Href == "" {
+		return e, fmt.Errorf("invalid link %v", link)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	err := enc.Encode(opt)
+	if err != nil {
+		return e, err
+	}
+
+	u := *c.url
+	u.Path = link.Href
+
+	req, err := http.NewRequest("POST", u.String(), &buf)
+	if err != nil {
+		return e, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = c.Do(req, &e, http.StatusOK)
+	return e, err
+}
+
 type ListTopicEventsOptions struct {
 	MinLevel string
 }
@@ -2454,6 +2496,525 @@ func (c *Client) ListTopicHandlers(link Link, opt *ListTopicHandlersOptions) (To
 	return handlers, nil
 }
 
+type AlertHistoryEntry struct {
+	Topic         string            `json:"topic"`
+	ID            string            `json:"id"`
+	Time          time.Time         `json:"time"`
+	Level         string            `json:"level"`
+	PreviousLevel string            `json:"previous-level"`
+	Message       string            `json:"message"`
+	Details       string            `json:"details"`
+	Tags          map[string]string `json:"tags"`
+}
+
+type AlertHistory struct {
+	Link    Link                `json:"link"`
+	Entries []AlertHistoryEntry `json:"entries"`
+}
+
+type AlertHistoryOptions struct {
+	// Glob patterns matching the topics and event IDs.
+	Topic string
+	Event string
+	// Tags the events must have.
+	Tags map[string]string
+	// Time range of the entries, zero times are unbounded.
+	Start time.Time
+	Stop  time.Time
+	// Maximum number of entries, the most recent entries are returned.
+	Limit int
+}
+
+func (o *AlertHistoryOptions) Default() {}
+
+func (o *AlertHistoryOptions) Values() *url.Values {
+	v := &url.Values{}
+	if o.Topic != "" {
+		v.Set("topic", o.Topic)
+	}
+	if o.Event != "" {
+		v.Set("event", o.Event)
+	}
+	for k, t := range o.Tags {
+		v.Add("tag", k+"="+t)
+	}
+	if !o.Start.IsZero() {
+		v.Set("start", o.Start.Format(time.RFC3339Nano))
+	}
+	if !o.Stop.IsZero() {
+		v.Set("stop", o.Stop.Format(time.RFC3339Nano))
+	}
+	if o.Limit > 0 {
+		v.Set("limit", strconv.Itoa(o.Limit))
+	}
+	return v
+}
+
+// AlertHistory returns the level changes of the events, sorted by time.
+func (c *Client) AlertHistory(opt *AlertHistoryOptions) (AlertHistory, error) {
+	h := AlertHistory{}
+	if opt == nil {
+		opt = new(AlertHistoryOptions)
+	}
+	opt.Default()
+
+	u := *c.url
+	u.Path = historyPath
+	u.RawQuery = opt.Values().Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return h, err
+	}
+
+	_, err = c.Do(req, &h, http.StatusOK)
+	return h, err
+}
+
+func (c *Client) SilenceLink(id string) Link {
+	return Link{Relation: Self, Href: path.Join(silencesPath, id)}
+}
+
+type TagMatcher struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+	Regex bool   `json:"regex" yaml:"regex"`
+}
+
+type Silence struct {
+	Link      Link         `json:"link"`
+	ID        string       `json:"id"`
+	Topic     string       `json:"topic"`
+	Matchers  []TagMatcher `json:"matchers"`
+	Author    string       `json:"author"`
+	Comment   string       `json:"comment"`
+	CreatedAt time.Time    `json:"created-at"`
+	ExpiresAt time.Time    `json:"expires-at"`
+}
+
+type Silences struct {
+	Link     Link      `json:"link"`
+	Silences []Silence `json:"silences"`
+}
+
+type SilenceOptions struct {
+	ID       string       `json:"id,omitempty" yaml:"id"`
+	Topic    string       `json:"topic" yaml:"topic"`
+	Matchers []TagMatcher `json:"matchers" yaml:"matchers"`
+	Author   string       `json:"author" yaml:"author"`
+	Comment  string       `json:"comment" yaml:"comment"`
+	// ExpiresAt is the time at which the silence expires.
+	ExpiresAt time.Time `json:"expires-at,omitempty" yaml:"expires-at"`
+	// Duration is used to compute the expiry from the current time when ExpiresAt is not set.
+	Duration Duration `json:"duration,omitempty" yaml:"duration"`
+}
+
+// CreateSilence creates a new silence.
+// Errors if the silence already exists.
+func (c *Client) CreateSilence(opt SilenceOptions) (Silence, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	err := enc.Encode(opt)
+	if err != nil {
+		return Silence{}, err
+	}
+
+	u := *c.url
+	u.Path = silencesPath
+
+	req, err := http.NewRequest("POST", u.String(), &buf)
+	if err != nil {
+		return Silence{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	s := Silence{}
+	_, err = c.Do(req, &s, http.StatusOK)
+	return s, err
+}
+
+// Silence retrieves a silence.
+// Errors if no silence exists.
+func (c *Client) Silence(link Link) (Silence, error) {
+	s := Silence{}
+	if link.Href == "" {
+		return s, fmt.Errorf("invalid link %v", link)
+	}
+
+	u := *c.url
+	u.Path = link.Href
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return s, err
+	}
+
+	_, err = c.Do(req, &s, http.StatusOK)
+	return s, err
+}
+
+// DeleteSilence deletes a silence.
+func (c *Client) DeleteSilence(link Link) error {
+	if link.Href == "" {
+		return fmt.Errorf("invalid link %v", link)
+	}
+	u := *c.url
+	u.Path = link.Href
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil, http.StatusNoContent)
+	return err
+}
+
+type ListSilencesOptions struct {
+	Pattern string
+}
+
+func (o *ListSilencesOptions) Default() {}
+
+func (o *ListSilencesOptions) Values() *url.Values {
+	v := &url.Values{}
+	v.Set("pattern", o.Pattern)
+	return v
+}
+
+func (c *Client) ListSilences(opt *ListSilencesOptions) (Silences, error) {
+	silences := Silences{}
+	if opt == nil {
+		opt = new(ListSilencesOptions)
+	}
+	opt.Default()
+
+	u := *c.url
+	u.Path = silencesPath
+	u.RawQuery = opt.Values().Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return silences, err
+	}
+
+	_, err = c.Do(req, &silences, http.StatusOK)
+	return silences, err
+}
+
+func (c *Client) InhibitionLink(id string) Link {
+	return Link{Relation: Self, Href: path.Join(inhibitionsPath, id)}
+}
+
+// InhibitionMatch selects alerts by category and tags.
+type InhibitionMatch struct {
+	Category string       `json:"category" yaml:"category"`
+	Matchers []TagMatcher `json:"matchers" yaml:"matchers"`
+}
+
+type Inhibition struct {
+	Link   Link            `json:"link"`
+	ID     string          `json:"id"`
+	Source InhibitionMatch `json:"source"`
+	Target InhibitionMatch `json:"target"`
+	Equal  []string        `json:"equal"`
+}
+
+type Inhibitions struct {
+	Link        Link         `json:"link"`
+	Inhibitions []Inhibition `json:"inhibitions"`
+}
+
+type InhibitionOptions struct {
+	ID string `json:"id" yaml:"id"`
+	// Source selects the alerts that inhibit the target alerts while they are not OK.
+	Source InhibitionMatch `json:"source" yaml:"source"`
+	// Target selects the alerts that are inhibited.
+	Target InhibitionMatch `json:"target" yaml:"target"`
+	// Equal is the tags that must have the same values in the source and target alerts.
+	Equal []string `json:"equal" yaml:"equal"`
+}
+
+// CreateInhibition creates a new inhibition.
+// Errors if the inhibition already exists.
+func (c *Client) CreateInhibition(opt InhibitionOptions) (Inhibition, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	err := enc.Encode(opt)
+	if err != nil {
+		return Inhibition{}, err
+	}
+
+	u := *c.url
+	u.Path = inhibitionsPath
+
+	req, err := http.NewRequest("POST", u.String(), &buf)
+	if err != nil {
+		return Inhibition{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	i := Inhibition{}
+	_, err = c.Do(req, &i, http.StatusOK)
+	return i, err
+}
+
+// ReplaceInhibition replaces an existing inhibition, with the new definition.
+func (c *Client) ReplaceInhibition(link Link, opt InhibitionOptions) (Inhibition, error) {
+	i := Inhibition{}
+	if link.Href == "" {
+		return i, fmt.Errorf("invalid link %v", link)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	err := enc.Encode(opt)
+	if err != nil {
+		return i, err
+	}
+
+	u := *c.url
+	u.Path = link.Href
+
+	req, err := http.NewRequest("PUT", u.String(), &buf)
+	if err != nil {
+		return i, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = c.Do(req, &i, http.StatusOK)
+	return i, err
+}
+
+// Inhibition retrieves an inhibition.
+// Errors if no inhibition exists.
+func (c *Client) Inhibition(link Link) (Inhibition, error) {
+	i := Inhibition{}
+	if link.Href == "" {
+		return i, fmt.Errorf("invalid link %v", link)
+	}
+
+	u := *c.url
+	u.Path = link.Href
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return i, err
+	}
+
+	_, err = c.Do(req, &i, http.StatusOK)
+	return i, err
+}
+
+// DeleteInhibition deletes an inhibition.
+func (c *Client) DeleteInhibition(link Link) error {
+	if link.Href == "" {
+		return fmt.Errorf("invalid link %v", link)
+	}
+	u := *c.url
+	u.Path = link.Href
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil, http.StatusNoContent)
+	return err
+}
+
+type ListInhibitionsOptions struct {
+	Pattern string
+}
+
+func (o *ListInhibitionsOptions) Default() {}
+
+func (o *ListInhibitionsOptions) Values() *url.Values {
+	v := &url.Values{}
+	v.Set("pattern", o.Pattern)
+	return v
+}
+
+func (c *Client) ListInhibitions(opt *ListInhibitionsOptions) (Inhibitions, error) {
+	inhibitions := Inhibitions{}
+	if opt == nil {
+		opt = new(ListInhibitionsOptions)
+	}
+	opt.Default()
+
+	u := *c.url
+	u.Path = inhibitionsPath
+	u.RawQuery = opt.Values().Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return inhibitions, err
+	}
+
+	_, err = c.Do(req, &inhibitions, http.StatusOK)
+	return inhibitions, err
+}
+
+type CalendarWindow struct {
+	Cron     string   `json:"cron"`
+	Duration Duration `json:"duration"`
+}
+
+type Calendar struct {
+	Link     Link             `json:"link"`
+	Name     string           `json:"name"`
+	Timezone string           `json:"timezone"`
+	Windows  []CalendarWindow `json:"windows"`
+	Holidays []string         `json:"holidays"`
+	// Configured calendars are defined in the configuration file and cannot be modified.
+	Configured bool `json:"configured"`
+}
+
+type CalendarOptions struct {
+	Name     string           `json:"name"`
+	Timezone string           `json:"timezone"`
+	Windows  []CalendarWindow `json:"windows"`
+	Holidays []string         `json:"holidays"`
+}
+
+func (c *Client) CalendarLink(name string) Link {
+	return Link{Relation: Self, Href: path.Join(calendarsPath, name)}
+}
+
+// CreateCalendar creates a new calendar.
+// Errors if the calendar already exists.
+func (c *Client) CreateCalendar(opt CalendarOptions) (Calendar, error) {
+	calendar := Calendar{}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	err := enc.Encode(opt)
+	if err != nil {
+		return calendar, err
+	}
+
+	u := c.BaseURL()
+	u.Path = calendarsPath
+
+	req, err := http.NewRequest("POST", u.String(), &buf)
+	if err != nil {
+		return calendar, err
+	}
+
+	_, err = c.Do(req, &calendar, http.StatusOK)
+	return calendar, err
+}
+
+// ReplaceCalendar replaces an existing calendar, with the new definition.
+func (c *Client) ReplaceCalendar(link Link, opt CalendarOptions) (Calendar, error) {
+	calendar := Calendar{}
+	if link.Href == "" {
+		return calendar, fmt.Errorf("invalid link %v", link)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	err := enc.Encode(opt)
+	if err != nil {
+		return calendar, err
+	}
+
+	u := c.BaseURL()
+	u.Path = link.Href
+
+	req, err := http.NewRequest("PUT", u.String(), &buf)
+	if err != nil {
+		return calendar, err
+	}
+
+	_, err = c.Do(req, &calendar, http.StatusOK)
+	return calendar, err
+}
+
+// Calendar retrieves a calendar.
+// Errors if no calendar exists.
+func (c *Client) Calendar(link Link) (Calendar, error) {
+	calendar := Calendar{}
+	if link.Href == "" {
+		return calendar, fmt.Errorf("invalid link %v", link)
+	}
+
+	u := c.BaseURL()
+	u.Path = link.Href
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return calendar, err
+	}
+
+	_, err = c.Do(req, &calendar, http.StatusOK)
+	return calendar, err
+}
+
+// DeleteCalendar deletes a calendar.
+func (c *Client) DeleteCalendar(link Link) error {
+	if link.Href == "" {
+		return fmt.Errorf("invalid link %v", link)
+	}
+
+	u := c.BaseURL()
+	u.Path = link.Href
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil, http.StatusNoContent)
+	return err
+}
+
+type ListCalendarsOptions struct {
+	Pattern string
+	Offset  int
+	Limit   int
+}
+
+func (o *ListCalendarsOptions) Default() {
+	if o.Limit == 0 {
+		o.Limit = 100
+	}
+}
+
+func (o *ListCalendarsOptions) Values() *url.Values {
+	v := &url.Values{}
+	v.Set("pattern", o.Pattern)
+	v.Set("offset", strconv.FormatInt(int64(o.Offset), 10))
+	v.Set("limit", strconv.FormatInt(int64(o.Limit), 10))
+	return v
+}
+
+// ListCalendars returns the calendars, including the configured calendars.
+func (c *Client) ListCalendars(opt *ListCalendarsOptions) ([]Calendar, error) {
+	if opt == nil {
+		opt = new(ListCalendarsOptions)
+	}
+	opt.Default()
+	u := c.BaseURL()
+	u.Path = calendarsPath
+	u.RawQuery = opt.Values().Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response type
+	type response struct {
+		Calendars []Calendar `json:"calendars"`
+	}
+
+	r := &response{}
+
+	_, err = c.Do(req, r, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return r.Calendars, nil
+}
+
 type StorageList struct {
 	Link    Link      `json:"link"`
 	Storage []Storage `json:"storage"`
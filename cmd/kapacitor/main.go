@@ -68,6 +68,7 @@ Commands:
 	show-template         Display detailed information about a template.
 	show-topic-handler    Display detailed information about an alert handler for a topic.
 	show-topic            Display detailed information about an alert topic.
+	alert-history         Display the level changes of alert events.
 	flux                  Flux task information and management
 	backup                Backup the Kapacitor database.
 	level                 Sets the logging level on the kapacitord server.
@@ -194,6 +195,10 @@ func main() {
 	case "show-topic":
 		commandArgs = args
 		commandF = doShowTopic
+	case "alert-history":
+		alertHistoryFlags.Parse(args)
+		commandArgs = alertHistoryFlags.Args()
+		commandF = doAlertHistory
 	case "flux":
 		commandArgs = args
 		commandF = doFluxTasks(url, skipSSL)
@@ -233,6 +238,7 @@ func init() {
 	defineFlags.Usage = defineUsage
 	defineTemplateFlags.Usage = defineTemplateUsage
 	showFlags.Usage = showUsage
+	alertHistoryFlags.Usage = alertHistoryUsage
 
 	recordStreamFlags.Usage = recordStreamUsage
 	recordBatchFlags.Usage = recordBatchUsage
@@ -289,6 +295,8 @@ func doHelp(args []string) error {
 			showTopicHandlerUsage()
 		case "show-topic":
 			showTopicUsage()
+		case "alert-history":
+			alertHistoryFlags.Usage()
 		case "flux":
 			app := createFluxTaskApp("", false)
 			app.Run([]string{"", "-h"})
@@ -1574,6 +1582,18 @@ func showTopicUsage() {
 	fmt.Fprintln(os.Stderr, u)
 }
 
+// ackedBy describes the acknowledgement of an event state.
+func ackedBy(s client.EventState) string {
+	switch {
+	case !s.Acked:
+		return ""
+	case s.AckedBy == "":
+		return "yes"
+	default:
+		return s.AckedBy
+	}
+}
+
 type topicEvents []client.TopicEvent
 
 func (t topicEvents) Len() int               { return len(t) }
@@ -1617,15 +1637,129 @@ func doShowTopic(args []string) error {
 		handlerIDs[i] = h.ID
 	}
 
-	outFmt := fmt.Sprintf("%%-%ds%%-9s%%-%ds%%-23s\n", maxEvent+1, maxMessage+1)
+	outFmt := fmt.Sprintf("%%-%ds%%-9s%%-%ds%%-23s%%s\n", maxEvent+1, maxMessage+1)
 	fmt.Println("ID:", topic.ID)
 	fmt.Println("Level:", topic.Level)
 	fmt.Println("Collected:", topic.Collected)
 	fmt.Printf("Handlers: [%s]\n", strings.Join(handlerIDs, ", "))
 	fmt.Println("Events:")
-	fmt.Printf(outFmt, "Event", "Level", "Message", "Date")
+	fmt.Printf(outFmt, "Event", "Level", "Message", "Date", "Acked")
 	for _, e := range te.Events {
-		fmt.Printf(outFmt, e.ID, e.State.Level, e.State.Message, e.State.Time.Local().Format(time.RFC822))
+		fmt.Printf(outFmt, e.ID, e.State.Level, e.State.Message, e.State.Time.Local().Format(time.RFC822), ackedBy(e.State))
+	}
+	return nil
+}
+
+// Alert History
+var (
+	alertHistoryFlags = flag.NewFlagSet("alert-history", flag.ExitOnError)
+	ahTopic           = alertHistoryFlags.String("topic", "", "Optional pattern matching the topic IDs.")
+	ahEvent           = alertHistoryFlags.String("event", "", "Optional pattern matching the event IDs.")
+	ahStart           = alertHistoryFlags.String("start", "", "Optional start time of the history. Format is RFC3339Nano.")
+	ahStop            = alertHistoryFlags.String("stop", "", "Optional stop time of the history. Format is RFC3339Nano.")
+	ahPast            = alertHistoryFlags.String("past", "", "Optional duration of the history before the stop time or now. Cannot be used with start.")
+	ahLimit           = alertHistoryFlags.Int("limit", 100, "Maximum number of entries, the most recent entries are displayed. 0 for no limit.")
+	ahTags            = make(tags)
+)
+
+func init() {
+	alertHistoryFlags.Var(&ahTags, "tag", `A tag of the events of the form name=value. The flag can be specified multiple times.`)
+}
+
+type tags map[string]string
+
+func (t *tags) String() string {
+	return fmt.Sprint(*t)
+}
+
+func (t *tags) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return fmt.Errorf("invalid tag %q, it must be in the form name=value", value)
+	}
+	(*t)[kv[0]] = kv[1]
+	return nil
+}
+
+func alertHistoryUsage() {
+	var u = `Usage: kapacitor alert-history [options]
+
+	Display the level changes of the alert events, sorted by time.
+
+Examples:
+
+	$ kapacitor alert-history -topic 'main:*' -past 24h
+
+		Display the level changes of the last 24h of the topics of the main task.
+
+	$ kapacitor alert-history -event 'cpu:*' -tag host=serverA -start 2026-10-01T00:00:00Z -stop 2026-10-02T00:00:00Z
+
+		Display the level changes of the cpu events of serverA on the 1st of October.
+
+Options:
+`
+	fmt.Fprintln(os.Stderr, u)
+	alertHistoryFlags.PrintDefaults()
+}
+
+func doAlertHistory(args []string) error {
+	if len(args) != 0 {
+		alertHistoryUsage()
+		os.Exit(2)
+	}
+	if *ahStart != "" && *ahPast != "" {
+		alertHistoryUsage()
+		return errors.New("cannot set both start and past flags.")
+	}
+	opt := &client.AlertHistoryOptions{
+		Topic: *ahTopic,
+		Event: *ahEvent,
+		Tags:  ahTags,
+		Limit: *ahLimit,
+	}
+	var err error
+	if *ahStart != "" {
+		opt.Start, err = time.Parse(time.RFC3339Nano, *ahStart)
+		if err != nil {
+			return err
+		}
+	}
+	if *ahStop != "" {
+		opt.Stop, err = time.Parse(time.RFC3339Nano, *ahStop)
+		if err != nil {
+			return err
+		}
+	}
+	if *ahPast != "" {
+		past, err := influxql.ParseDuration(*ahPast)
+		if err != nil {
+			return err
+		}
+		stop := opt.Stop
+		if stop.IsZero() {
+			stop = time.Now()
+		}
+		opt.Start = stop.Add(-1 * past)
+	}
+
+	history, err := kCli.AlertHistory(opt)
+	if err != nil {
+		return err
+	}
+	maxTopic := 5 // len("Topic")
+	maxEvent := 5 // len("Event")
+	for _, e := range history.Entries {
+		if l := len(e.Topic); l > maxTopic {
+			maxTopic = l
+		}
+		if l := len(e.ID); l > maxEvent {
+			maxEvent = l
+		}
+	}
+	outFmt := fmt.Sprintf("%%-23s%%-%ds%%-%ds%%-9s%%-9s%%s\n", maxTopic+1, maxEvent+1)
+	fmt.Printf(outFmt, "Date", "Topic", "Event", "Level", "Previous", "Message")
+	for _, e := range history.Entries {
+		fmt.Printf(outFmt, e.Time.Local().Format(time.RFC822), e.Topic, e.ID, e.Level, e.PreviousLevel, e.Message)
 	}
 	return nil
 }
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/influxdata/kapacitor/client/v1"
+)
+
+const alertHistoryResponse = `{
+	"link": {"rel": "self", "href": "/kapacitor/v1/alerts/history"},
+	"entries": [
+		{
+			"topic": "main:cpu:alert2",
+			"id": "cpu:serverA",
+			"time": "2026-10-01T10:00:00Z",
+			"level": "WARNING",
+			"previous-level": "OK",
+			"message": "cpu:serverA is WARNING",
+			"details": "",
+			"tags": {"host": "serverA"}
+		},
+		{
+			"topic": "main:cpu:alert2",
+			"id": "cpu:serverA",
+			"time": "2026-10-01T10:05:00Z",
+			"level": "OK",
+			"previous-level": "WARNING",
+			"message": "cpu:serverA is OK",
+			"details": "",
+			"tags": {"host": "serverA"}
+		}
+	]
+}`
+
+func TestAlertHistory(t *testing.T) {
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/kapacitor/v1/alerts/history" {
+			t.Errorf("unexpected path: got %s", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(alertHistoryResponse))
+	}))
+	defer ts.Close()
+
+	cli, err := client.New(client.Config{URL: ts.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kCli = cli
+	defer func() { kCli = nil }()
+
+	local := time.Local
+	time.Local = time.UTC
+	defer func() { time.Local = local }()
+
+	args := []string{
+		"-topic", "main:*",
+		"-event", "cpu:*",
+		"-tag", "host=serverA",
+		"-stop", "2026-10-02T00:00:00Z",
+		"-past", "1d",
+		"-limit", "10",
+	}
+	if err := alertHistoryFlags.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	defer resetAlertHistoryFlags(t)
+
+	out, err := captureStdout(func() error {
+		return doAlertHistory(alertHistoryFlags.Args())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expQuery := "event=cpu%3A%2A&limit=10&start=2026-10-01T00%3A00%3A00Z&stop=2026-10-02T00%3A00%3A00Z&tag=host%3DserverA&topic=main%3A%2A"
+	if query != expQuery {
+		t.Errorf("unexpected query:\ngot\n%s\nexp\n%s\n", query, expQuery)
+	}
+	expOut := `Date                   Topic           Event       Level    Previous Message
+01 Oct 26 10:00 UTC    main:cpu:alert2 cpu:serverA WARNING  OK       cpu:serverA is WARNING
+01 Oct 26 10:05 UTC    main:cpu:alert2 cpu:serverA OK       WARNING  cpu:serverA is OK
+`
+	if out != expOut {
+		t.Errorf("unexpected output:\ngot\n%s\nexp\n%s\n", out, expOut)
+	}
+}
+
+func TestAlertHistory_StartAndPast(t *testing.T) {
+	if err := alertHistoryFlags.Parse([]string{"-start", "2026-10-01T00:00:00Z", "-past", "1h"}); err != nil {
+		t.Fatal(err)
+	}
+	defer resetAlertHistoryFlags(t)
+
+	// Discard the usage
+	stderr := os.Stderr
+	os.Stderr, _ = os.Open(os.DevNull)
+	defer func() { os.Stderr = stderr }()
+
+	if err := doAlertHistory(alertHistoryFlags.Args()); err == nil {
+		t.Error("expected error setting both the start and past flags")
+	}
+}
+
+func resetAlertHistoryFlags(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"topic", "event", "start", "stop", "past"} {
+		if err := alertHistoryFlags.Set(name, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := alertHistoryFlags.Set("limit", "100"); err != nil {
+		t.Fatal(err)
+	}
+	for k := range ahTags {
+		delete(ahTags, k)
+	}
+}
+
+// captureStdout returns what f writes to the standard output.
+func captureStdout(f func() error) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan []byte)
+	go func() {
+		b, _ := ioutil.ReadAll(r)
+		done <- b
+	}()
+	err = f()
+	os.Stdout = stdout
+	w.Close()
+	out := <-done
+	r.Close()
+	return string(out), err
+}
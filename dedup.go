@@ -0,0 +1,163 @@
+package kapacitor
+
+import (
+	"container/heap"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/expvar"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+const (
+	statsDuplicatesDropped = "duplicates_dropped"
+)
+
+type DedupNode struct {
+	node
+	d *pipeline.DedupNode
+
+	// seen contains the keys of the points within the horizon.
+	seen map[string]bool
+	// expiry orders the seen keys by point time.
+	expiry dedupExpiry
+	// max is the most recent point time.
+	max time.Time
+
+	duplicatesDropped *expvar.Int
+}
+
+// Create a new DedupNode which drops duplicate points.
+func newDedupNode(et *ExecutingTask, n *pipeline.DedupNode, d NodeDiagnostic) (*DedupNode, error) {
+	dn := &DedupNode{
+		node:              node{Node: n, et: et, diag: d},
+		d:                 n,
+		seen:              make(map[string]bool),
+		duplicatesDropped: new(expvar.Int),
+	}
+	dn.node.runF = dn.runDedup
+	return dn, nil
+}
+
+func (n *DedupNode) runDedup(snapshot []byte) error {
+	n.statMap.Set(statsDuplicatesDropped, n.duplicatesDropped)
+
+	consumer := edge.NewConsumerWithReceiver(
+		n.ins[0],
+		edge.NewReceiverFromForwardReceiverWithStats(
+			n.outs,
+			edge.NewTimedForwardReceiver(n.timer, n),
+		),
+	)
+	return consumer.Consume()
+}
+
+func (n *DedupNode) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	return begin, nil
+}
+
+func (n *DedupNode) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	return bp, nil
+}
+
+func (n *DedupNode) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	return end, nil
+}
+
+func (n *DedupNode) Point(p edge.PointMessage) (edge.Message, error) {
+	if n.isDuplicate(p) {
+		n.duplicatesDropped.Add(1)
+		return nil, nil
+	}
+	return p, nil
+}
+
+func (n *DedupNode) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	return b, nil
+}
+
+func (n *DedupNode) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	return d, nil
+}
+
+func (n *DedupNode) Done() {}
+
+// isDuplicate reports whether the point has already been seen and remembers it otherwise.
+func (n *DedupNode) isDuplicate(p edge.PointMessage) bool {
+	t := p.Time()
+	if t.After(n.max) {
+		n.max = t
+		n.expire()
+	}
+	if t.Before(n.max.Add(-n.d.Horizon)) {
+		// The point is beyond the horizon and cannot be checked.
+		return false
+	}
+	key := n.key(p)
+	if n.seen[key] {
+		return true
+	}
+	n.seen[key] = true
+	heap.Push(&n.expiry, dedupKey{key: key, time: t})
+	return false
+}
+
+// expire forgets all keys that are beyond the horizon.
+func (n *DedupNode) expire() {
+	horizon := n.max.Add(-n.d.Horizon)
+	for len(n.expiry) > 0 && n.expiry[0].time.Before(horizon) {
+		k := heap.Pop(&n.expiry).(dedupKey)
+		delete(n.seen, k.key)
+	}
+}
+
+// key returns the identity of the point, its series and either the values of the configured fields or its time.
+func (n *DedupNode) key(p edge.PointMessage) string {
+	tags := p.Tags()
+	var b strings.Builder
+	b.WriteString(string(models.ToGroupID(p.Name(), tags, models.Dimensions{
+		ByName:   true,
+		TagNames: models.SortedKeys(tags),
+	})))
+	if len(n.d.FieldNames) == 0 {
+		b.WriteByte('\n')
+		b.WriteString(strconv.FormatInt(p.Time().UnixNano(), 10))
+		return b.String()
+	}
+	fields := p.Fields()
+	for _, f := range n.d.FieldNames {
+		b.WriteByte('\n')
+		if v, ok := fields[f]; ok {
+			fmt.Fprintf(&b, "%T:%v", v, v)
+		}
+	}
+	return b.String()
+}
+
+type dedupKey struct {
+	key  string
+	time time.Time
+}
+
+// dedupExpiry is a min heap of keys by time.
+type dedupExpiry []dedupKey
+
+func (h dedupExpiry) Len() int           { return len(h) }
+func (h dedupExpiry) Less(i, j int) bool { return h[i].time.Before(h[j].time) }
+func (h dedupExpiry) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *dedupExpiry) Push(x interface{}) {
+	*h = append(*h, x.(dedupKey))
+}
+
+func (h *dedupExpiry) Pop() interface{} {
+	old := *h
+	l := len(old)
+	x := old[l-1]
+	*h = old[:l-1]
+	return x
+}
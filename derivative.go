@@ -3,6 +3,7 @@ package kapacitor
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/influxdata/kapacitor/edge"
@@ -14,20 +15,31 @@ import (
 type DerivativeNode struct {
 	node
 	d *pipeline.DerivativeNode
+
+	// mu protects the groups so they can be snapshotted.
+	mu       sync.Mutex
+	groups   map[models.GroupID]*derivativeGroup
+	restored map[models.GroupID]pointSnapshot
 }
 
 // Create a new derivative node.
 func newDerivativeNode(et *ExecutingTask, n *pipeline.DerivativeNode, d NodeDiagnostic) (*DerivativeNode, error) {
 	dn := &DerivativeNode{
-		node: node{Node: n, et: et, diag: d},
-		d:    n,
+		node:   node{Node: n, et: et, diag: d},
+		d:      n,
+		groups: make(map[models.GroupID]*derivativeGroup),
 	}
 	// Create stateful expressions
 	dn.node.runF = dn.runDerivative
 	return dn, nil
 }
 
-func (n *DerivativeNode) runDerivative([]byte) error {
+func (n *DerivativeNode) runDerivative(snapshot []byte) error {
+	if snapshot != nil {
+		if err := n.restore(snapshot); err != nil {
+			n.diag.Error("failed to restore derivative state from snapshot", err)
+		}
+	}
 	consumer := edge.NewGroupedConsumer(
 		n.ins[0],
 		n,
@@ -37,12 +49,43 @@ func (n *DerivativeNode) runDerivative([]byte) error {
 }
 
 func (n *DerivativeNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.Receiver, error) {
+	g := n.newGroup()
+	n.mu.Lock()
+	if s, ok := n.restored[group.ID]; ok {
+		g.previous = s.batchPointMessage()
+		delete(n.restored, group.ID)
+	}
+	n.groups[group.ID] = g
+	n.mu.Unlock()
 	return edge.NewReceiverFromForwardReceiverWithStats(
 		n.outs,
-		edge.NewTimedForwardReceiver(n.timer, n.newGroup()),
+		edge.NewTimedForwardReceiver(n.timer, edge.NewLockedForwardReceiver(&n.mu, g)),
 	), nil
 }
 
+func (n *DerivativeNode) snapshot() ([]byte, error) {
+	n.mu.Lock()
+	previous := make(map[models.GroupID]pointSnapshot, len(n.groups))
+	for id, g := range n.groups {
+		if g.previous != nil {
+			previous[id] = newBatchPointSnapshot(g.previous)
+		}
+	}
+	n.mu.Unlock()
+	return encodeNodeSnapshot(previous)
+}
+
+func (n *DerivativeNode) restore(data []byte) error {
+	previous := make(map[models.GroupID]pointSnapshot)
+	if err := decodeNodeSnapshot(data, &previous); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.restored = previous
+	n.mu.Unlock()
+	return nil
+}
+
 func (n *DerivativeNode) newGroup() *derivativeGroup {
 	return &derivativeGroup{
 		n: n,
@@ -117,6 +160,7 @@ func (g *derivativeGroup) Barrier(b edge.BarrierMessage) (edge.Message, error) {
 	return b, nil
 }
 func (g *derivativeGroup) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	delete(g.n.groups, d.GroupID())
 	return d, nil
 }
 func (g *derivativeGroup) Done() {}
@@ -0,0 +1,234 @@
+package kapacitor
+
+import (
+	"math"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/expvar"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+const (
+	statsPointsFilled = "points_filled"
+)
+
+type FillNode struct {
+	node
+	f *pipeline.FillNode
+
+	filled *expvar.Int
+}
+
+// Create a new FillNode, which fills missing intervals with synthetic points.
+func newFillNode(et *ExecutingTask, n *pipeline.FillNode, d NodeDiagnostic) (*FillNode, error) {
+	fn := &FillNode{
+		node:   node{Node: n, et: et, diag: d},
+		f:      n,
+		filled: new(expvar.Int),
+	}
+	fn.node.runF = fn.runFill
+	return fn, nil
+}
+
+func (n *FillNode) runFill([]byte) error {
+	n.statMap.Set(statsPointsFilled, n.filled)
+	consumer := edge.NewGroupedConsumer(
+		n.ins[0],
+		n,
+	)
+	n.statMap.Set(statCardinalityGauge, consumer.CardinalityVar())
+	return consumer.Consume()
+}
+
+func (n *FillNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.Receiver, error) {
+	return &fillGroup{
+		n: n,
+	}, nil
+}
+
+type fillGroup struct {
+	n *FillNode
+
+	// the last real point
+	last           edge.FieldsTagsTimeGetter
+	name, db, rp   string
+	lastDimensions models.Dimensions
+
+	// the time of the last real or filled point
+	time time.Time
+}
+
+// fillPoint is a synthetic point.
+type fillPoint struct {
+	fields models.Fields
+	tags   models.Tags
+	time   time.Time
+}
+
+// fill returns the points to fill the missing intervals before until.
+// The next point is nil if it is not known yet.
+func (g *fillGroup) fill(until time.Time, next edge.FieldsTagsTimeGetter) []fillPoint {
+	f := g.n.f
+	if g.last == nil || (next == nil && f.FillMethod == pipeline.FillLinear) {
+		return nil
+	}
+	if f.FillMethod == pipeline.FillLinear && next.Time().Sub(g.last.Time()) > f.MaxGap {
+		// The gap is too large to interpolate
+		return nil
+	}
+	var tags models.Tags
+	var points []fillPoint
+	tolerance := f.Every / 2
+	for t := g.time.Add(f.Every); t.Add(tolerance).Before(until); t = t.Add(f.Every) {
+		if t.Sub(g.last.Time()) > f.MaxGap {
+			break
+		}
+		if tags == nil {
+			tags = g.last.Tags().Copy()
+			tags[f.Tag] = "true"
+		}
+		points = append(points, fillPoint{
+			fields: g.fields(t, next),
+			tags:   tags,
+			time:   t,
+		})
+		g.time = t
+	}
+	g.n.filled.Add(int64(len(points)))
+	return points
+}
+
+// fields returns the fields of a filled point at time t.
+func (g *fillGroup) fields(t time.Time, next edge.FieldsTagsTimeGetter) models.Fields {
+	f := g.n.f
+	previous := g.last.Fields()
+	switch f.FillMethod {
+	case pipeline.FillNull:
+		fields := make(models.Fields, len(previous))
+		for k := range previous {
+			fields[k] = nil
+		}
+		return fields
+	case pipeline.FillNumber:
+		fields := make(models.Fields, len(previous))
+		for k := range previous {
+			fields[k] = f.Number
+		}
+		return fields
+	case pipeline.FillLinear:
+		fields := make(models.Fields, len(previous))
+		frac := float64(t.Sub(g.last.Time())) / float64(next.Time().Sub(g.last.Time()))
+		for k, v := range previous {
+			fields[k] = v
+			a, ok := numToFloat(v)
+			if !ok {
+				continue
+			}
+			b, ok := numToFloat(next.Fields()[k])
+			if !ok {
+				continue
+			}
+			value := a + (b-a)*frac
+			_, aInt := v.(int64)
+			_, bInt := next.Fields()[k].(int64)
+			if aInt && bInt {
+				fields[k] = int64(math.Round(value))
+			} else {
+				fields[k] = value
+			}
+		}
+		return fields
+	default:
+		return previous
+	}
+}
+
+// update records p as the last real point.
+func (g *fillGroup) update(p edge.FieldsTagsTimeGetter) {
+	if g.last != nil && p.Time().Before(g.time) {
+		// Out of order points do not move the time back
+		return
+	}
+	g.last = p
+	g.time = p.Time()
+}
+
+func (g *fillGroup) forward(msg edge.Message) error {
+	g.n.timer.Pause()
+	err := edge.Forward(g.n.outs, msg)
+	g.n.timer.Resume()
+	return err
+}
+
+func (g *fillGroup) BeginBatch(begin edge.BeginBatchMessage) error {
+	g.n.timer.Start()
+	defer g.n.timer.Stop()
+	g.last = nil
+	begin = begin.ShallowCopy()
+	begin.SetSizeHint(0)
+	return g.forward(begin)
+}
+
+func (g *fillGroup) BatchPoint(bp edge.BatchPointMessage) error {
+	g.n.timer.Start()
+	defer g.n.timer.Stop()
+	for _, fp := range g.fill(bp.Time(), bp) {
+		if err := g.forward(edge.NewBatchPointMessage(fp.fields, fp.tags, fp.time)); err != nil {
+			return err
+		}
+	}
+	g.update(bp)
+	return g.forward(bp)
+}
+
+func (g *fillGroup) EndBatch(end edge.EndBatchMessage) error {
+	g.last = nil
+	return edge.Forward(g.n.outs, end)
+}
+
+func (g *fillGroup) Point(p edge.PointMessage) error {
+	g.n.timer.Start()
+	defer g.n.timer.Stop()
+	if err := g.forwardPoints(g.fill(p.Time(), p)); err != nil {
+		return err
+	}
+	g.update(p)
+	g.name = p.Name()
+	g.db = p.Database()
+	g.rp = p.RetentionPolicy()
+	g.lastDimensions = p.Dimensions()
+	return g.forward(p)
+}
+
+func (g *fillGroup) forwardPoints(points []fillPoint) error {
+	for _, fp := range points {
+		msg := edge.NewPointMessage(
+			g.name, g.db, g.rp,
+			g.lastDimensions,
+			fp.fields,
+			fp.tags,
+			fp.time,
+		)
+		if err := g.forward(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *fillGroup) Barrier(b edge.BarrierMessage) error {
+	g.n.timer.Start()
+	defer g.n.timer.Stop()
+	if err := g.forwardPoints(g.fill(b.Time(), nil)); err != nil {
+		return err
+	}
+	return g.forward(b)
+}
+
+func (g *fillGroup) DeleteGroup(d edge.DeleteGroupMessage) error {
+	return edge.Forward(g.n.outs, d)
+}
+
+func (g *fillGroup) Done() {}
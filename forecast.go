@@ -0,0 +1,261 @@
+package kapacitor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/keyvalue"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+type ForecastNode struct {
+	node
+	f *pipeline.ForecastNode
+
+	// mu protects the groups so they can be snapshotted.
+	mu       sync.Mutex
+	groups   map[models.GroupID]*forecastGroup
+	restored map[models.GroupID]holtWintersModel
+}
+
+// Create a new forecast node.
+func newForecastNode(et *ExecutingTask, n *pipeline.ForecastNode, d NodeDiagnostic) (*ForecastNode, error) {
+	fn := &ForecastNode{
+		node:   node{Node: n, et: et, diag: d},
+		f:      n,
+		groups: make(map[models.GroupID]*forecastGroup),
+	}
+	fn.node.runF = fn.runForecast
+	return fn, nil
+}
+
+func (n *ForecastNode) runForecast(snapshot []byte) error {
+	if snapshot != nil {
+		if err := n.restore(snapshot); err != nil {
+			n.diag.Error("failed to restore forecast models from snapshot", err)
+		}
+	}
+	consumer := edge.NewGroupedConsumer(
+		n.ins[0],
+		n,
+	)
+	n.statMap.Set(statCardinalityGauge, consumer.CardinalityVar())
+	return consumer.Consume()
+}
+
+func (n *ForecastNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.Receiver, error) {
+	g := &forecastGroup{
+		n:     n,
+		model: n.newModel(),
+	}
+	n.mu.Lock()
+	if s, ok := n.restored[group.ID]; ok {
+		g.model = s
+		delete(n.restored, group.ID)
+	}
+	n.groups[group.ID] = g
+	n.mu.Unlock()
+	return edge.NewReceiverFromForwardReceiverWithStats(
+		n.outs,
+		edge.NewTimedForwardReceiver(n.timer, edge.NewLockedForwardReceiver(&n.mu, g)),
+	), nil
+}
+
+func (n *ForecastNode) newModel() holtWintersModel {
+	m := holtWintersModel{}
+	if n.f.Seasonality > 0 {
+		m.Seasonal = make([]float64, n.f.Slots)
+	}
+	return m
+}
+
+func (n *ForecastNode) snapshot() ([]byte, error) {
+	n.mu.Lock()
+	states := make(map[models.GroupID]holtWintersModel, len(n.groups))
+	for id, g := range n.groups {
+		m := g.model
+		m.Seasonal = append([]float64(nil), m.Seasonal...)
+		states[id] = m
+	}
+	n.mu.Unlock()
+	return encodeNodeSnapshot(states)
+}
+
+func (n *ForecastNode) restore(data []byte) error {
+	groups := make(map[models.GroupID]holtWintersModel)
+	if err := decodeNodeSnapshot(data, &groups); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.restored = groups
+	n.mu.Unlock()
+	return nil
+}
+
+// slot returns the season slot of time t.
+func (n *ForecastNode) slot(t time.Time) int {
+	if n.f.Seasonality <= 0 {
+		return -1
+	}
+	offset := t.UnixNano() % int64(n.f.Seasonality)
+	if offset < 0 {
+		offset += int64(n.f.Seasonality)
+	}
+	return int(offset * n.f.Slots / int64(n.f.Seasonality))
+}
+
+type forecastGroup struct {
+	n     *ForecastNode
+	model holtWintersModel
+}
+
+func (g *forecastGroup) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	return begin, nil
+}
+
+func (g *forecastGroup) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	bp = bp.ShallowCopy()
+	if !g.forecast(bp) {
+		return nil, nil
+	}
+	return bp, nil
+}
+
+func (g *forecastGroup) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	return end, nil
+}
+
+func (g *forecastGroup) Point(p edge.PointMessage) (edge.Message, error) {
+	p = p.ShallowCopy()
+	if !g.forecast(p) {
+		return nil, nil
+	}
+	return p, nil
+}
+
+// forecast updates the model with p and adds the forecast fields to it.
+// Points without a numeric value for the field are dropped.
+func (g *forecastGroup) forecast(p edge.FieldsTagsTimeSetter) bool {
+	f := g.n.f
+	value, ok := numToFloat(p.Fields()[f.Field])
+	if !ok {
+		g.n.diag.Error("cannot forecast",
+			errors.New("field is missing or the wrong type"),
+			keyvalue.KV("field", f.Field),
+			keyvalue.KV("type", fmt.Sprintf("%T", p.Fields()[f.Field])),
+		)
+		return false
+	}
+	t := p.Time()
+	g.model.update(value, t, g.n.slot(t), f.Alpha, f.Beta, f.Gamma)
+
+	fields := p.Fields().Copy()
+	fields[f.ForecastAs] = g.model.forecast(t.Add(f.Horizon), g.n.slot(t.Add(f.Horizon)))
+	if f.ThresholdValue != nil {
+		ttt := g.timeToThreshold(value, t, *f.ThresholdValue)
+		if ttt >= 0 {
+			fields[f.TimeToThresholdAs] = float64(ttt) / float64(f.Unit)
+		} else {
+			fields[f.TimeToThresholdAs] = -1.0
+		}
+	}
+	p.SetFields(fields)
+	return true
+}
+
+// timeToThreshold returns the time from t until the forecast reaches the threshold,
+// or -1 if it is not reached within the horizon.
+func (g *forecastGroup) timeToThreshold(value float64, t time.Time, threshold float64) time.Duration {
+	f := g.n.f
+	if value == threshold {
+		return 0
+	}
+	rising := value < threshold
+	reached := func(v float64) bool {
+		if rising {
+			return v >= threshold
+		}
+		return v <= threshold
+	}
+	if f.Seasonality <= 0 {
+		// Linear trend, solve level + trend * d = threshold
+		if reached(g.model.Level) {
+			return 0
+		}
+		if g.model.Trend == 0 {
+			return -1
+		}
+		d := time.Duration((threshold - g.model.Level) / g.model.Trend * float64(time.Second))
+		if d < 0 || d > f.Horizon {
+			return -1
+		}
+		return d
+	}
+	// Step through the season slots until the threshold is reached
+	step := f.Seasonality / time.Duration(f.Slots)
+	for d := time.Duration(0); d <= f.Horizon; d += step {
+		at := t.Add(d)
+		if reached(g.model.forecast(at, g.n.slot(at))) {
+			return d
+		}
+	}
+	return -1
+}
+
+func (g *forecastGroup) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	return b, nil
+}
+func (g *forecastGroup) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	delete(g.n.groups, d.GroupID())
+	return d, nil
+}
+func (g *forecastGroup) Done() {}
+
+// holtWintersModel is an additive Holt-Winters model for irregularly spaced points.
+// The trend is per second and the seasonal component has an offset for each slot of a season.
+type holtWintersModel struct {
+	Level    float64
+	Trend    float64
+	Seasonal []float64
+	Time     time.Time
+	Count    int64
+}
+
+// update adds the value at time t in season slot to the model.
+func (m *holtWintersModel) update(value float64, t time.Time, slot int, alpha, beta, gamma float64) {
+	m.Count++
+	if m.Count == 1 {
+		m.Level = value
+		m.Time = t
+		return
+	}
+	dt := t.Sub(m.Time).Seconds()
+	if dt <= 0 {
+		// Out of order points do not update the trend.
+		return
+	}
+	var seasonal float64
+	if slot >= 0 {
+		seasonal = m.Seasonal[slot]
+	}
+	level := alpha*(value-seasonal) + (1-alpha)*(m.Level+m.Trend*dt)
+	m.Trend = beta*((level-m.Level)/dt) + (1-beta)*m.Trend
+	if slot >= 0 {
+		m.Seasonal[slot] = gamma*(value-level) + (1-gamma)*seasonal
+	}
+	m.Level = level
+	m.Time = t
+}
+
+// forecast returns the forecasted value at time t in season slot.
+func (m *holtWintersModel) forecast(t time.Time, slot int) float64 {
+	v := m.Level + m.Trend*t.Sub(m.Time).Seconds()
+	if slot >= 0 {
+		v += m.Seasonal[slot]
+	}
+	return v
+}
@@ -920,6 +920,187 @@ batch
 	testBatcherWithOutput(t, "TestBatch_SimpleMR", script, 30*time.Second, er, false)
 }
 
+func TestBatch_Fill(t *testing.T) {
+
+	var script = `
+batch
+	|query('''
+		SELECT "value"
+		FROM "telegraf"."default".cpu
+''')
+		.period(30s)
+		.every(30s)
+		.groupBy('host')
+	|where(lambda: "value" > 0)
+	|fill()
+		.every(10s)
+		.method(-1)
+	|httpOut('TestBatch_Fill')
+`
+
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "cpu",
+				Tags:    map[string]string{"host": "A"},
+				Columns: []string{"time", "value"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC), 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC), -1.0},
+					{time.Date(1971, 1, 1, 0, 0, 20, 0, time.UTC), -1.0},
+					{time.Date(1971, 1, 1, 0, 0, 30, 0, time.UTC), 2.0},
+				},
+			},
+		},
+	}
+
+	testBatcherWithOutput(t, "TestBatch_Fill", script, 30*time.Second, er, false)
+}
+
+func TestBatch_Increase(t *testing.T) {
+
+	var script = `
+batch
+	|query('''
+		SELECT "counter"
+		FROM "telegraf"."default".requests
+''')
+		.period(5m)
+		.every(5m)
+	|increase('counter')
+	|httpOut('TestBatch_Increase')
+`
+
+	// The increase of 240 over the 4m between the first and last point
+	// is extrapolated by 30s to each edge of the 5m period of the query.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "requests",
+				Columns: []string{"time", "increase"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 4, 30, 0, time.UTC), 300.0},
+				},
+			},
+		},
+	}
+
+	testBatcherWithOutput(t, "TestBatch_Increase", script, 5*time.Minute, er, false)
+}
+
+func TestBatch_TopK(t *testing.T) {
+
+	var script = `
+batch
+	|query('''
+		SELECT "usage"
+		FROM "telegraf"."default".cpu
+''')
+		.period(10s)
+		.every(10s)
+		.groupBy('host', 'cpu')
+	|topK(2, 'usage')
+		.by('host')
+		.method('sum')
+	|httpOut('TestBatch_TopK')
+`
+
+	tw := time.Date(1971, 1, 1, 0, 0, 25, 0, time.UTC)
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "cpu",
+				Columns: []string{"time", "cpu", "host", "rank", "usage"},
+				Values: [][]interface{}{
+					{tw, "cpu0", "C", 1.0, 110.0},
+					{tw, "cpu1", "A", 2.0, 100.0},
+				},
+			},
+		},
+	}
+
+	testBatcherWithOutput(t, "TestBatch_TopK", script, 30*time.Second, er, false)
+}
+
+func TestBatch_Pivot(t *testing.T) {
+
+	var script = `
+batch
+	|query('''
+		SELECT "value"
+		FROM "telegraf"."default".m
+''')
+		.period(10s)
+		.every(10s)
+		.groupBy('host')
+	|pivot()
+		.rowKey('host')
+		.columnKey('metric')
+	|httpOut('TestBatch_Pivot')
+`
+
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "m",
+				Tags:    map[string]string{"host": "A"},
+				Columns: []string{"time", "cpu", "mem"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC), 1.5, 1.75},
+					{time.Date(1971, 1, 1, 0, 0, 11, 0, time.UTC), 1.6, 1.7},
+				},
+			},
+			{
+				Name:    "m",
+				Tags:    map[string]string{"host": "B"},
+				Columns: []string{"time", "cpu", "mem"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC), 1.25, 1.5},
+					{time.Date(1971, 1, 1, 0, 0, 11, 0, time.UTC), 1.3, 1.45},
+				},
+			},
+		},
+	}
+
+	testBatcherWithOutput(t, "TestBatch_Pivot", script, 30*time.Second, er, true)
+}
+
+func TestBatch_Unpivot(t *testing.T) {
+
+	var script = `
+batch
+	|query('''
+		SELECT "cpu", "mem", "load"
+		FROM "telegraf"."default".m
+''')
+		.period(10s)
+		.every(10s)
+		.groupBy('host')
+	|unpivot()
+		.fields('cpu', 'mem')
+		.tag('metric')
+	|httpOut('TestBatch_Unpivot')
+`
+
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "m",
+				Tags:    map[string]string{"host": "A"},
+				Columns: []string{"time", "load", "metric", "value"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC), 2.0, "cpu", 1.5},
+					{time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC), 2.0, "mem", 1.75},
+					{time.Date(1971, 1, 1, 0, 0, 11, 0, time.UTC), 2.0, "cpu", 1.5},
+					{time.Date(1971, 1, 1, 0, 0, 11, 0, time.UTC), 2.0, "mem", 1.75},
+				},
+			},
+		},
+	}
+
+	testBatcherWithOutput(t, "TestBatch_Unpivot", script, 30*time.Second, er, false)
+}
+
 func TestBatch_Default(t *testing.T) {
 
 	var script = `
@@ -1582,6 +1582,102 @@ stream
 	testStreamerWithOutput(t, "TestStream_Window_FillPeriod_Aligned", script, 21*time.Second, er, false, nil)
 }
 
+func TestStream_Window_AllowedLateness(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('cpu')
+		.groupBy('host')
+		.allowedLateness(5s)
+	|window()
+		.period(10s)
+		.every(10s)
+		.align()
+		.allowedLateness(5s)
+	|count('value')
+	|httpOut('TestStream_Window_AllowedLateness')
+`
+
+	// The out of order point at 4s lands in the first window and the late point at 2s is dropped.
+	// The first window is emitted once the window has received a point past the end of the window and the allowed lateness.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "cpu",
+				Tags:    map[string]string{"host": "A"},
+				Columns: []string{"time", "count"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC),
+					3.0,
+				}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Window_AllowedLateness", script, 20*time.Second, er, false, nil)
+}
+
+func TestStream_Window_Session(t *testing.T) {
+	rows := make(chan models.Row, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := models.Result{}
+		if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+			t.Error(err)
+			return
+		}
+		for _, row := range result.Series {
+			rows <- *row
+		}
+	}))
+	defer ts.Close()
+
+	var script = `
+stream
+	|from()
+		.measurement('cpu')
+		.groupBy('host')
+	|window()
+		.sessionGap(5s)
+	|count('value')
+	|httpPost('` + ts.URL + `')
+`
+
+	testStreamerNoOutput(t, "TestStream_Window_Session", script, 25*time.Second, nil)
+	close(rows)
+
+	session := func(host string, sec, count int) models.Row {
+		return models.Row{
+			Name:    "cpu",
+			Tags:    map[string]string{"host": host},
+			Columns: []string{"time", "count"},
+			Values: [][]interface{}{{
+				time.Date(1971, 1, 1, 0, 0, sec, 0, time.UTC),
+				float64(count),
+			}},
+		}
+	}
+	// The point of B at 10s closes the sessions of both groups, including the session of the quiet group A.
+	// The point of B at 20s is more than the gap after the previous point and starts a new session.
+	// The open session is emitted when the task is done.
+	exp := []models.Row{
+		session("A", 2, 2),
+		session("B", 3, 2),
+		session("B", 14, 3),
+		session("B", 20, 1),
+	}
+	var got []models.Row
+	for row := range rows {
+		got = append(got, row)
+	}
+	// The sessions closed by the same point are emitted in any order.
+	if len(got) > 1 && got[0].Tags["host"] == "B" {
+		got[0], got[1] = got[1], got[0]
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected sessions:\ngot\n%+v\nexp\n%+v\n", got, exp)
+	}
+}
+
 func TestStream_Barrier_Idle_No_Data(t *testing.T) {
 	// Set up clock to current time - 22 seconds since we're going to send 21 data points
 	clock := clock.New(time.Now().UTC())
@@ -5204,6 +5300,68 @@ errorCounts
 	testStreamerWithOutput(t, "TestStream_Join_Fill", script, 13*time.Second, er, true, nil)
 }
 
+func TestStream_Join_Left(t *testing.T) {
+	rows := make(chan models.Row, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := models.Result{}
+		if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+			t.Error(err)
+			return
+		}
+		for _, row := range result.Series {
+			rows <- *row
+		}
+	}))
+	defer ts.Close()
+
+	var script = `
+var requests = stream
+	|from()
+		.measurement('requests')
+
+var errors = stream
+	|from()
+		.measurement('errors')
+
+requests
+	|join(errors)
+		.as('requests', 'errors')
+		.left()
+		.fillFor('errors', 0)
+		.timeout(10s)
+	|httpPost('` + ts.URL + `')
+`
+
+	testStreamerNoOutput(t, "TestStream_Join_Left", script, 15*time.Second, nil)
+	close(rows)
+
+	joined := func(sec int, requests, errors float64) models.Row {
+		return models.Row{
+			Name:    "requests",
+			Columns: []string{"time", "errors.value", "requests.value"},
+			Values: [][]interface{}{{
+				time.Date(1971, 1, 1, 0, 0, sec, 0, time.UTC),
+				errors,
+				requests,
+			}},
+		}
+	}
+	// The set at 0s times out and the set at 11s is emitted when the task is done, both are filled.
+	// The errors at 7s and 12s are dropped without requests.
+	exp := []models.Row{
+		joined(0, 1, 0),
+		joined(5, 2, 3),
+		joined(11, 5, 0),
+	}
+	var got []models.Row
+	for row := range rows {
+		got = append(got, row)
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected joined points:\ngot\n%+v\nexp\n%+v\n", got, exp)
+	}
+}
+
 func TestStream_JoinN(t *testing.T) {
 
 	var script = `
@@ -12775,6 +12933,802 @@ stream
 	testStreamerWithOutput(t, "TestStream_TopSelector", script, 10*time.Second, er, false, nil)
 }
 
+func TestStream_TopK(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('cpu')
+		.groupBy('host', 'cpu')
+	|window()
+		.period(10s)
+		.every(10s)
+		.align()
+	|topK(2, 'usage')
+		.by('host')
+	|httpOut('TestStream_TopK')
+`
+
+	// The last window is ranked once all groups have sent it,
+	// even though no window follows it.
+	tw := time.Date(1971, 1, 1, 0, 0, 29, 0, time.UTC)
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "cpu",
+				Columns: []string{"time", "cpu", "host", "rank", "usage"},
+				Values: [][]interface{}{
+					{tw, "cpu1", "A", 1.0, 95.0},
+					{tw, "cpu0", "C", 2.0, 70.0},
+				},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_TopK", script, 35*time.Second, er, false, nil)
+}
+
+func TestStream_Fill(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('cpu')
+		.groupBy('host')
+	|fill()
+		.every(10s)
+		.method('linear')
+	|window()
+		.period(60s)
+		.every(60s)
+	|httpOut('TestStream_Fill')
+`
+
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "cpu",
+				Tags:    map[string]string{"host": "A"},
+				Columns: []string{"time", "value"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC), 0.0},
+					{time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC), 10.0},
+					{time.Date(1971, 1, 1, 0, 0, 20, 0, time.UTC), 20.0},
+					{time.Date(1971, 1, 1, 0, 0, 30, 0, time.UTC), 30.0},
+					{time.Date(1971, 1, 1, 0, 0, 40, 0, time.UTC), 40.0},
+					{time.Date(1971, 1, 1, 0, 0, 50, 0, time.UTC), 50.0},
+				},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Fill", script, 65*time.Second, er, false, nil)
+}
+
+func TestStream_Fill_Null(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('cpu')
+		.groupBy('host')
+	|fill()
+		.every(10s)
+		.method('null')
+		.maxGap(20s)
+	|window()
+		.period(60s)
+		.every(60s)
+	|httpOut('TestStream_Fill_Null')
+`
+
+	// No points are filled beyond the max gap after the last point.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "cpu",
+				Tags:    map[string]string{"host": "A"},
+				Columns: []string{"time", "value"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC), 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC), nil},
+					{time.Date(1971, 1, 1, 0, 0, 20, 0, time.UTC), nil},
+					{time.Date(1971, 1, 1, 0, 0, 40, 0, time.UTC), 2.0},
+					{time.Date(1971, 1, 1, 0, 0, 50, 0, time.UTC), nil},
+				},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Fill_Null", script, 65*time.Second, er, false, nil)
+}
+
+func TestStream_Dedup(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('requests')
+	|dedup()
+	|window()
+		.period(10s)
+		.every(10s)
+	|httpOut('TestStream_Dedup')
+`
+
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "requests",
+				Columns: []string{"time", "host", "value"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC), "A", 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC), "B", 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 1, 0, time.UTC), "A", 3.0},
+				},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Dedup", script, 15*time.Second, er, false, nil)
+}
+
+func TestStream_Dedup_Fields(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('requests')
+	|dedup()
+		.fields('id')
+		.horizon(5s)
+	|window()
+		.period(10s)
+		.every(10s)
+	|httpOut('TestStream_Dedup_Fields')
+`
+
+	// The points with the same id are duplicates whatever their time,
+	// until their first point is beyond the horizon.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "requests",
+				Columns: []string{"time", "host", "id", "value"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC), "A", 1.0, 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 1, 0, time.UTC), "B", 1.0, 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 2, 0, time.UTC), "A", 2.0, 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 8, 0, time.UTC), "A", 3.0, 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 9, 0, time.UTC), "A", 1.0, 3.0},
+				},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Dedup_Fields", script, 15*time.Second, er, false, nil)
+}
+
+func TestStream_Anomaly(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('requests')
+		.groupBy('host')
+	|anomaly('value')
+		.alpha(0.5)
+		.threshold(2.0)
+	|httpOut('TestStream_Anomaly')
+`
+
+	// The baseline of A after 10 and 20 has a mean of 15 and a variance of 25.
+	// The first point of B has a score of zero.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "requests",
+				Tags:    map[string]string{"host": "A"},
+				Columns: []string{"time", "expected", "lower", "score", "upper", "value"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 1, 0, 0, 2, 0, time.UTC),
+					15.0,
+					5.0,
+					3.0,
+					25.0,
+					30.0,
+				}},
+			},
+			{
+				Name:    "requests",
+				Tags:    map[string]string{"host": "B"},
+				Columns: []string{"time", "expected", "lower", "score", "upper", "value"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 1, 0, 0, 1, 0, time.UTC),
+					50.0,
+					50.0,
+					0.0,
+					50.0,
+					50.0,
+				}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Anomaly", script, 15*time.Second, er, true, nil)
+}
+
+func TestStream_Anomaly_Seasonal(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('requests')
+	|anomaly('value')
+		.method('seasonal')
+		.alpha(0.5)
+		.threshold(2.0)
+	|httpOut('TestStream_Anomaly_Seasonal')
+`
+
+	// The point a week later is scored against the baseline of the same hour,
+	// the point of the next hour does not change that baseline.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "requests",
+				Columns: []string{"time", "expected", "lower", "score", "upper", "value"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 8, 0, 0, 0, 0, time.UTC),
+					15.0,
+					5.0,
+					3.0,
+					25.0,
+					30.0,
+				}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Anomaly_Seasonal", script, 8*24*time.Hour, er, false, nil)
+}
+
+func TestStream_Rate(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('requests')
+	|rate('counter')
+	|window()
+		.period(30s)
+		.every(30s)
+		.align()
+	|httpOut('TestStream_Rate')
+`
+
+	// The first point is dropped and the counter reset at 20s counts the increase from zero.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "requests",
+				Columns: []string{"time", "counter", "rate"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC), 110.0, 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 20, 0, time.UTC), 5.0, 0.5},
+				},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Rate", script, 35*time.Second, er, false, nil)
+}
+
+func TestStream_Forecast(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('disk')
+	|forecast('used')
+		.horizon(24h)
+		.alpha(1.0)
+		.beta(1.0)
+		.unit(1h)
+		.threshold(10.0)
+	|httpOut('TestStream_Forecast')
+`
+
+	// Used grows by 1 every hour, it reaches 26 within the horizon and the threshold in 8 hours.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "disk",
+				Columns: []string{"time", "forecast", "time_to_threshold", "used"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 1, 2, 0, 0, 0, time.UTC),
+					26.0,
+					8.0,
+					2.0,
+				}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Forecast", script, 3*time.Hour, er, false, nil)
+}
+
+func TestStream_Forecast_Seasonal(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('load')
+	|forecast('value')
+		.horizon(12h)
+		.seasonality(24h)
+		.slots(2)
+		.alpha(0.5)
+		.beta(0.0)
+		.gamma(0.5)
+	|httpOut('TestStream_Forecast_Seasonal')
+`
+
+	// The load is 10 during the first half of the day and 30 during the second half,
+	// the forecast of the last afternoon point is close to the load of the next morning.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "load",
+				Columns: []string{"time", "forecast", "value"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 20, 12, 0, 0, 0, time.UTC),
+					10.003722894871316,
+					30.0,
+				}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Forecast_Seasonal", script, 20*24*time.Hour, er, false, nil)
+}
+
+func TestStream_Rename(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('container_cpu')
+		.groupBy('hostname', 'k8s_pod')
+	|rename()
+		.measurement('container_cpu', 'cpu')
+		.tag('hostname', 'host')
+		.tagRegex(/^k8s_(.*)/, '$1')
+		.field('usage_percent', 'usage')
+		.fieldRegex(/^(.*)_total$/, '${1}')
+	|window()
+		.period(10s)
+		.every(10s)
+	|httpOut('TestStream_Rename')
+`
+
+	// The tags of the group by dimensions are renamed too.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "cpu",
+				Tags:    map[string]string{"host": "A", "pod": "web-1"},
+				Columns: []string{"time", "dc", "limit", "namespace", "requests", "usage"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC),
+					"east",
+					1.0,
+					"default",
+					10.0,
+					0.5,
+				}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Rename", script, 15*time.Second, er, false, nil)
+}
+
+func TestStream_Switch(t *testing.T) {
+	var script = `
+var levels = stream
+	|from()
+		.measurement('cpu')
+	|switch()
+		.case(lambda: "usage_idle" < 10, 'crit')
+		.case(lambda: "usage_idle" < 30, 'warn')
+		.defaultBranch('ok')
+
+var crit = levels
+	|branch('crit')
+	|eval(lambda: 'crit')
+		.as('level')
+		.tags('level')
+		.keep()
+
+var warn = levels
+	|branch('warn')
+	|eval(lambda: 'warn')
+		.as('level')
+		.tags('level')
+		.keep()
+
+var ok = levels
+	|branch('ok')
+	|eval(lambda: 'ok')
+		.as('level')
+		.tags('level')
+		.keep()
+
+crit
+	|union(warn, ok)
+	|groupBy('level')
+	|httpOut('TestStream_Switch')
+`
+
+	// The point at 3s matches both cases and is only routed to the branch of the first case.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "cpu",
+				Tags:    map[string]string{"level": "crit"},
+				Columns: []string{"time", "level", "usage_idle"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 1, 0, 0, 3, 0, time.UTC),
+					"crit",
+					8.0,
+				}},
+			},
+			{
+				Name:    "cpu",
+				Tags:    map[string]string{"level": "warn"},
+				Columns: []string{"time", "level", "usage_idle"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 1, 0, 0, 1, 0, time.UTC),
+					"warn",
+					20.0,
+				}},
+			},
+			{
+				Name:    "cpu",
+				Tags:    map[string]string{"level": "ok"},
+				Columns: []string{"time", "level", "usage_idle"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 1, 0, 0, 2, 0, time.UTC),
+					"ok",
+					50.0,
+				}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Switch", script, 5*time.Second, er, true, nil)
+}
+
+func TestStream_Switch_NoDefault(t *testing.T) {
+	var script = `
+var levels = stream
+	|from()
+		.measurement('cpu')
+	|switch()
+		.case(lambda: "usage_idle" < 10, 'crit')
+		.case(lambda: "usage_idle" < 30, 'warn')
+
+levels
+	|branch('warn')
+	|httpOut('TestStream_Switch_NoDefault')
+`
+
+	// The point at 3s does not match any case and is dropped.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "cpu",
+				Columns: []string{"time", "usage_idle"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 1, 0, 0, 2, 0, time.UTC),
+					20.0,
+				}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Switch_NoDefault", script, 5*time.Second, er, false, nil)
+}
+
+func TestStream_Throttle(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('errors')
+	|throttle()
+		.rate(2)
+		.per(1h)
+		.by('host')
+	|window()
+		.period(10s)
+		.every(10s)
+	|count('value')
+	|httpOut('TestStream_Throttle')
+`
+
+	// Each host has its own bucket of two tokens, the third point of A is dropped.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "errors",
+				Columns: []string{"time", "count"},
+				Values: [][]interface{}{{
+					time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC),
+					3.0,
+				}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Throttle", script, 15*time.Second, er, false, nil)
+}
+
+func TestStream_Schedule(t *testing.T) {
+	testCases := []struct {
+		name    string
+		exclude string
+		exp     []float64
+	}{
+		{
+			// Only the points from 9am to 5pm New York time on weekdays pass,
+			// the points of the Saturday and of the Monday holiday are dropped.
+			name: "TestStream_Schedule",
+			exp:  []float64{3, 4, 8},
+		},
+		{
+			name:    "TestStream_Schedule_Exclude",
+			exclude: ".exclude()",
+			exp:     []float64{1, 2, 5, 6, 7},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values := make(chan float64, 10)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				result := models.Result{}
+				if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+					t.Error(err)
+					return
+				}
+				for _, row := range result.Series {
+					for _, v := range row.Values {
+						values <- v[1].(float64)
+					}
+				}
+			}))
+			defer ts.Close()
+
+			var script = `
+stream
+	|from()
+		.measurement('requests')
+	|schedule()
+		.window('0 9 * * 1-5', 8h)
+		.timezone('America/New_York')
+		.holiday('1971-01-04')
+		` + tc.exclude + `
+	|httpPost('` + ts.URL + `')
+`
+
+			testStreamerNoOutput(t, "TestStream_Schedule", script, 5*24*time.Hour, nil)
+			close(values)
+
+			var got []float64
+			for v := range values {
+				got = append(got, v)
+			}
+			if !reflect.DeepEqual(got, tc.exp) {
+				t.Errorf("unexpected values passed:\ngot\n%v\nexp\n%v\n", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestStream_Rollup(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('cpu')
+	|rollup('region', 'cluster', 'host')
+	|window()
+		.period(10s)
+		.every(10s)
+		.align()
+	|sum('value')
+	|httpOut('TestStream_Rollup')
+`
+
+	sum := func(tags map[string]string, value float64) *models.Row {
+		return &models.Row{
+			Name:    "cpu",
+			Tags:    tags,
+			Columns: []string{"time", "sum"},
+			Values: [][]interface{}{{
+				time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC),
+				value,
+			}},
+		}
+	}
+	// Each point is summed per host, per cluster, per region and globally.
+	er := models.Result{
+		Series: models.Rows{
+			sum(map[string]string{"region": "us", "cluster": "c1", "host": "h1", "rollup": "host"}, 1),
+			sum(map[string]string{"region": "us", "cluster": "c1", "host": "h2", "rollup": "host"}, 2),
+			sum(map[string]string{"region": "us", "cluster": "c2", "host": "h3", "rollup": "host"}, 4),
+			sum(map[string]string{"region": "eu", "cluster": "c3", "host": "h4", "rollup": "host"}, 8),
+			sum(map[string]string{"region": "us", "cluster": "c1", "rollup": "cluster"}, 3),
+			sum(map[string]string{"region": "us", "cluster": "c2", "rollup": "cluster"}, 4),
+			sum(map[string]string{"region": "eu", "cluster": "c3", "rollup": "cluster"}, 8),
+			sum(map[string]string{"region": "us", "rollup": "region"}, 7),
+			sum(map[string]string{"region": "eu", "rollup": "region"}, 8),
+			sum(map[string]string{"rollup": "all"}, 15),
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Rollup", script, 15*time.Second, er, true, nil)
+}
+
+func TestStream_Slo(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('requests')
+	|slo()
+		.good(lambda: "status" < 500)
+		.objective(90.0)
+		.windows(1m, 10s)
+	|window()
+		.periodCount(4)
+		.everyCount(4)
+	|httpOut('TestStream_Slo')
+`
+
+	// burnRate matches the arithmetic of the node for an objective of 90%.
+	objective := 90.0
+	burnRate := func(good, total float64) float64 {
+		return (total - good) / total / (1 - objective/100)
+	}
+
+	// The first point leaves the short window at 10s,
+	// then two of the three points in the short window are bad.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name: "requests",
+				Columns: []string{
+					"time",
+					"burn_rate_10s",
+					"burn_rate_1m",
+					"error_budget_remaining_10s",
+					"error_budget_remaining_1m",
+					"good_10s",
+					"good_1m",
+					"status",
+					"total_10s",
+					"total_1m",
+				},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC), 0.0, 0.0, 1.0, 1.0, 1.0, 1.0, 200.0, 1.0, 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 5, 0, time.UTC), burnRate(1, 2), burnRate(1, 2), 1 - burnRate(1, 2), 1 - burnRate(1, 2), 1.0, 1.0, 500.0, 2.0, 2.0},
+					{time.Date(1971, 1, 1, 0, 0, 10, 0, time.UTC), burnRate(1, 2), burnRate(2, 3), 1 - burnRate(1, 2), 1 - burnRate(2, 3), 1.0, 2.0, 200.0, 2.0, 3.0},
+					{time.Date(1971, 1, 1, 0, 0, 14, 0, time.UTC), burnRate(1, 3), burnRate(2, 4), 1 - burnRate(1, 3), 1 - burnRate(2, 4), 1.0, 2.0, 503.0, 3.0, 4.0},
+				},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Slo", script, 15*time.Second, er, false, nil)
+}
+
+func TestStream_Slo_LatePoints(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('requests')
+	|slo()
+		.good(lambda: "status" < 500)
+		.objective(90.0)
+		.windows(10s)
+	|window()
+		.periodCount(6)
+		.everyCount(6)
+	|httpOut('TestStream_Slo_LatePoints')
+`
+
+	objective := 90.0
+	burnRate := func(good, total float64) float64 {
+		return (total - good) / total / (1 - objective/100)
+	}
+
+	// The late point at 3s is counted, the late point at 2s is older than the window and is not counted.
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "requests",
+				Columns: []string{"time", "burn_rate_10s", "error_budget_remaining_10s", "good_10s", "status", "total_10s"},
+				Values: [][]interface{}{
+					{time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC), 0.0, 1.0, 1.0, 200.0, 1.0},
+					{time.Date(1971, 1, 1, 0, 0, 8, 0, time.UTC), burnRate(1, 2), 1 - burnRate(1, 2), 1.0, 500.0, 2.0},
+					{time.Date(1971, 1, 1, 0, 0, 3, 0, time.UTC), burnRate(1, 3), 1 - burnRate(1, 3), 1.0, 500.0, 3.0},
+					{time.Date(1971, 1, 1, 0, 0, 15, 0, time.UTC), burnRate(1, 2), 1 - burnRate(1, 2), 1.0, 200.0, 2.0},
+					{time.Date(1971, 1, 1, 0, 0, 2, 0, time.UTC), burnRate(1, 2), 1 - burnRate(1, 2), 1.0, 200.0, 2.0},
+					{time.Date(1971, 1, 1, 0, 0, 19, 0, time.UTC), 0.0, 1.0, 2.0, 200.0, 2.0},
+				},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Slo_LatePoints", script, 25*time.Second, er, false, nil)
+}
+
+func TestStream_Pivot(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('m')
+		.groupBy('host', 'metric')
+	|pivot()
+		.rowKey('host')
+		.columnKey('metric')
+	|httpOut('TestStream_Pivot')
+`
+
+	// The rows of the last time are emitted when the task stops.
+	tm := time.Date(1971, 1, 1, 0, 0, 2, 0, time.UTC)
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "m",
+				Tags:    map[string]string{"host": "A"},
+				Columns: []string{"time", "cpu", "mem"},
+				Values:  [][]interface{}{{tm, 0.8, 0.65}},
+			},
+			{
+				Name:    "m",
+				Tags:    map[string]string{"host": "B"},
+				Columns: []string{"time", "cpu"},
+				Values:  [][]interface{}{{tm, 0.35}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Pivot", script, 5*time.Second, er, true, nil)
+}
+
+func TestStream_Unpivot(t *testing.T) {
+	var script = `
+stream
+	|from()
+		.measurement('m')
+		.groupBy('host')
+	|unpivot()
+		.fields('cpu', 'mem')
+		.tag('metric')
+	|groupBy('host', 'metric')
+	|httpOut('TestStream_Unpivot')
+`
+
+	tm := time.Date(1971, 1, 1, 0, 0, 2, 0, time.UTC)
+	er := models.Result{
+		Series: models.Rows{
+			{
+				Name:    "m",
+				Tags:    map[string]string{"host": "A", "metric": "cpu"},
+				Columns: []string{"time", "load", "value"},
+				Values:  [][]interface{}{{tm, 2.0, 0.8}},
+			},
+			{
+				Name:    "m",
+				Tags:    map[string]string{"host": "A", "metric": "mem"},
+				Columns: []string{"time", "load", "value"},
+				Values:  [][]interface{}{{tm, 2.0, 0.65}},
+			},
+		},
+	}
+
+	testStreamerWithOutput(t, "TestStream_Unpivot", script, 5*time.Second, er, true, nil)
+}
+
 func TestStream_Sample_Count(t *testing.T) {
 	var script = `
 stream
@@ -13244,6 +14198,7 @@ stream
 			"crits_triggered":     int64(0),
 			"alerts_triggered":    int64(0),
 			"alerts_inhibited":    int64(0),
+			"alerts_suppressed":   int64(0),
 			"oks_triggered":       int64(0),
 			"infos_triggered":     int64(0),
 		},
@@ -13521,6 +14476,7 @@ stream
 			"crits_triggered":     int64(0),
 			"alerts_triggered":    int64(0),
 			"alerts_inhibited":    int64(0),
+			"alerts_suppressed":   int64(0),
 			"oks_triggered":       int64(0),
 			"infos_triggered":     int64(0),
 		},
@@ -15,9 +15,10 @@ import (
 
 type JoinNode struct {
 	node
-	j         *pipeline.JoinNode
-	fill      influxql.FillOption
-	fillValue interface{}
+	j *pipeline.JoinNode
+	// fills contains the fill of missing points for each parent.
+	// A parent with no fill is required to join a set.
+	fills []joinFill
 
 	groupsMu sync.RWMutex
 	groups   map[models.GroupID]*joinGroup
@@ -46,24 +47,77 @@ func newJoinNode(et *ExecutingTask, n *pipeline.JoinNode, d NodeDiagnostic) (*Jo
 		reported:             make(map[int]bool),
 	}
 	// Set fill
-	switch fill := n.Fill.(type) {
+	fill, err := newJoinFill(n.Fill)
+	if err != nil {
+		return nil, err
+	}
+	outer := n.LeftFlag || n.RightFlag || n.FullFlag
+	if outer && fill.fill == influxql.NoFill {
+		// Outer joins fill with null by default
+		fill.fill = influxql.NullFill
+	}
+	last := len(n.Names) - 1
+	jn.fills = make([]joinFill, len(n.Names))
+	for i, name := range n.Names {
+		switch {
+		case n.LeftFlag && i == 0, n.RightFlag && i == last:
+			// Required parent, sets missing its point are dropped.
+			jn.fills[i] = joinFill{fill: influxql.NoFill}
+			continue
+		}
+		jn.fills[i] = fill
+		if v, ok := n.FillValues[name]; ok {
+			f, err := newJoinFill(v)
+			if err != nil {
+				return nil, err
+			}
+			jn.fills[i] = f
+		}
+	}
+	jn.node.runF = jn.runJoin
+	return jn, nil
+}
+
+// joinFill is the fill of missing points from a single parent.
+type joinFill struct {
+	fill  influxql.FillOption
+	value interface{}
+}
+
+func newJoinFill(fill interface{}) (joinFill, error) {
+	switch fill := fill.(type) {
 	case string:
 		switch fill {
 		case "null":
-			jn.fill = influxql.NullFill
+			return joinFill{fill: influxql.NullFill}, nil
 		case "none":
-			jn.fill = influxql.NoFill
+			return joinFill{fill: influxql.NoFill}, nil
 		default:
-			return nil, fmt.Errorf("unexpected fill option %s", fill)
+			return joinFill{}, fmt.Errorf("unexpected fill option %s", fill)
 		}
 	case int64, float64:
-		jn.fill = influxql.NumberFill
-		jn.fillValue = fill
+		return joinFill{fill: influxql.NumberFill, value: fill}, nil
 	default:
-		jn.fill = influxql.NoFill
+		return joinFill{fill: influxql.NoFill}, nil
 	}
-	jn.node.runF = jn.runJoin
-	return jn, nil
+}
+
+// fillFields fills the fields of a missing point for the given field names.
+// It reports false if the point cannot be filled.
+func (f joinFill) fillFields(fields models.Fields, prefix string, names []string) bool {
+	switch f.fill {
+	case influxql.NullFill:
+		for _, k := range names {
+			fields[prefix+k] = nil
+		}
+	case influxql.NumberFill:
+		for _, k := range names {
+			fields[prefix+k] = f.value
+		}
+	default:
+		return false
+	}
+	return true
 }
 
 func (n *JoinNode) runJoin([]byte) error {
@@ -420,8 +474,7 @@ func (g *joinGroup) newJoinset(t time.Time) *joinset {
 	return newJoinset(
 		g.n,
 		g.n.j.StreamName,
-		g.n.fill,
-		g.n.fillValue,
+		g.n.fills,
 		g.n.j.Names,
 		g.n.j.Delimiter,
 		g.n.j.Tolerance,
@@ -473,15 +526,23 @@ func (g *joinGroup) emit(onlyReadySets bool) error {
 }
 
 // checkOnlyReadSets reports if all heads are past the oldesttime,
+// or if any head is past the oldesttime by more than the timeout,
 // indicated whether its ok to emit non ready sets.
 func (g *joinGroup) checkOnlyReadSets() bool {
 	onlyReadySets := false
+	var newest time.Time
 	// Check if heads are past oldest time
 	for _, t := range g.head {
 		if !t.After(g.oldestTime) {
 			onlyReadySets = true
-			break
 		}
+		if t.After(newest) {
+			newest = t
+		}
+	}
+	if onlyReadySets && g.n.j.Timeout != 0 && newest.Sub(g.oldestTime) > g.n.j.Timeout {
+		// The set has timed out waiting on the remaining parents.
+		onlyReadySets = false
 	}
 	return onlyReadySets
 }
@@ -538,8 +599,7 @@ type srcGroup struct {
 type joinset struct {
 	j         *JoinNode
 	name      string
-	fill      influxql.FillOption
-	fillValue interface{}
+	fills     []joinFill
 	prefixes  []string
 	delimiter string
 
@@ -558,8 +618,7 @@ type joinset struct {
 func newJoinset(
 	n *JoinNode,
 	name string,
-	fill influxql.FillOption,
-	fillValue interface{},
+	fills []joinFill,
 	prefixes []string,
 	delimiter string,
 	tolerance time.Duration,
@@ -570,8 +629,7 @@ func newJoinset(
 	return &joinset{
 		j:         n,
 		name:      name,
-		fill:      fill,
-		fillValue: fillValue,
+		fills:     fills,
 		prefixes:  prefixes,
 		delimiter: delimiter,
 		expected:  expected,
@@ -612,19 +670,14 @@ func (js *joinset) JoinIntoPoint() (edge.PointMessage, error) {
 		return nil, fmt.Errorf("unexpected type of first value %T", js.First())
 	}
 	firstFields := first.Fields()
+	fieldNames := make([]string, 0, len(firstFields))
+	for k := range firstFields {
+		fieldNames = append(fieldNames, k)
+	}
 	fields := make(models.Fields, js.size*len(firstFields))
 	for i, v := range js.values {
 		if v == nil {
-			switch js.fill {
-			case influxql.NullFill:
-				for k := range firstFields {
-					fields[js.prefixes[i]+js.delimiter+k] = nil
-				}
-			case influxql.NumberFill:
-				for k := range firstFields {
-					fields[js.prefixes[i]+js.delimiter+k] = js.fillValue
-				}
-			default:
+			if !js.fills[i].fillFields(fields, js.prefixes[i]+js.delimiter, fieldNames) {
 				// inner join no valid point possible
 				return nil, nil
 			}
@@ -727,16 +780,7 @@ BATCH_POINT:
 		fields := make(models.Fields, js.expected*len(fieldNames))
 		for i, bp := range set {
 			if bp == nil {
-				switch js.fill {
-				case influxql.NullFill:
-					for _, k := range fieldNames {
-						fields[js.prefixes[i]+js.delimiter+k] = nil
-					}
-				case influxql.NumberFill:
-					for _, k := range fieldNames {
-						fields[js.prefixes[i]+js.delimiter+k] = js.fillValue
-					}
-				default:
+				if !js.fills[i].fillFields(fields, js.prefixes[i]+js.delimiter, fieldNames) {
 					// inner join no valid point possible
 					continue BATCH_POINT
 				}
@@ -0,0 +1,147 @@
+package kapacitor
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/expvar"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+const (
+	statsLookupMisses = "lookup_misses"
+)
+
+// lookupEntry is the most recent table data for a single key.
+type lookupEntry struct {
+	fields models.Fields
+	tags   models.Tags
+}
+
+type LookupJoinNode struct {
+	node
+	j    *pipeline.LookupJoinNode
+	dims models.Dimensions
+
+	mu    sync.RWMutex
+	table map[models.GroupID]lookupEntry
+
+	misses *expvar.Int
+}
+
+// Create a new LookupJoinNode, which enriches stream points with the latest data of a batch table.
+func newLookupJoinNode(et *ExecutingTask, n *pipeline.LookupJoinNode, d NodeDiagnostic) (*LookupJoinNode, error) {
+	tagNames := make([]string, len(n.Dimensions))
+	copy(tagNames, n.Dimensions)
+	sort.Strings(tagNames)
+	ln := &LookupJoinNode{
+		j:      n,
+		node:   node{Node: n, et: et, diag: d},
+		dims:   models.Dimensions{TagNames: tagNames},
+		table:  make(map[models.GroupID]lookupEntry),
+		misses: new(expvar.Int),
+	}
+	ln.node.runF = ln.runLookupJoin
+	return ln, nil
+}
+
+// accepts the stream edge from the first parent and the batch edge from the table.
+func (n *LookupJoinNode) accepts(t pipeline.EdgeType) bool {
+	return t == pipeline.StreamEdge || t == pipeline.BatchEdge
+}
+
+func (n *LookupJoinNode) runLookupJoin([]byte) error {
+	consumer := edge.NewMultiConsumerWithStats(n.ins, n)
+	valueF := func() int64 {
+		n.mu.RLock()
+		l := len(n.table)
+		n.mu.RUnlock()
+		return int64(l)
+	}
+	n.statMap.Set(statCardinalityGauge, expvar.NewIntFuncGauge(valueF))
+	n.statMap.Set(statsLookupMisses, n.misses)
+
+	return consumer.Consume()
+}
+
+func (n *LookupJoinNode) BufferedBatch(src int, batch edge.BufferedBatchMessage) error {
+	if src == 0 {
+		// Only the table provides batches
+		return nil
+	}
+	latest := make(map[models.GroupID]edge.BatchPointMessage)
+	for _, bp := range batch.Points() {
+		key := models.ToGroupID("", bp.Tags(), n.dims)
+		if l, ok := latest[key]; ok && l.Time().After(bp.Time()) {
+			continue
+		}
+		latest[key] = bp
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for key, bp := range latest {
+		n.table[key] = lookupEntry{
+			fields: bp.Fields(),
+			tags:   bp.Tags(),
+		}
+	}
+	return nil
+}
+
+func (n *LookupJoinNode) Point(src int, p edge.PointMessage) error {
+	if src != 0 {
+		return nil
+	}
+	n.timer.Start()
+	p = n.enrich(p)
+	n.timer.Stop()
+	return edge.Forward(n.outs, p)
+}
+
+// enrich adds the fields and tags of the matching table entry to the point.
+func (n *LookupJoinNode) enrich(p edge.PointMessage) edge.PointMessage {
+	n.mu.RLock()
+	e, ok := n.table[models.ToGroupID("", p.Tags(), n.dims)]
+	n.mu.RUnlock()
+	if !ok {
+		n.misses.Add(1)
+		return p
+	}
+	p = p.ShallowCopy()
+	fields := p.Fields().Copy()
+	for k, v := range e.fields {
+		k = n.j.Prefix + k
+		if _, ok := fields[k]; !ok {
+			fields[k] = v
+		}
+	}
+	p.SetFields(fields)
+	tags := p.Tags().Copy()
+	for k, v := range e.tags {
+		if _, ok := tags[k]; !ok {
+			tags[k] = v
+		}
+	}
+	p.SetTags(tags)
+	return p
+}
+
+func (n *LookupJoinNode) Barrier(src int, b edge.BarrierMessage) error {
+	if src != 0 {
+		return nil
+	}
+	return edge.Forward(n.outs, b)
+}
+
+func (n *LookupJoinNode) Delete(src int, d edge.DeleteGroupMessage) error {
+	if src != 0 {
+		return nil
+	}
+	return edge.Forward(n.outs, d)
+}
+
+func (n *LookupJoinNode) Finish() error {
+	return nil
+}
@@ -210,8 +210,17 @@ func (n *node) Wait() error {
 	return n.err
 }
 
+// mixedEdgeNode is implemented by nodes whose parents may provide different edge types.
+type mixedEdgeNode interface {
+	accepts(t pipeline.EdgeType) bool
+}
+
 func (n *node) addChild(c Node) (edge.StatsEdge, error) {
-	if n.Provides() != c.Wants() {
+	if m, ok := c.(mixedEdgeNode); ok {
+		if !m.accepts(n.Provides()) {
+			return nil, fmt.Errorf("cannot add child mismatched edges: %s:%s -> %s:%s", n.Name(), n.Provides(), c.Name(), c.Wants())
+		}
+	} else if n.Provides() != c.Wants() {
 		return nil, fmt.Errorf("cannot add child mismatched edges: %s:%s -> %s:%s", n.Name(), n.Provides(), c.Name(), c.Wants())
 	}
 	if n.Provides() == pipeline.NoEdge {
@@ -284,10 +293,15 @@ func (n *node) edot(buf *bytes.Buffer, labels bool) {
 		buf.Write([]byte("\"];\n"))
 
 		for i, c := range n.children {
+			var branch string
+			if b, ok := c.(*BranchNode); ok {
+				branch = fmt.Sprintf("branch=%s ", b.b.Branch)
+			}
 			buf.Write([]byte(
-				fmt.Sprintf("%s -> %s [label=\"processed=%d\"];\n",
+				fmt.Sprintf("%s -> %s [label=\"%sprocessed=%d\"];\n",
 					n.Name(),
 					c.Name(),
+					branch,
 					n.outs[i].Collected(),
 				),
 			))
@@ -316,10 +330,15 @@ func (n *node) edot(buf *bytes.Buffer, labels bool) {
 		})
 		buf.Write([]byte("];\n"))
 		for i, c := range n.children {
+			var branch string
+			if b, ok := c.(*BranchNode); ok {
+				branch = fmt.Sprintf("branch=%q ", b.b.Branch)
+			}
 			buf.Write([]byte(
-				fmt.Sprintf("%s -> %s [processed=\"%d\"];\n",
+				fmt.Sprintf("%s -> %s [%sprocessed=\"%d\"];\n",
 					n.Name(),
 					c.Name(),
+					branch,
 					n.outs[i].Collected(),
 				),
 			))
@@ -327,6 +346,22 @@ func (n *node) edot(buf *bytes.Buffer, labels bool) {
 	}
 }
 
+// emitMessages forwards all but the last message to the children of the node and returns the last message.
+// The timer of the node is paused while forwarding.
+func (n *node) emitMessages(msgs []edge.Message) (edge.Message, error) {
+	if len(msgs) == 0 {
+		return nil, nil
+	}
+	n.timer.Pause()
+	defer n.timer.Resume()
+	for _, m := range msgs[:len(msgs)-1] {
+		if err := edge.Forward(n.outs, m); err != nil {
+			return nil, err
+		}
+	}
+	return msgs[len(msgs)-1], nil
+}
+
 // node collected count is the sum of emitted counts of parent edges
 func (n *node) collectedCount() (count int64) {
 	for _, in := range n.ins {
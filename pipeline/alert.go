@@ -312,6 +312,14 @@ type AlertNodeData struct {
 	// tick:ignore
 	Inhibitors []Inhibitor `tick:"Inhibit" json:"inhibitors"`
 
+	// The calendars during which events are sent.
+	// tick:ignore
+	ActiveCalendars []string `tick:"ActiveDuring" json:"activeDuring"`
+
+	// The calendars during which events are not sent.
+	// tick:ignore
+	InactiveCalendars []string `tick:"InactiveDuring" json:"inactiveDuring"`
+
 	// Post the JSON alert data to the specified URL.
 	// tick:ignore
 	HTTPPostHandlers []*AlertHTTPPostHandler `tick:"Post" json:"post"`
@@ -611,6 +619,48 @@ func (n *AlertNodeData) Inhibit(category string, equalTags ...string) *AlertNode
 	return n
 }
 
+// Send events only while one of the named calendars is active.
+// Outside of the calendars the data is ignored and the alert state is not updated,
+// so an alert still triggered once the calendars are active again is sent as a state change.
+// Calendars are defined in the configuration file or via the calendars API.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('cpu')
+//	    |alert()
+//	        .crit(lambda: "usage_idle" < 10)
+//	        .activeDuring('business-hours')
+//
+// The calendars are evaluated at the time of the event.
+//
+// tick:property
+func (n *AlertNodeData) ActiveDuring(calendars ...string) *AlertNodeData {
+	n.ActiveCalendars = append(n.ActiveCalendars, calendars...)
+	return n
+}
+
+// Do not send events while any of the named calendars is active,
+// for example to mute alerts during maintenance windows.
+// While the calendars are active the data is ignored and the alert state is not updated,
+// so an alert still triggered once the calendars are inactive again is sent as a state change.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('cpu')
+//	    |alert()
+//	        .crit(lambda: "usage_idle" < 10)
+//	        .inactiveDuring('maintenance')
+//
+// tick:property
+func (n *AlertNodeData) InactiveDuring(calendars ...string) *AlertNodeData {
+	n.InactiveCalendars = append(n.InactiveCalendars, calendars...)
+	return n
+}
+
 // Inhibitor represents a single alert inhibitor
 // tick:ignore
 type Inhibitor struct {
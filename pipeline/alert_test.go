@@ -52,6 +52,8 @@ func TestAlertNode_MarshalJSON(t *testing.T) {
     "stateChangesOnly": false,
     "stateChangesOnlyDuration": 0,
     "inhibitors": null,
+    "activeDuring": null,
+    "inactiveDuring": null,
     "post": [
         {
             "url": "http://howdy.local",
@@ -131,6 +133,8 @@ func TestAlertNode_MarshalJSON(t *testing.T) {
     "stateChangesOnly": false,
     "stateChangesOnlyDuration": 0,
     "inhibitors": null,
+    "activeDuring": null,
+    "inactiveDuring": null,
     "post": null,
     "tcp": null,
     "email": null,
@@ -207,6 +211,8 @@ func TestAlertNode_MarshalJSON(t *testing.T) {
     "stateChangesOnly": false,
     "stateChangesOnlyDuration": 0,
     "inhibitors": null,
+    "activeDuring": null,
+    "inactiveDuring": null,
     "post": null,
     "tcp": null,
     "email": null,
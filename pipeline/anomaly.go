@@ -0,0 +1,167 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// AnomalyEWMA scores points against an exponentially weighted moving average and variance.
+	AnomalyEWMA = "ewma"
+	// AnomalySeasonal scores points against an exponentially weighted baseline per hour of the week.
+	AnomalySeasonal = "seasonal"
+)
+
+// Scores each point of a stream or batch by how far a field deviates from its baseline.
+// The baseline is an exponentially weighted moving average and variance and is kept per group.
+//
+// The following fields are added to each point:
+//
+//   - score -- the number of standard deviations the value is away from the baseline, the z-score
+//   - expected -- the baseline value
+//   - lower -- the lower bound of the expected band, expected - threshold * stddev
+//   - upper -- the upper bound of the expected band, expected + threshold * stddev
+//
+// The score and band are computed against the baseline before the point updates it.
+// The first point of a baseline has a score of zero.
+//
+// Two methods are available:
+//
+//   - ewma -- a single baseline per group.
+//   - seasonal -- a baseline per group for each hour of the week (UTC), so that daily and weekly patterns are expected.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('requests')
+//	        .groupBy('service')
+//	    |anomaly('value')
+//	        .method('ewma')
+//	        .alpha(0.3)
+//	        .threshold(3.0)
+//	    |alert()
+//	        .warn(lambda: abs("score") > 3.0)
+//	        .crit(lambda: "value" > "upper" * 2.0)
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('logins')
+//	    |anomaly('count')
+//	        .method('seasonal')
+//	        .alpha(0.1)
+//	        .scoreAs('login_score')
+//
+// The above example compares the logins with the logins of the same hour of previous weeks.
+type AnomalyNode struct {
+	chainnode `json:"-"`
+
+	// The field to score.
+	// tick:ignore
+	Field string `json:"field"`
+
+	// The method used to build the baseline, one of ewma or seasonal.
+	// Default: ewma
+	Method string `json:"method"`
+
+	// The smoothing factor of the baseline, between 0 and 1.
+	// Higher values discount older values faster.
+	// Default: 0.3
+	Alpha float64 `json:"alpha"`
+
+	// The number of standard deviations of the expected band.
+	// Default: 3.0
+	Threshold float64 `json:"threshold"`
+
+	// The name of the score field.
+	// Default: score
+	ScoreAs string `json:"scoreAs"`
+
+	// The name of the expected value field.
+	// Default: expected
+	ExpectedAs string `json:"expectedAs"`
+
+	// The name of the lower bound field.
+	// Default: lower
+	LowerAs string `json:"lowerAs"`
+
+	// The name of the upper bound field.
+	// Default: upper
+	UpperAs string `json:"upperAs"`
+}
+
+func newAnomalyNode(wants EdgeType, field string) *AnomalyNode {
+	return &AnomalyNode{
+		chainnode:  newBasicChainNode("anomaly", wants, wants),
+		Field:      field,
+		Method:     AnomalyEWMA,
+		Alpha:      0.3,
+		Threshold:  3.0,
+		ScoreAs:    "score",
+		ExpectedAs: "expected",
+		LowerAs:    "lower",
+		UpperAs:    "upper",
+	}
+}
+
+// MarshalJSON converts AnomalyNode to JSON
+// tick:ignore
+func (n *AnomalyNode) MarshalJSON() ([]byte, error) {
+	type Alias AnomalyNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "anomaly",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an AnomalyNode
+// tick:ignore
+func (n *AnomalyNode) UnmarshalJSON(data []byte) error {
+	type Alias AnomalyNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "anomaly" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as AnomalyNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+func (n *AnomalyNode) validate() error {
+	if n.Field == "" {
+		return errors.New("must provide the field to score")
+	}
+	switch n.Method {
+	case AnomalyEWMA, AnomalySeasonal:
+	default:
+		return fmt.Errorf("invalid anomaly method %q, must be one of %q or %q", n.Method, AnomalyEWMA, AnomalySeasonal)
+	}
+	if n.Alpha <= 0 || n.Alpha > 1 {
+		return fmt.Errorf("anomaly alpha must be greater than 0 and at most 1, got %v", n.Alpha)
+	}
+	if n.Threshold <= 0 {
+		return fmt.Errorf("anomaly threshold must be positive, got %v", n.Threshold)
+	}
+	if n.ScoreAs == "" || n.ExpectedAs == "" || n.LowerAs == "" || n.UpperAs == "" {
+		return errors.New("anomaly output field names must not be empty")
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package pipeline
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -165,6 +166,21 @@ type QueryNode struct {
 	// 1 AM and the Offset is 1 hour. Then at 1 AM on Sunday the data from 12 AM will be queried.
 	Offset time.Duration `json:"offset"`
 
+	// Define a schedule aligned to the calendar, a number followed by one of
+	// `d` for days, `w` for weeks, `mo` for months or `y` for years, i.e. `1d` or `1mo`.
+	//
+	// InfluxDB is queried at the start of each calendar interval, plus the Offset,
+	// for the data of the previous calendar interval.
+	// For example a Calendar of `1mo` with a Timezone of `Europe/Berlin` queries
+	// the data of the previous month, from midnight on the first day of the month in Berlin.
+	//
+	// The Calendar property is mutually exclusive with the Every, Cron and Period properties.
+	Calendar string `json:"calendar,omitempty"`
+
+	// The time zone of the calendar.
+	// Default: UTC
+	Timezone string `json:"timezone,omitempty"`
+
 	// Align the group by time intervals with the start time of the query
 	// tick:ignore
 	AlignGroupFlag bool `tick:"AlignGroup" json:"alignGroup"`
@@ -349,6 +365,19 @@ func (n *QueryNode) GroupByMeasurement() *QueryNode {
 	return n
 }
 
+func (n *QueryNode) validate() error {
+	if n.Calendar != "" {
+		if n.Every != 0 || n.Cron != "" || n.Period != 0 {
+			return errors.New("cannot use calendar with every, cron or period")
+		}
+		return validateCalendar(n.Calendar, n.Timezone)
+	}
+	if n.Timezone != "" {
+		return errors.New("can only use timezone with calendar")
+	}
+	return nil
+}
+
 // Align start and stop times for queries with even boundaries of the QueryNode.Every property.
 // Does not apply if using the QueryNode.Cron property.
 // tick:property
@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Units of calendar intervals.
+const (
+	CalendarDay   = "d"
+	CalendarWeek  = "w"
+	CalendarMonth = "mo"
+	CalendarYear  = "y"
+)
+
+// CalendarInterval is a number of calendar days, weeks, months or years.
+// Unlike durations, calendar intervals are aligned to the calendar in a timezone,
+// so days start at midnight, weeks start on Monday, months on the first day of the month
+// and years on the first of January.
+// tick:ignore
+type CalendarInterval struct {
+	Count int
+	Unit  string
+}
+
+var calendarIntervalRegex = regexp.MustCompile(`^([1-9][0-9]*)(d|w|mo|y)$`)
+
+// ParseCalendarInterval parses a calendar interval like `1d`, `2w`, `1mo` or `1y`.
+// tick:ignore
+func ParseCalendarInterval(s string) (CalendarInterval, error) {
+	m := calendarIntervalRegex.FindStringSubmatch(s)
+	if m == nil {
+		return CalendarInterval{}, fmt.Errorf("invalid calendar interval %q, must be a number followed by one of %q, %q, %q or %q", s, CalendarDay, CalendarWeek, CalendarMonth, CalendarYear)
+	}
+	count, err := strconv.Atoi(m[1])
+	if err != nil {
+		return CalendarInterval{}, fmt.Errorf("invalid calendar interval %q: %v", s, err)
+	}
+	return CalendarInterval{
+		Count: count,
+		Unit:  m[2],
+	}, nil
+}
+
+// validateCalendar validates a calendar interval and its timezone.
+func validateCalendar(calendar, timezone string) error {
+	if _, err := ParseCalendarInterval(calendar); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: %v", timezone, err)
+	}
+	return nil
+}
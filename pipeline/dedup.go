@@ -0,0 +1,128 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxql"
+)
+
+// The default time duration for which seen points are remembered.
+const defaultDedupHorizon = time.Minute
+
+// Drops duplicate points from a stream.
+// By default two points are duplicates if they belong to the same series,
+// the same measurement and tags, and have the same time.
+// When fields are specified, two points of the same series are duplicates
+// if the values of those fields are equal, whatever their time.
+//
+// Seen points are remembered for a bounded horizon, relative to the most recent point time.
+// Points older than the horizon can no longer be detected as duplicates and are passed on.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('requests')
+//	    |dedup()
+//	        .horizon(5m)
+//	    |window()
+//	        .period(1m)
+//	        .every(1m)
+//	    |count('value')
+//
+// The above example drops points that are delivered more than once within 5 minutes, before counting them.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('orders')
+//	    |dedup()
+//	        .fields('order_id')
+//
+// The above example drops the points of an order that was already seen within the last minute.
+//
+// Available Statistics:
+//
+//   - duplicates_dropped -- number of duplicate points that were dropped
+type DedupNode struct {
+	chainnode `json:"-"`
+
+	// The fields whose values identify the points of a series instead of their time.
+	// tick:ignore
+	FieldNames []string `tick:"Fields" json:"fields"`
+
+	// How long seen points are remembered.
+	// Defaults to 1m.
+	Horizon time.Duration `json:"horizon"`
+}
+
+func newDedupNode() *DedupNode {
+	return &DedupNode{
+		chainnode: newBasicChainNode("dedup", StreamEdge, StreamEdge),
+		Horizon:   defaultDedupHorizon,
+	}
+}
+
+// MarshalJSON converts DedupNode to JSON
+// tick:ignore
+func (n *DedupNode) MarshalJSON() ([]byte, error) {
+	type Alias DedupNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Horizon string `json:"horizon"`
+	}{
+		TypeOf: TypeOf{
+			Type: "dedup",
+			ID:   n.ID(),
+		},
+		Alias:   (*Alias)(n),
+		Horizon: influxql.FormatDuration(n.Horizon),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an DedupNode
+// tick:ignore
+func (n *DedupNode) UnmarshalJSON(data []byte) error {
+	type Alias DedupNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Horizon string `json:"horizon"`
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "dedup" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as DedupNode", raw.ID, raw.Type)
+	}
+	n.Horizon, err = influxql.ParseDuration(raw.Horizon)
+	if err != nil {
+		return err
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// The fields whose values identify the points of a series.
+// Points of the same series with equal values of the fields are duplicates, whatever their time.
+// tick:property
+func (n *DedupNode) Fields(names ...string) *DedupNode {
+	n.FieldNames = names
+	return n
+}
+
+func (n *DedupNode) validate() error {
+	if n.Horizon <= 0 {
+		return errors.New("dedup horizon must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxql"
+)
+
+const (
+	// FillLinear interpolates the fields between the points around a gap.
+	FillLinear = "linear"
+	// FillPrevious repeats the fields of the previous point.
+	FillPrevious = "previous"
+	// FillNull sets the fields of the previous point to null.
+	FillNull = "null"
+	// FillNumber sets the fields of the previous point to a number.
+	FillNumber = "number"
+
+	defaultFillTag    = "filled"
+	defaultFillMaxGap = time.Hour
+)
+
+// Fill missing intervals of each group with synthetic points, similar to the InfluxQL fill() function.
+// A point is expected every interval after the previous point.
+// If no point arrives within half an interval of the expected time, a point is filled in for that time.
+//
+// The fill method is one of:
+//
+//   - linear -- interpolate the numeric fields between the points around the gap,
+//     other fields are repeated from the previous point.
+//     The gap is filled once the next point arrives.
+//   - previous -- repeat the fields of the previous point.
+//   - null -- set the fields of the previous point to null.
+//   - a number -- set the fields of the previous point to the number.
+//
+// Filled points get a tag with the value `true`, so downstream nodes can tell real from filled points.
+// Points are only filled up to the max gap after the last real point, which defaults to 1h.
+//
+// For streams the gaps after the last point of a group are filled when the next point or barrier of the group arrives,
+// except for linear fills which need the next point.
+// Use a barrier node to fill the gaps of groups that stopped receiving points.
+// For batches the gaps between the points of a batch are filled.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('cpu')
+//	        .groupBy('host')
+//	    |fill()
+//	        .every(10s)
+//	        .method('linear')
+//	        .maxGap(5m)
+//	    |derivative('usage_total')
+//
+// The above example interpolates missing points of each host for up to 5 minutes before computing the derivative.
+//
+// Fill cannot be applied directly to a query or join node, use their fill property instead.
+type FillNode struct {
+	chainnode `json:"-"`
+
+	// The interval at which points are expected.
+	Every time.Duration `json:"every"`
+
+	// The fill method.
+	// Default: previous
+	// tick:ignore
+	FillMethod string `tick:"Method" json:"method"`
+
+	// The number to fill with for the number method.
+	// tick:ignore
+	Number interface{} `json:"number,omitempty"`
+
+	// The maximum duration after the last real point to fill points for.
+	// Default: 1h
+	MaxGap time.Duration `json:"maxGap"`
+
+	// The tag that marks filled points.
+	// Default: filled
+	Tag string `json:"tag"`
+}
+
+func newFillNode(e EdgeType) *FillNode {
+	return &FillNode{
+		chainnode:  newBasicChainNode("fill", e, e),
+		FillMethod: FillPrevious,
+		MaxGap:     defaultFillMaxGap,
+		Tag:        defaultFillTag,
+	}
+}
+
+// MarshalJSON converts FillNode to JSON
+// tick:ignore
+func (n *FillNode) MarshalJSON() ([]byte, error) {
+	type Alias FillNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Every  string `json:"every"`
+		MaxGap string `json:"maxGap"`
+	}{
+		TypeOf: TypeOf{
+			Type: "fill",
+			ID:   n.ID(),
+		},
+		Alias:  (*Alias)(n),
+		Every:  influxql.FormatDuration(n.Every),
+		MaxGap: influxql.FormatDuration(n.MaxGap),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an FillNode
+// tick:ignore
+func (n *FillNode) UnmarshalJSON(data []byte) error {
+	type Alias FillNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Every  string `json:"every"`
+		MaxGap string `json:"maxGap"`
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "fill" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as FillNode", raw.ID, raw.Type)
+	}
+	n.Every, err = influxql.ParseDuration(raw.Every)
+	if err != nil {
+		return err
+	}
+	n.MaxGap, err = influxql.ParseDuration(raw.MaxGap)
+	if err != nil {
+		return err
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// The fill method, one of linear, previous, null or a number.
+// tick:property
+func (n *FillNode) Method(method interface{}) *FillNode {
+	switch m := method.(type) {
+	case string:
+		n.FillMethod = m
+		n.Number = nil
+	default:
+		n.FillMethod = FillNumber
+		n.Number = m
+	}
+	return n
+}
+
+func (n *FillNode) validate() error {
+	if n.Every <= 0 {
+		return errors.New("fill every must be positive")
+	}
+	switch n.FillMethod {
+	case FillLinear, FillPrevious, FillNull:
+	case FillNumber:
+		switch n.Number.(type) {
+		case int64, float64:
+		default:
+			return fmt.Errorf("cannot fill with %v of type %T, must be an int or float", n.Number, n.Number)
+		}
+	default:
+		return fmt.Errorf("invalid fill method %q, must be one of %q, %q, %q or a number", n.FillMethod, FillLinear, FillPrevious, FillNull)
+	}
+	if n.MaxGap <= 0 {
+		return errors.New("fill maxGap must be positive")
+	}
+	if n.Tag == "" {
+		return errors.New("fill tag must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxql"
+)
+
+// Continuously forecast a field per group using Holt-Winters exponential smoothing.
+// The model is updated with each point, so no window or join is needed to compare forecasts with actual values.
+//
+// The following fields are added to each point:
+//
+//   - forecast -- the forecasted value at the horizon
+//   - time_to_threshold -- the time until the forecast reaches the threshold, in units of unit.
+//     It is -1 if the threshold is not reached within the horizon.
+//     Only added if a threshold is set.
+//
+// Without a seasonality the model is a linear trend.
+// With a seasonality the season is split into a number of slots that each learn their offset from the trend.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('disk')
+//	        .groupBy('host', 'path')
+//	    |forecast('used_percent')
+//	        .horizon(24h)
+//	        .seasonality(1d)
+//	        .threshold(100.0)
+//	        .unit(1h)
+//	    |alert()
+//	        .warn(lambda: "time_to_threshold" >= 0 AND "time_to_threshold" < 24)
+//	        .crit(lambda: "time_to_threshold" >= 0 AND "time_to_threshold" < 6)
+//	        .message('Disk {{ index .Tags "path" }} on {{ index .Tags "host" }} full in {{ index .Fields "time_to_threshold" | printf "%0.1f" }}h')
+//
+// The above example warns when a disk is forecasted to be full within a day and is critical when full within 6 hours.
+type ForecastNode struct {
+	chainnode `json:"-"`
+
+	// The field to forecast.
+	// tick:ignore
+	Field string `json:"field"`
+
+	// How far ahead to forecast.
+	Horizon time.Duration `json:"horizon"`
+
+	// The duration of a season, zero for no seasonality.
+	Seasonality time.Duration `json:"seasonality"`
+
+	// The number of slots a season is split into.
+	// Default: 24
+	Slots int64 `json:"slots"`
+
+	// The smoothing factor of the level, between 0 and 1.
+	// Default: 0.5
+	Alpha float64 `json:"alpha"`
+
+	// The smoothing factor of the trend, between 0 and 1.
+	// Default: 0.1
+	Beta float64 `json:"beta"`
+
+	// The smoothing factor of the season, between 0 and 1.
+	// Default: 0.1
+	Gamma float64 `json:"gamma"`
+
+	// The threshold to compute the time to.
+	// tick:ignore
+	ThresholdValue *float64 `tick:"Threshold" json:"threshold,omitempty"`
+
+	// The time unit of the time to threshold.
+	// Default: 1s
+	Unit time.Duration `json:"unit"`
+
+	// The name of the forecast field.
+	// Default: forecast
+	ForecastAs string `json:"forecastAs"`
+
+	// The name of the time to threshold field.
+	// Default: time_to_threshold
+	TimeToThresholdAs string `json:"timeToThresholdAs"`
+}
+
+func newForecastNode(wants EdgeType, field string) *ForecastNode {
+	return &ForecastNode{
+		chainnode:         newBasicChainNode("forecast", wants, wants),
+		Field:             field,
+		Slots:             24,
+		Alpha:             0.5,
+		Beta:              0.1,
+		Gamma:             0.1,
+		Unit:              time.Second,
+		ForecastAs:        "forecast",
+		TimeToThresholdAs: "time_to_threshold",
+	}
+}
+
+// MarshalJSON converts ForecastNode to JSON
+// tick:ignore
+func (n *ForecastNode) MarshalJSON() ([]byte, error) {
+	type Alias ForecastNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Horizon     string `json:"horizon"`
+		Seasonality string `json:"seasonality"`
+		Unit        string `json:"unit"`
+	}{
+		TypeOf: TypeOf{
+			Type: "forecast",
+			ID:   n.ID(),
+		},
+		Alias:       (*Alias)(n),
+		Horizon:     influxql.FormatDuration(n.Horizon),
+		Seasonality: influxql.FormatDuration(n.Seasonality),
+		Unit:        influxql.FormatDuration(n.Unit),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an ForecastNode
+// tick:ignore
+func (n *ForecastNode) UnmarshalJSON(data []byte) error {
+	type Alias ForecastNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Horizon     string `json:"horizon"`
+		Seasonality string `json:"seasonality"`
+		Unit        string `json:"unit"`
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "forecast" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as ForecastNode", raw.ID, raw.Type)
+	}
+	n.Horizon, err = influxql.ParseDuration(raw.Horizon)
+	if err != nil {
+		return err
+	}
+	n.Seasonality, err = influxql.ParseDuration(raw.Seasonality)
+	if err != nil {
+		return err
+	}
+	n.Unit, err = influxql.ParseDuration(raw.Unit)
+	if err != nil {
+		return err
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// The threshold to compute the time to.
+// tick:property
+func (n *ForecastNode) Threshold(value float64) *ForecastNode {
+	n.ThresholdValue = &value
+	return n
+}
+
+func (n *ForecastNode) validate() error {
+	if n.Field == "" {
+		return errors.New("must provide the field to forecast")
+	}
+	if n.Horizon <= 0 {
+		return errors.New("a positive forecast horizon is required")
+	}
+	if n.Seasonality < 0 {
+		return errors.New("seasonality must not be negative")
+	}
+	if n.Seasonality > 0 && n.Slots <= 0 {
+		return errors.New("the number of slots of a season must be positive")
+	}
+	for name, v := range map[string]float64{"alpha": n.Alpha, "beta": n.Beta, "gamma": n.Gamma} {
+		if v < 0 || v > 1 {
+			return fmt.Errorf("forecast %s must be between 0 and 1, got %v", name, v)
+		}
+	}
+	if n.Unit <= 0 {
+		return errors.New("unit must be positive")
+	}
+	if n.ForecastAs == "" || n.TimeToThresholdAs == "" {
+		return errors.New("forecast output field names must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Compute an approximate histogram of a field using a mergeable sketch.
+// Memory is bounded regardless of the number of points.
+//
+// For each bucket upper bound a field is added with the number of values less than or equal to the bound,
+// named `le_` followed by the bound, e.g. le_100.
+// The buckets are cumulative, a `count` field contains the total number of values.
+//
+// The sketch itself can be emitted as a string field using `.sketchAs()`.
+// Points whose field is such a string sketch are merged instead of added,
+// so sketches produced by other tasks can be combined.
+// See QuantilesNode for an example.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('requests')
+//	    |window()
+//	        .period(1m)
+//	        .every(1m)
+//	    |histogram('latency')
+//	        .buckets(10.0, 50.0, 100.0, 500.0)
+//
+// The above example emits the number of requests with a latency up to 10, 50, 100 and 500 every minute.
+type HistogramNode struct {
+	chainnode `json:"-"`
+
+	// The field of values or sketches.
+	// tick:ignore
+	Field string `json:"field"`
+
+	// The upper bounds of the buckets in increasing order.
+	// tick:ignore
+	Bounds []float64 `tick:"Buckets" json:"buckets"`
+
+	// The relative accuracy of the sketch.
+	// Default: 0.01
+	RelativeAccuracy float64 `json:"relativeAccuracy"`
+
+	// The maximum number of bins of the sketch.
+	// Default: 2048
+	MaxBins int64 `json:"maxBins"`
+
+	// The name of the field to emit the encoded sketch to.
+	// If empty the sketch is not emitted.
+	SketchAs string `json:"sketchAs"`
+}
+
+func newHistogramNode(wants EdgeType, field string) *HistogramNode {
+	return &HistogramNode{
+		chainnode:        newBasicChainNode("histogram", wants, StreamEdge),
+		Field:            field,
+		RelativeAccuracy: defaultSketchRelativeAccuracy,
+		MaxBins:          defaultSketchMaxBins,
+	}
+}
+
+// MarshalJSON converts HistogramNode to JSON
+// tick:ignore
+func (n *HistogramNode) MarshalJSON() ([]byte, error) {
+	type Alias HistogramNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "histogram",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an HistogramNode
+// tick:ignore
+func (n *HistogramNode) UnmarshalJSON(data []byte) error {
+	type Alias HistogramNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "histogram" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as HistogramNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// The upper bounds of the buckets in increasing order.
+// tick:property
+func (n *HistogramNode) Buckets(bounds ...float64) *HistogramNode {
+	n.Bounds = bounds
+	return n
+}
+
+func (n *HistogramNode) validate() error {
+	if n.Field == "" {
+		return errors.New("must provide the field of the histogram")
+	}
+	if len(n.Bounds) == 0 {
+		return errors.New("a call to histogram.buckets() is required to specify the bucket bounds")
+	}
+	for i := 1; i < len(n.Bounds); i++ {
+		if n.Bounds[i] <= n.Bounds[i-1] {
+			return fmt.Errorf("bucket bounds must be increasing, got %v after %v", n.Bounds[i], n.Bounds[i-1])
+		}
+	}
+	return validateSketch(n.RelativeAccuracy, n.MaxBins)
+}
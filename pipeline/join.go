@@ -26,6 +26,8 @@ const (
 // Aliases are used to prefix all fields from the respective nodes.
 //
 // The join can be an inner or outer join, see the JoinNode.Fill property.
+// Alternatively the type of join can be set explicitly with the `left`, `right` and `full` properties,
+// and missing points of a specific parent can be filled using the `fillFor` property.
 //
 // Example:
 //
@@ -56,6 +58,24 @@ const (
 //
 // In the above example the `errors` and `requests` streams are joined
 // and then transformed to calculate a combined field.
+//
+// Example:
+//
+//	requests
+//	    |join(errors)
+//	        .as('requests', 'errors')
+//	        .tolerance(1s)
+//	        // keep every requests point, even without a matching errors point.
+//	        .left()
+//	        // missing errors are zero errors.
+//	        .fillFor('errors', 0)
+//	        // do not wait longer than 1m for a matching errors point.
+//	        .timeout(1m)
+//	    |eval(lambda: "errors.value" / "requests.value")
+//	       .as('rate')
+//
+// In the above example every point from `requests` is joined,
+// points that have no matching `errors` point within a minute are joined with an `errors.value` of 0.
 type JoinNode struct {
 	chainnode `json:"-"`
 	// The alias names of the two parents.
@@ -131,6 +151,29 @@ type JoinNode struct {
 	//        |where(lambda: "maintlock.mode")
 	//        |...
 	Fill interface{} `json:"fill"`
+
+	// Whether to perform a left outer join.
+	// tick:ignore
+	LeftFlag bool `tick:"Left" json:"left"`
+
+	// Whether to perform a right outer join.
+	// tick:ignore
+	RightFlag bool `tick:"Right" json:"right"`
+
+	// Whether to perform a full outer join.
+	// tick:ignore
+	FullFlag bool `tick:"Full" json:"full"`
+
+	// The fill values of missing points per parent, keyed by the parent alias.
+	// tick:ignore
+	FillValues map[string]interface{} `tick:"FillFor" json:"fillFor,omitempty"`
+
+	// The maximum duration in time to wait for a point from every parent.
+	// Once a point from any parent is newer than a join set by more than the timeout,
+	// the incomplete set is emitted according to the type of join.
+	// The timeout is based on the time of the data and not the wall clock.
+	// If zero, incomplete sets are held until every parent has moved past them.
+	Timeout time.Duration `json:"timeout"`
 }
 
 func newJoinNode(e EdgeType, parents []Node) *JoinNode {
@@ -152,6 +195,7 @@ func (n *JoinNode) MarshalJSON() ([]byte, error) {
 		TypeOf
 		*Alias
 		Tolerance string `json:"tolerance"`
+		Timeout   string `json:"timeout,omitempty"`
 	}{
 		TypeOf: TypeOf{
 			Type: "join",
@@ -160,6 +204,9 @@ func (n *JoinNode) MarshalJSON() ([]byte, error) {
 		Alias:     (*Alias)(n),
 		Tolerance: influxql.FormatDuration(n.Tolerance),
 	}
+	if n.Timeout != 0 {
+		raw.Timeout = influxql.FormatDuration(n.Timeout)
+	}
 	return json.Marshal(raw)
 }
 
@@ -171,6 +218,7 @@ func (n *JoinNode) UnmarshalJSON(data []byte) error {
 		TypeOf
 		*Alias
 		Tolerance string `json:"tolerance"`
+		Timeout   string `json:"timeout"`
 	}{
 		Alias: (*Alias)(n),
 	}
@@ -185,6 +233,12 @@ func (n *JoinNode) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
+	if raw.Timeout != "" {
+		n.Timeout, err = influxql.ParseDuration(raw.Timeout)
+		if err != nil {
+			return err
+		}
+	}
 	n.setID(raw.ID)
 	return nil
 }
@@ -240,6 +294,57 @@ func (j *JoinNode) On(dims ...string) *JoinNode {
 	return j
 }
 
+// Perform a left outer join.
+// Every point from the first parent is joined,
+// missing points from the other parent are filled.
+// A left join requires exactly two parents.
+// tick:property
+func (j *JoinNode) Left() *JoinNode {
+	j.LeftFlag = true
+	return j
+}
+
+// Perform a right outer join.
+// Every point from the last parent is joined,
+// missing points from the other parent are filled.
+// A right join requires exactly two parents.
+// tick:property
+func (j *JoinNode) Right() *JoinNode {
+	j.RightFlag = true
+	return j
+}
+
+// Perform a full outer join.
+// Every point from any parent is joined,
+// missing points from the other parents are filled.
+// tick:property
+func (j *JoinNode) Full() *JoinNode {
+	j.FullFlag = true
+	return j
+}
+
+// Fill the fields of missing points from the parent with the given alias.
+// The value can be 'null' or any numerical value.
+// Overrides the Fill property for that parent.
+// Without an explicit join type, the parent becomes optional.
+// The required parent of a left or right join cannot be filled.
+//
+// Example:
+//
+//	requests
+//	    |join(errors)
+//	        .as('requests', 'errors')
+//	        .fillFor('errors', 0)
+//
+// tick:property
+func (j *JoinNode) FillFor(name string, value interface{}) *JoinNode {
+	if j.FillValues == nil {
+		j.FillValues = make(map[string]interface{})
+	}
+	j.FillValues[name] = value
+	return j
+}
+
 // Validate that the as() specification is consistent with the number of join arms.
 func (j *JoinNode) validate() error {
 	if len(j.Names) == 0 {
@@ -266,5 +371,58 @@ func (j *JoinNode) validate() error {
 		names[name] = true
 	}
 
+	types := 0
+	for _, flag := range []bool{j.LeftFlag, j.RightFlag, j.FullFlag} {
+		if flag {
+			types++
+		}
+	}
+	if types > 1 {
+		return fmt.Errorf("can only use one of join.left(), join.right() or join.full()")
+	}
+	if (j.LeftFlag || j.RightFlag) && len(j.Parents()) != 2 {
+		return fmt.Errorf("left and right joins require exactly two joined streams")
+	}
+	if types > 0 && j.Fill == "none" {
+		return fmt.Errorf("cannot use fill none with an outer join")
+	}
+	if err := validateJoinFill(j.Fill); err != nil {
+		return err
+	}
+	for name, value := range j.FillValues {
+		if !names[name] {
+			return fmt.Errorf("cannot fill unknown join prefix %q, see .as() property method", name)
+		}
+		if j.LeftFlag && name == j.Names[0] {
+			return fmt.Errorf("cannot fill %q, it is the required stream of a left join", name)
+		}
+		if j.RightFlag && name == j.Names[len(j.Names)-1] {
+			return fmt.Errorf("cannot fill %q, it is the required stream of a right join", name)
+		}
+		if value == "none" {
+			return fmt.Errorf("cannot fill %q with none, fill values must be null or numerical", name)
+		}
+		if err := validateJoinFill(value); err != nil {
+			return err
+		}
+	}
+	if j.Timeout < 0 {
+		return fmt.Errorf("join timeout must be greater than zero")
+	}
+
+	return nil
+}
+
+// validateJoinFill validates a fill value of a join.
+func validateJoinFill(fill interface{}) error {
+	switch fill := fill.(type) {
+	case nil, int64, float64:
+	case string:
+		if fill != "null" && fill != "none" {
+			return fmt.Errorf("unexpected fill option %s", fill)
+		}
+	default:
+		return fmt.Errorf("unexpected fill type %T, fill must be null, none or numerical", fill)
+	}
 	return nil
 }
@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"testing"
+)
+
+func TestJoinNode_ValidateFillFor(t *testing.T) {
+	testCases := []struct {
+		name    string
+		join    func(j *JoinNode)
+		wantErr string
+	}{
+		{
+			name: "left join fills optional parent",
+			join: func(j *JoinNode) { j.Left().FillFor("errors", int64(0)) },
+		},
+		{
+			name:    "left join fills required parent",
+			join:    func(j *JoinNode) { j.Left().FillFor("requests", int64(0)) },
+			wantErr: `cannot fill "requests", it is the required stream of a left join`,
+		},
+		{
+			name:    "right join fills required parent",
+			join:    func(j *JoinNode) { j.Right().FillFor("errors", int64(0)) },
+			wantErr: `cannot fill "errors", it is the required stream of a right join`,
+		},
+		{
+			name: "full join fills any parent",
+			join: func(j *JoinNode) { j.Full().FillFor("requests", int64(0)) },
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stream1 := &StreamNode{}
+			stream2 := &StreamNode{}
+			CreatePipelineSources(stream1, stream2)
+			j := stream1.From().Join(stream2.From())
+			j.As("requests", "errors")
+			tc.join(j)
+
+			err := j.validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("unexpected error got %v exp %s", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -200,6 +200,23 @@ func init() {
 		"changeDetect":      func(parent chainnodeAlias) Node { return parent.ChangeDetect("") },
 		"delete":            func(parent chainnodeAlias) Node { return parent.Delete() },
 		"default":           func(parent chainnodeAlias) Node { return parent.Default() },
+		"dedup":             func(parent chainnodeAlias) Node { return parent.Dedup() },
+		"anomaly":           func(parent chainnodeAlias) Node { return parent.Anomaly("") },
+		"quantiles":         func(parent chainnodeAlias) Node { return parent.Quantiles("") },
+		"histogram":         func(parent chainnodeAlias) Node { return parent.Histogram("") },
+		"rate":              func(parent chainnodeAlias) Node { return parent.Rate("") },
+		"increase":          func(parent chainnodeAlias) Node { return parent.Increase("") },
+		"forecast":          func(parent chainnodeAlias) Node { return parent.Forecast("") },
+		"topK":              func(parent chainnodeAlias) Node { return parent.TopK(0, "") },
+		"pivot":             func(parent chainnodeAlias) Node { return parent.Pivot() },
+		"unpivot":           func(parent chainnodeAlias) Node { return parent.Unpivot() },
+		"rename":            func(parent chainnodeAlias) Node { return parent.Rename() },
+		"fill":              func(parent chainnodeAlias) Node { return parent.Fill() },
+		"switch":            func(parent chainnodeAlias) Node { return parent.Switch() },
+		"throttle":          func(parent chainnodeAlias) Node { return parent.Throttle() },
+		"schedule":          func(parent chainnodeAlias) Node { return parent.Schedule() },
+		"rollup":            func(parent chainnodeAlias) Node { return parent.Rollup() },
+		"slo":               func(parent chainnodeAlias) Node { return parent.Slo() },
 		"combine":           func(parent chainnodeAlias) Node { return parent.Combine(nil) },
 		"alert":             func(parent chainnodeAlias) Node { return parent.Alert() },
 	}
@@ -207,6 +224,9 @@ func init() {
 	multiParents = map[string]func(chainnodeAlias, []Node) Node{
 		"union": func(parent chainnodeAlias, nodes []Node) Node { return parent.Union(nodes...) },
 		"join":  func(parent chainnodeAlias, nodes []Node) Node { return parent.Join(nodes...) },
+		"lookupJoin": func(parent chainnodeAlias, nodes []Node) Node {
+			return parent.LookupJoin(nodes[0])
+		},
 	}
 
 	influxFunctions = map[string]func(chainnodeAlias, string) *InfluxQLNode{
@@ -239,6 +259,7 @@ func init() {
 		"where":   unmarshalWhere,
 		"groupBy": unmarshalGroupby,
 		"udf":     unmarshalUDF,
+		"branch":  unmarshalBranch,
 	}
 }
 
@@ -448,6 +469,19 @@ func unmarshalGroupby(data []byte, parents []Node, typ TypeOf) (Node, error) {
 	return child, err
 }
 
+func unmarshalBranch(data []byte, parents []Node, typ TypeOf) (Node, error) {
+	if len(parents) != 1 {
+		return nil, fmt.Errorf("expected one parent for node %d but found %d", typ.ID, len(parents))
+	}
+	parent, ok := parents[0].(*SwitchNode)
+	if !ok {
+		return nil, fmt.Errorf("parent of branch node must be a SwitchNode but is %T", parents[0])
+	}
+	child := parent.Branch("")
+	err := json.Unmarshal(data, child)
+	return child, err
+}
+
 func unmarshalStats(data []byte, parents []Node, typ TypeOf) (Node, error) {
 	if len(parents) != 1 {
 		return nil, fmt.Errorf("expected one parent for node %d but found %d", typ.ID, len(parents))
@@ -527,6 +561,7 @@ func isChainNode(node Node) (chainnodeAlias, bool) {
 // chainnodeAlias is used to check for the presence of a chain node
 type chainnodeAlias interface {
 	Alert() *AlertNode
+	Anomaly(string) *AnomalyNode
 	Bottom(int64, string, ...string) *InfluxQLNode
 	Children() []Node
 	Combine(...*ast.LambdaNode) *CombineNode
@@ -534,6 +569,7 @@ type chainnodeAlias interface {
 	CumulativeSum(string) *InfluxQLNode
 	Deadman(float64, time.Duration, ...*ast.LambdaNode) *AlertNode
 	Default() *DefaultNode
+	Dedup() *DedupNode
 	Delete() *DeleteNode
 	Derivative(string) *DerivativeNode
 	ChangeDetect(...string) *ChangeDetectNode
@@ -542,19 +578,24 @@ type chainnodeAlias interface {
 	Distinct(string) *InfluxQLNode
 	Elapsed(string, time.Duration) *InfluxQLNode
 	Eval(...*ast.LambdaNode) *EvalNode
+	Fill() *FillNode
 	First(string) *InfluxQLNode
 	Flatten() *FlattenNode
+	Forecast(string) *ForecastNode
+	Histogram(string) *HistogramNode
 	HoltWinters(string, int64, int64, time.Duration) *InfluxQLNode
 	HoltWintersWithFit(string, int64, int64, time.Duration) *InfluxQLNode
 	HttpOut(string) *HTTPOutNode
 	HttpPost(...string) *HTTPPostNode
 	ID() ID
+	Increase(string) *RateNode
 	InfluxDBOut() *InfluxDBOutNode
 	Join(...Node) *JoinNode
 	K8sAutoscale() *K8sAutoscaleNode
 	KapacitorLoopback() *KapacitorLoopbackNode
 	Last(string) *InfluxQLNode
 	Log() *LogNode
+	LookupJoin(Node) *LookupJoinNode
 	Max(string) *InfluxQLNode
 	Mean(string) *InfluxQLNode
 	Median(string) *InfluxQLNode
@@ -564,11 +605,18 @@ type chainnodeAlias interface {
 	Name() string
 	Parents() []Node
 	Percentile(string, float64) *InfluxQLNode
+	Pivot() *PivotNode
 	Provides() EdgeType
+	Quantiles(string) *QuantilesNode
+	Rate(string) *RateNode
+	Rename() *RenameNode
+	Rollup(...string) *RollupNode
 	Sample(interface{}) *SampleNode
+	Schedule() *ScheduleNode
 	SetName(string)
 	Shift(time.Duration) *ShiftNode
 	Sideload() *SideloadNode
+	Slo() *SloNode
 	Spread(string) *InfluxQLNode
 	StateCount(*ast.LambdaNode) *StateCountNode
 	StateDuration(*ast.LambdaNode) *StateDurationNode
@@ -576,8 +624,12 @@ type chainnodeAlias interface {
 	Stddev(string) *InfluxQLNode
 	Sum(string) *InfluxQLNode
 	SwarmAutoscale() *SwarmAutoscaleNode
+	Switch() *SwitchNode
+	Throttle() *ThrottleNode
 	Top(int64, string, ...string) *InfluxQLNode
+	TopK(int64, string) *TopKNode
 	Union(...Node) *UnionNode
+	Unpivot() *UnpivotNode
 	Wants() EdgeType
 	Window() *WindowNode
 	addParent(Node)
@@ -231,6 +231,8 @@ func TestPipeline_MarshalJSON(t *testing.T) {
             "stateChangesOnly": true,
             "stateChangesOnlyDuration": 0,
             "inhibitors": null,
+            "activeDuring": null,
+            "inactiveDuring": null,
             "post": [
                 {
                     "url": "http://howdy.local",
@@ -0,0 +1,142 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Enriches each point of a stream with the data of a slowly changing table.
+// The table is the result of a batch query, the most recent point of the table
+// for each combination of the `on` tags is kept.
+// Each stream point is enriched with the fields and tags of the table point that has the same `on` tag values.
+// Unlike a join, points are not matched on time.
+//
+// Fields and tags that already exist on the stream point are not overwritten.
+// Stream points without a matching table point are passed on unchanged.
+//
+// Within a stream task the `batch` variable can be used to define the table,
+// but it can only be used as the table of a lookupJoin.
+//
+// Example:
+//
+//	var owners = batch
+//	    |query('SELECT last("owner") AS "owner", last("team") AS "team" FROM "cmdb"."autogen"."hosts"')
+//	        .period(1d)
+//	        .every(10m)
+//	        .groupBy('host')
+//	stream
+//	    |from()
+//	        .measurement('cpu')
+//	    |lookupJoin(owners)
+//	        .on('host')
+//	        .prefix('cmdb.')
+//	    |alert()
+//	        .crit(lambda: "usage_idle" < 10)
+//	        .message('{{ index .Fields "cmdb.owner" }} your host {{ index .Tags "host" }} is busy')
+//
+// The above example queries the owner of each host every 10 minutes
+// and adds the owner and team fields to each cpu point for that host.
+type LookupJoinNode struct {
+	chainnode `json:"-"`
+
+	// The tags on which to match stream points with table points.
+	// tick:ignore
+	Dimensions []string `tick:"On" json:"on"`
+
+	// The prefix added to the names of the fields from the table.
+	Prefix string `json:"prefix"`
+
+	// The node that provides the table, the parents only keep its base node.
+	table Node
+}
+
+func newLookupJoinNode(stream, table Node) *LookupJoinNode {
+	j := &LookupJoinNode{
+		chainnode: newBasicChainNode("lookupJoin", StreamEdge, StreamEdge),
+		table:     table,
+	}
+	stream.linkChild(j)
+	table.linkChild(j)
+	return j
+}
+
+// MarshalJSON converts LookupJoinNode to JSON
+// tick:ignore
+func (n *LookupJoinNode) MarshalJSON() ([]byte, error) {
+	type Alias LookupJoinNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "lookupJoin",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an LookupJoinNode
+// tick:ignore
+func (n *LookupJoinNode) UnmarshalJSON(data []byte) error {
+	type Alias LookupJoinNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "lookupJoin" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as LookupJoinNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// The tags on which to match stream points with table points.
+// tick:property
+func (n *LookupJoinNode) On(dims ...string) *LookupJoinNode {
+	n.Dimensions = dims
+	return n
+}
+
+// Table returns the node that provides the table.
+// tick:ignore
+func (n *LookupJoinNode) Table() Node {
+	return n.table
+}
+
+func (n *LookupJoinNode) validate() error {
+	if len(n.Dimensions) == 0 {
+		return errors.New("a call to lookupJoin.on() is required to specify the tags to match on")
+	}
+	if len(n.Parents()) != 2 {
+		return fmt.Errorf("lookupJoin requires exactly one table, got %d", len(n.Parents())-1)
+	}
+	if p := n.Table().Provides(); p != BatchEdge {
+		return fmt.Errorf("the table of lookupJoin must provide a batch edge, got %s", p)
+	}
+	return nil
+}
+
+// validateLookupTables validates that the batch source of a stream pipeline is only used as the table of a lookupJoin.
+func validateLookupTables(table Node) error {
+	for _, c := range table.Children() {
+		if _, ok := c.(*LookupJoinNode); ok {
+			continue
+		}
+		if len(c.Children()) == 0 {
+			return errors.New("batch data in a stream task must be joined into the stream using lookupJoin")
+		}
+		if err := validateLookupTables(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
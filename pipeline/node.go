@@ -390,6 +390,16 @@ func (n *chainnode) Join(others ...Node) *JoinNode {
 	return j
 }
 
+// Enrich this stream with the most recent data of a table, the table is matched on tags and not on time.
+//
+// NOTE: LookupJoin can only be applied to stream edges and the table must be a batch edge.
+func (n *chainnode) LookupJoin(table Node) *LookupJoinNode {
+	if n.Provides() != StreamEdge {
+		panic("cannot lookupJoin batch edge")
+	}
+	return newLookupJoinNode(n, table)
+}
+
 // Combine this node with itself. The data are combined on timestamp.
 func (n *chainnode) Combine(expressions ...*ast.LambdaNode) *CombineNode {
 	c := newCombineNode(n.provides, expressions)
@@ -446,6 +456,139 @@ func (n *chainnode) Barrier() *BarrierNode {
 	return b
 }
 
+// Create a new node that ranks the k items with the highest values of a field across all groups.
+//
+// NOTE: TopK can only be applied to batch edges.
+func (n *chainnode) TopK(k int64, field string) *TopKNode {
+	if n.Provides() != BatchEdge {
+		panic("cannot topK stream edge")
+	}
+	t := newTopKNode(k, field)
+	n.linkChild(t)
+	return t
+}
+
+// Create a new node that continuously forecasts a field.
+func (n *chainnode) Forecast(field string) *ForecastNode {
+	f := newForecastNode(n.provides, field)
+	n.linkChild(f)
+	return f
+}
+
+// Create a new node that computes the rate of a counter, accounting for counter resets.
+func (n *chainnode) Rate(field string) *RateNode {
+	r := newRateNode(RateMethod, n.provides, field)
+	n.linkChild(r)
+	return r
+}
+
+// Create a new node that computes the increase of a counter, accounting for counter resets.
+func (n *chainnode) Increase(field string) *RateNode {
+	r := newRateNode(IncreaseMethod, n.provides, field)
+	n.linkChild(r)
+	return r
+}
+
+// Create a new node that computes approximate quantiles of a field using a sketch.
+func (n *chainnode) Quantiles(field string) *QuantilesNode {
+	q := newQuantilesNode(n.provides, field)
+	n.linkChild(q)
+	return q
+}
+
+// Create a new node that computes an approximate histogram of a field using a sketch.
+func (n *chainnode) Histogram(field string) *HistogramNode {
+	h := newHistogramNode(n.provides, field)
+	n.linkChild(h)
+	return h
+}
+
+// Create a new node that scores points by how far a field deviates from its baseline.
+func (n *chainnode) Anomaly(field string) *AnomalyNode {
+	a := newAnomalyNode(n.provides, field)
+	n.linkChild(a)
+	return a
+}
+
+// Create a new node that turns the values of tags into fields.
+func (n *chainnode) Pivot() *PivotNode {
+	p := newPivotNode(n.provides)
+	n.linkChild(p)
+	return p
+}
+
+// Create a new node that turns multiple fields into separate points.
+func (n *chainnode) Unpivot() *UnpivotNode {
+	u := newUnpivotNode(n.provides)
+	n.linkChild(u)
+	return u
+}
+
+// Create a new node that renames measurements, tags and fields.
+func (n *chainnode) Rename() *RenameNode {
+	r := newRenameNode(n.provides)
+	n.linkChild(r)
+	return r
+}
+
+// Create a new node that fills missing intervals with synthetic points.
+func (n *chainnode) Fill() *FillNode {
+	f := newFillNode(n.provides)
+	n.linkChild(f)
+	return f
+}
+
+// Create a new node that routes each point to exactly one named branch.
+func (n *chainnode) Switch() *SwitchNode {
+	s := newSwitchNode(n.provides)
+	n.linkChild(s)
+	return s
+}
+
+// Create a new node that limits the rate of points with a token bucket per group.
+func (n *chainnode) Throttle() *ThrottleNode {
+	t := newThrottleNode(n.provides)
+	n.linkChild(t)
+	return t
+}
+
+// Create a new node that passes or drops points based on the time of day and calendar.
+func (n *chainnode) Schedule() *ScheduleNode {
+	s := newScheduleNode(n.provides)
+	n.linkChild(s)
+	return s
+}
+
+// Group the data at every level of a hierarchy of dimensions, like SQL ROLLUP.
+//
+// Example:
+//
+//	|rollup('region', 'cluster', 'host')
+func (n *chainnode) Rollup(dimensions ...string) *RollupNode {
+	r := newRollupNode(n.provides, dimensions)
+	n.linkChild(r)
+	return r
+}
+
+// Create a new node that computes the burn rate and remaining error budget of a service level objective.
+func (n *chainnode) Slo() *SloNode {
+	s := newSloNode(n.provides)
+	n.linkChild(s)
+	return s
+}
+
+// Create a new node that drops duplicate points.
+//
+// NOTE: Dedup can only be applied to stream edges.
+func (n *chainnode) Dedup() *DedupNode {
+	if n.Provides() != StreamEdge {
+		panic("cannot dedup batch edge")
+	}
+	d := newDedupNode()
+	n.linkChild(d)
+	return d
+}
+
 // Create a new node that samples the incoming points or batches.
 //
 // One point will be emitted every count or duration specified.
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/influxdata/kapacitor/tick"
+	"github.com/influxdata/kapacitor/tick/ast"
 	"github.com/influxdata/kapacitor/tick/stateful"
 )
 
@@ -77,10 +78,16 @@ func createPipelineAndVars(
 	ignoreMissingVars bool,
 ) (*Pipeline, map[string]tick.Var, error) {
 	var src Node
+	// table is the batch source of a stream task, it can only be used by lookupJoin.
+	var table *BatchNode
 	switch sourceEdge {
 	case StreamEdge:
 		src = newStreamNode()
 		scope.Set("stream", src)
+		if usesLookupJoin(script) {
+			table = newBatchNode()
+			scope.Set("batch", table)
+		}
 	case BatchEdge:
 		src = newBatchNode()
 		scope.Set("batch", src)
@@ -90,11 +97,20 @@ func createPipelineAndVars(
 
 	p := CreatePipelineSources(src)
 	p.deadman = deadman
+	if table != nil {
+		table.setPipeline(p)
+	}
 
 	vars, err := tick.Evaluate(script, scope, predefinedVars, ignoreMissingVars)
 	if err != nil {
 		return nil, nil, err
 	}
+	if table != nil && len(table.Children()) > 0 {
+		if err := validateLookupTables(table); err != nil {
+			return nil, nil, err
+		}
+		p.addSource(table)
+	}
 	if deadman.Global() {
 		switch s := src.(type) {
 		case *StreamNode:
@@ -111,6 +127,21 @@ func createPipelineAndVars(
 	return p, vars, nil
 }
 
+// usesLookupJoin reports whether the script calls lookupJoin.
+// Scripts that fail to parse report their error when they are evaluated.
+func usesLookupJoin(script string) bool {
+	root, err := ast.Parse(script)
+	if err != nil {
+		return false
+	}
+	for _, f := range ast.FindFunctionCalls(root) {
+		if f == "lookupJoin" {
+			return true
+		}
+	}
+	return false
+}
+
 // A complete data processing pipeline. Starts with a single source.
 // tick:ignore
 type Pipeline struct {
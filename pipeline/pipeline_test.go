@@ -67,6 +67,53 @@ w.every(1s)
 	}
 }
 
+func TestTICK_To_Pipeline_LookupJoin(t *testing.T) {
+	var tickScript = `
+var owners = batch
+	|query('SELECT owner FROM "cmdb"."autogen"."hosts"')
+		.period(1d)
+		.every(10m)
+		.groupBy('host')
+
+stream
+	|from()
+		.measurement('cpu')
+	|lookupJoin(owners)
+		.on('host')
+`
+
+	scope := stateful.NewScope()
+	p, err := CreatePipeline(tickScript, StreamEdge, scope, deadman{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := 2, len(p.sources); exp != got {
+		t.Fatalf("unexpected number of pipeline sources: exp %d got %d", exp, got)
+	}
+	from := p.sources[0].Children()[0]
+	lookup, ok := from.Children()[0].(*LookupJoinNode)
+	if !ok {
+		t.Fatalf("unexpected node type: exp LookupJoinNode got %T", from.Children()[0])
+	}
+	if _, ok := lookup.Table().(*QueryNode); !ok {
+		t.Errorf("unexpected table type: exp QueryNode got %T", lookup.Table())
+	}
+	if exp, got := []string{"host"}, lookup.Dimensions; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected dimensions exp %v got %v", exp, got)
+	}
+
+	var invalidScript = `
+batch
+	|query('SELECT owner FROM "cmdb"."autogen"."hosts"')
+		.period(1d)
+		.every(10m)
+	|log()
+`
+	if _, err := CreatePipeline(invalidScript, StreamEdge, stateful.NewScope(), deadman{}, nil); err == nil {
+		t.Error("expected error using batch data in a stream task without lookupJoin")
+	}
+}
+
 func TestPipelineSort(t *testing.T) {
 	assert := assert.New(t)
 
@@ -0,0 +1,252 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	defaultPivotDelimiter = "_"
+	defaultPivotValue     = "value"
+	defaultUnpivotTag     = "field"
+)
+
+// Pivot turns the values of column key tags into fields, the same way the Flux pivot function does.
+// Points with the same time and the same row key tags become a single point,
+// with a field for each distinct combination of column key tag values.
+// The value of each such field is the value field of the point.
+//
+// For example given the points:
+//
+// m,host=A,metric=cpu value=0.5
+// m,host=A,metric=mem value=0.75
+// m,host=B,metric=cpu value=0.25
+//
+// Pivoting with row key `host` and column key `metric` results in the points:
+//
+// m,host=A cpu=0.5,mem=0.75
+// m,host=B cpu=0.25
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('m')
+//	        .groupBy('host', 'metric')
+//	    |pivot()
+//	        .rowKey('host')
+//	        .columnKey('metric')
+//	        .valueField('value')
+//
+// The resulting points only keep the row key tags and are grouped by them.
+// If multiple column keys are given their values are joined with the delimiter.
+//
+// For streams all points with the same time are pivoted together,
+// so a row is emitted once a point with a later time arrives.
+// For batches each batch is pivoted on its own.
+type PivotNode struct {
+	chainnode `json:"-"`
+
+	// The tags that identify a row.
+	// tick:ignore
+	RowKeys []string `tick:"RowKey" json:"rowKey"`
+
+	// The tags whose values become the field names.
+	// tick:ignore
+	ColumnKeys []string `tick:"ColumnKey" json:"columnKey"`
+
+	// The field that holds the value of each column.
+	// Default: value
+	ValueField string `json:"valueField"`
+
+	// The delimiter between the values of multiple column keys.
+	// Default: _
+	Delimiter string `json:"delimiter"`
+}
+
+func newPivotNode(e EdgeType) *PivotNode {
+	return &PivotNode{
+		chainnode:  newBasicChainNode("pivot", e, e),
+		ValueField: defaultPivotValue,
+		Delimiter:  defaultPivotDelimiter,
+	}
+}
+
+// MarshalJSON converts PivotNode to JSON
+// tick:ignore
+func (n *PivotNode) MarshalJSON() ([]byte, error) {
+	type Alias PivotNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "pivot",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an PivotNode
+// tick:ignore
+func (n *PivotNode) UnmarshalJSON(data []byte) error {
+	type Alias PivotNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "pivot" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as PivotNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// The tags that identify a row.
+// tick:property
+func (n *PivotNode) RowKey(tags ...string) *PivotNode {
+	n.RowKeys = tags
+	return n
+}
+
+// The tags whose values become the field names.
+// tick:property
+func (n *PivotNode) ColumnKey(tags ...string) *PivotNode {
+	n.ColumnKeys = tags
+	return n
+}
+
+func (n *PivotNode) validate() error {
+	if len(n.ColumnKeys) == 0 {
+		return errors.New("must provide at least one column key to pivot")
+	}
+	for _, c := range n.ColumnKeys {
+		for _, r := range n.RowKeys {
+			if c == r {
+				return fmt.Errorf("tag %q cannot be both a row key and a column key", c)
+			}
+		}
+	}
+	if n.ValueField == "" {
+		return errors.New("pivot valueField must not be empty")
+	}
+	return nil
+}
+
+// Unpivot turns multiple fields into separate points, the reverse of pivot.
+// Each point becomes one point per field,
+// with the field name in a tag and the field value in the value field.
+// Fields that are not unpivoted are kept on each of the points.
+//
+// For example given the point:
+//
+// m,host=A cpu=0.5,mem=0.75
+//
+// Unpivoting results in the points:
+//
+// m,host=A,field=cpu value=0.5
+// m,host=A,field=mem value=0.75
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('m')
+//	    |unpivot()
+//	        .fields('cpu', 'mem')
+//	        .tag('metric')
+//
+// The above example emits a point for each of the cpu and mem fields, with the field name in the metric tag.
+// If no fields are given all fields are unpivoted.
+type UnpivotNode struct {
+	chainnode `json:"-"`
+
+	// The fields to unpivot, all fields if empty.
+	// tick:ignore
+	FieldNames []string `tick:"Fields" json:"fields"`
+
+	// The tag that holds the name of the field.
+	// Default: field
+	Tag string `json:"tag"`
+
+	// The field that holds the value of the field.
+	// Default: value
+	ValueField string `json:"valueField"`
+}
+
+func newUnpivotNode(e EdgeType) *UnpivotNode {
+	return &UnpivotNode{
+		chainnode:  newBasicChainNode("unpivot", e, e),
+		Tag:        defaultUnpivotTag,
+		ValueField: defaultPivotValue,
+	}
+}
+
+// MarshalJSON converts UnpivotNode to JSON
+// tick:ignore
+func (n *UnpivotNode) MarshalJSON() ([]byte, error) {
+	type Alias UnpivotNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "unpivot",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an UnpivotNode
+// tick:ignore
+func (n *UnpivotNode) UnmarshalJSON(data []byte) error {
+	type Alias UnpivotNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "unpivot" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as UnpivotNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// The fields to unpivot.
+// tick:property
+func (n *UnpivotNode) Fields(fields ...string) *UnpivotNode {
+	n.FieldNames = fields
+	return n
+}
+
+func (n *UnpivotNode) validate() error {
+	if n.Tag == "" {
+		return errors.New("unpivot tag must not be empty")
+	}
+	if n.ValueField == "" {
+		return errors.New("unpivot valueField must not be empty")
+	}
+	for _, f := range n.FieldNames {
+		if f == n.ValueField {
+			return fmt.Errorf("cannot unpivot the value field %q", f)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	defaultSketchRelativeAccuracy = 0.01
+	defaultSketchMaxBins          = 2048
+)
+
+// Compute approximate quantiles of a field using a mergeable sketch.
+// Unlike the percentile function only the sketch is retained, so memory is bounded regardless of the number of points.
+//
+// A field is added for each quantile, named `p` followed by the quantile as a percentage,
+// e.g. p50, p90 and p99.9.
+//
+// The sketch itself can be emitted as a string field using `.sketchAs()`.
+// Points whose field is such a string sketch are merged instead of added,
+// so sketches produced by other tasks can be combined.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('requests')
+//	        .groupBy('service')
+//	    |window()
+//	        .period(1m)
+//	        .every(1m)
+//	    |quantiles('latency')
+//	        .q(0.5, 0.9, 0.99)
+//	        .sketchAs('latency_sketch')
+//	    |influxDBOut()
+//	        .database('rollups')
+//	        .measurement('latency')
+//
+// Example:
+//
+//	batch
+//	    |query('SELECT "latency_sketch" FROM "rollups"."autogen"."latency"')
+//	        .period(1h)
+//	        .every(1h)
+//	    |quantiles('latency_sketch')
+//	        .q(0.99)
+//
+// The above example merges the sketches written by the first example into hourly quantiles.
+type QuantilesNode struct {
+	chainnode `json:"-"`
+
+	// The field of values or sketches.
+	// tick:ignore
+	Field string `json:"field"`
+
+	// The quantiles to compute, between 0 and 1.
+	// tick:ignore
+	Quantiles []float64 `tick:"Q" json:"q"`
+
+	// The relative accuracy of the sketch.
+	// Default: 0.01
+	RelativeAccuracy float64 `json:"relativeAccuracy"`
+
+	// The maximum number of bins of the sketch.
+	// Beyond this number the accuracy of the lowest quantiles decreases.
+	// Default: 2048
+	MaxBins int64 `json:"maxBins"`
+
+	// The name of the field to emit the encoded sketch to.
+	// If empty the sketch is not emitted.
+	SketchAs string `json:"sketchAs"`
+}
+
+func newQuantilesNode(wants EdgeType, field string) *QuantilesNode {
+	return &QuantilesNode{
+		chainnode:        newBasicChainNode("quantiles", wants, StreamEdge),
+		Field:            field,
+		RelativeAccuracy: defaultSketchRelativeAccuracy,
+		MaxBins:          defaultSketchMaxBins,
+	}
+}
+
+// MarshalJSON converts QuantilesNode to JSON
+// tick:ignore
+func (n *QuantilesNode) MarshalJSON() ([]byte, error) {
+	type Alias QuantilesNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "quantiles",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an QuantilesNode
+// tick:ignore
+func (n *QuantilesNode) UnmarshalJSON(data []byte) error {
+	type Alias QuantilesNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "quantiles" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as QuantilesNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// The quantiles to compute, between 0 and 1.
+// tick:property
+func (n *QuantilesNode) Q(quantiles ...float64) *QuantilesNode {
+	n.Quantiles = quantiles
+	return n
+}
+
+func (n *QuantilesNode) validate() error {
+	if n.Field == "" {
+		return errors.New("must provide the field of the quantiles")
+	}
+	if len(n.Quantiles) == 0 {
+		return errors.New("a call to quantiles.q() is required to specify the quantiles")
+	}
+	for _, q := range n.Quantiles {
+		if q < 0 || q > 1 {
+			return fmt.Errorf("quantile must be between 0 and 1, got %v", q)
+		}
+	}
+	return validateSketch(n.RelativeAccuracy, n.MaxBins)
+}
+
+func validateSketch(relativeAccuracy float64, maxBins int64) error {
+	if relativeAccuracy <= 0 || relativeAccuracy >= 1 {
+		return fmt.Errorf("relativeAccuracy must be between 0 and 1, got %v", relativeAccuracy)
+	}
+	if maxBins <= 0 {
+		return fmt.Errorf("maxBins must be positive, got %d", maxBins)
+	}
+	return nil
+}
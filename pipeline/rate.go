@@ -0,0 +1,187 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxql"
+)
+
+const (
+	// RateMethod computes the per unit rate of increase of a counter.
+	RateMethod = "rate"
+	// IncreaseMethod computes the increase of a counter.
+	IncreaseMethod = "increase"
+)
+
+// Compute the rate or increase of a monotonically increasing counter, accounting for counter resets.
+// A value that is lower than the previous value is treated as a reset of the counter to zero,
+// so that the increase since the reset is not lost like it is with `derivative().nonNegative()`.
+//
+// On a stream edge each point gets the rate or increase since the previous point of its group.
+// The first point of a group is dropped.
+//
+// On a batch edge a single point is emitted for each batch, with the rate or increase over the batch.
+// Like PromQL the result is extrapolated to the edges of the range of the batch.
+// The range defaults to the period of the window or query that produced the batch.
+// When no range is known the result is not extrapolated.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('http_requests_total')
+//	        .groupBy('instance')
+//	    |window()
+//	        .period(5m)
+//	        .every(1m)
+//	    |rate('counter')
+//	        .unit(1s)
+//	    |alert()
+//	        .crit(lambda: "rate" > 100.0)
+//
+// The above example alerts when an instance serves more than 100 requests per second over the last 5 minutes.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('http_requests_total')
+//	    |increase('counter')
+//	        .as('requests')
+//
+// The above example adds the number of requests since the previous point to each point.
+type RateNode struct {
+	chainnode `json:"-"`
+
+	// The method, either rate or increase.
+	// tick:ignore
+	Method string `json:"-"`
+
+	// The counter field.
+	// tick:ignore
+	Field string `json:"field"`
+
+	// The name of the resulting field.
+	// Default: the name of the method, rate or increase.
+	As string `json:"as"`
+
+	// The time unit of the rate.
+	// Default: 1s
+	Unit time.Duration `json:"unit"`
+
+	// The range of batches to extrapolate to.
+	// Default: the period of the parent window or query.
+	Range time.Duration `json:"range"`
+}
+
+func newRateNode(method string, wants EdgeType, field string) *RateNode {
+	return &RateNode{
+		chainnode: newBasicChainNode(method, wants, StreamEdge),
+		Method:    method,
+		Field:     field,
+		As:        method,
+		Unit:      time.Second,
+	}
+}
+
+// MarshalJSON converts RateNode to JSON
+// tick:ignore
+func (n *RateNode) MarshalJSON() ([]byte, error) {
+	type Alias RateNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Unit  string `json:"unit"`
+		Range string `json:"range,omitempty"`
+	}{
+		TypeOf: TypeOf{
+			Type: n.Method,
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+		Unit:  influxql.FormatDuration(n.Unit),
+	}
+	if n.Range != 0 {
+		raw.Range = influxql.FormatDuration(n.Range)
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an RateNode
+// tick:ignore
+func (n *RateNode) UnmarshalJSON(data []byte) error {
+	type Alias RateNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Unit  string `json:"unit"`
+		Range string `json:"range"`
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != RateMethod && raw.Type != IncreaseMethod {
+		return fmt.Errorf("error unmarshaling node %d of type %s as RateNode", raw.ID, raw.Type)
+	}
+	n.Method = raw.Type
+	n.Unit, err = influxql.ParseDuration(raw.Unit)
+	if err != nil {
+		return err
+	}
+	if raw.Range != "" {
+		n.Range, err = influxql.ParseDuration(raw.Range)
+		if err != nil {
+			return err
+		}
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// BatchRange returns the range to extrapolate batches to.
+// It is the configured range or the period of the nearest parent window or query.
+// tick:ignore
+func (n *RateNode) BatchRange() time.Duration {
+	if n.Range != 0 {
+		return n.Range
+	}
+	// The parents are the embedded base nodes, look up the nodes themselves by ID.
+	nodes := make(map[ID]Node)
+	_ = n.pipeline().Walk(func(pn Node) error {
+		nodes[pn.ID()] = pn
+		return nil
+	})
+	var node Node = n
+	for len(node.Parents()) == 1 {
+		node = nodes[node.Parents()[0].ID()]
+		switch p := node.(type) {
+		case *WindowNode:
+			return p.Period
+		case *QueryNode:
+			return p.Period
+		}
+	}
+	return 0
+}
+
+func (n *RateNode) validate() error {
+	if n.Field == "" {
+		return fmt.Errorf("must provide the counter field of %s", n.Method)
+	}
+	if n.As == "" {
+		return fmt.Errorf("%s as must not be empty", n.Method)
+	}
+	if n.Unit <= 0 {
+		return errors.New("unit must be positive")
+	}
+	if n.Range < 0 {
+		return errors.New("range must not be negative")
+	}
+	return nil
+}
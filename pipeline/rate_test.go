@@ -0,0 +1,30 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateNode_BatchRange(t *testing.T) {
+	stream := &StreamNode{}
+	CreatePipelineSources(stream)
+	w := stream.From().Window()
+	w.Period = 5 * time.Minute
+	w.Every = time.Minute
+	r := w.Rate("counter")
+	if got, exp := r.BatchRange(), 5*time.Minute; got != exp {
+		t.Errorf("unexpected range got %v exp %v", got, exp)
+	}
+	r.Range = time.Minute
+	if got, exp := r.BatchRange(), time.Minute; got != exp {
+		t.Errorf("unexpected range got %v exp %v", got, exp)
+	}
+
+	batch := &BatchNode{}
+	CreatePipelineSources(batch)
+	q := batch.Query(`SELECT "counter" FROM "db"."rp"."requests"`)
+	q.Period = 10 * time.Minute
+	if got, exp := q.Increase("counter").BatchRange(), 10*time.Minute; got != exp {
+		t.Errorf("unexpected range got %v exp %v", got, exp)
+	}
+}
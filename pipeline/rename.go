@@ -0,0 +1,208 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Rename measurements, tags and fields.
+// Names can be renamed exactly or rewritten with a regular expression,
+// which makes it possible to harmonize data from different collectors.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('container_cpu')
+//	    |rename()
+//	        .measurement('container_cpu', 'cpu')
+//	        .tag('hostname', 'host')
+//	        .tagRegex(/^k8s_(.*)/, '$1')
+//	        .field('usage_percent', 'usage')
+//	    |influxDBOut()
+//	        .database('telegraf')
+//
+// The above example renames the container_cpu measurement to cpu,
+// the hostname tag to host, strips the k8s_ prefix from all tags and renames the usage_percent field to usage.
+//
+// A name is renamed at most once.
+// An exact rename takes precedence, otherwise the first matching regular expression is used.
+// Regular expression replacements may reference submatches as $1 or ${name}.
+// If a renamed tag or field already exists it is overwritten.
+//
+// Renamed tags that are part of the group by dimensions are also renamed in the dimensions.
+type RenameNode struct {
+	chainnode `json:"-"`
+
+	// Exact renames of measurements.
+	// tick:ignore
+	Measurements map[string]string `tick:"Measurement" json:"measurements"`
+
+	// Regular expression renames of measurements.
+	// tick:ignore
+	MeasurementRegexes []*RenameRegex `tick:"MeasurementRegex" json:"measurementRegexes"`
+
+	// Exact renames of tags.
+	// tick:ignore
+	Tags map[string]string `tick:"Tag" json:"tags"`
+
+	// Regular expression renames of tags.
+	// tick:ignore
+	TagRegexes []*RenameRegex `tick:"TagRegex" json:"tagRegexes"`
+
+	// Exact renames of fields.
+	// tick:ignore
+	Fields map[string]string `tick:"Field" json:"fields"`
+
+	// Regular expression renames of fields.
+	// tick:ignore
+	FieldRegexes []*RenameRegex `tick:"FieldRegex" json:"fieldRegexes"`
+}
+
+// RenameRegex rewrites the names that match a regular expression.
+type RenameRegex struct {
+	Regex       *regexp.Regexp
+	Replacement string
+}
+
+// MarshalJSON converts RenameRegex to JSON
+func (r *RenameRegex) MarshalJSON() ([]byte, error) {
+	var pattern string
+	if r.Regex != nil {
+		pattern = r.Regex.String()
+	}
+	return json.Marshal(struct {
+		Regex       string `json:"regex"`
+		Replacement string `json:"replacement"`
+	}{
+		Regex:       pattern,
+		Replacement: r.Replacement,
+	})
+}
+
+// UnmarshalJSON converts JSON to a RenameRegex
+func (r *RenameRegex) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Regex       string `json:"regex"`
+		Replacement string `json:"replacement"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	regex, err := regexp.Compile(raw.Regex)
+	if err != nil {
+		return err
+	}
+	r.Regex = regex
+	r.Replacement = raw.Replacement
+	return nil
+}
+
+func newRenameNode(e EdgeType) *RenameNode {
+	return &RenameNode{
+		chainnode:    newBasicChainNode("rename", e, e),
+		Measurements: make(map[string]string),
+		Tags:         make(map[string]string),
+		Fields:       make(map[string]string),
+	}
+}
+
+// MarshalJSON converts RenameNode to JSON
+// tick:ignore
+func (n *RenameNode) MarshalJSON() ([]byte, error) {
+	type Alias RenameNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "rename",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an RenameNode
+// tick:ignore
+func (n *RenameNode) UnmarshalJSON(data []byte) error {
+	type Alias RenameNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "rename" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as RenameNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// Rename a measurement.
+// tick:property
+func (n *RenameNode) Measurement(old, new string) *RenameNode {
+	n.Measurements[old] = new
+	return n
+}
+
+// Rename the measurements that match a regular expression.
+// tick:property
+func (n *RenameNode) MeasurementRegex(regex *regexp.Regexp, replacement string) *RenameNode {
+	n.MeasurementRegexes = append(n.MeasurementRegexes, &RenameRegex{Regex: regex, Replacement: replacement})
+	return n
+}
+
+// Rename a tag.
+// tick:property
+func (n *RenameNode) Tag(old, new string) *RenameNode {
+	n.Tags[old] = new
+	return n
+}
+
+// Rename the tags that match a regular expression.
+// tick:property
+func (n *RenameNode) TagRegex(regex *regexp.Regexp, replacement string) *RenameNode {
+	n.TagRegexes = append(n.TagRegexes, &RenameRegex{Regex: regex, Replacement: replacement})
+	return n
+}
+
+// Rename a field.
+// tick:property
+func (n *RenameNode) Field(old, new string) *RenameNode {
+	n.Fields[old] = new
+	return n
+}
+
+// Rename the fields that match a regular expression.
+// tick:property
+func (n *RenameNode) FieldRegex(regex *regexp.Regexp, replacement string) *RenameNode {
+	n.FieldRegexes = append(n.FieldRegexes, &RenameRegex{Regex: regex, Replacement: replacement})
+	return n
+}
+
+func (n *RenameNode) validate() error {
+	for kind, renames := range map[string]map[string]string{"measurement": n.Measurements, "tag": n.Tags, "field": n.Fields} {
+		for old, new := range renames {
+			if new == "" {
+				return fmt.Errorf("cannot rename %s %q to an empty name", kind, old)
+			}
+		}
+	}
+	for _, regexes := range [][]*RenameRegex{n.MeasurementRegexes, n.TagRegexes, n.FieldRegexes} {
+		for _, r := range regexes {
+			if r.Regex == nil {
+				return errors.New("rename regex must not be empty")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// RollupAll is the value of the level tag for the level without dimensions.
+const RollupAll = "all"
+
+// Group the data at every level of a hierarchy of dimensions, like SQL ROLLUP.
+// Each point is emitted once per prefix of the dimensions, from the most detailed level to a single global group,
+// so that the following aggregates are computed for every level in one pass.
+//
+// The level tag is added to each point and to the dimensions of its group.
+// Its value is the last dimension of the level, or `all` for the global level.
+// The tags of the dimensions below the level are removed.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('cpu')
+//	    |rollup('region', 'cluster', 'host')
+//	    |window()
+//	        .period(1m)
+//	        .every(1m)
+//	    |mean('usage_user')
+//	    |influxDBOut()
+//	        .database('rollups')
+//
+// The above example computes the mean per host, per cluster, per region and globally.
+// The level tag is `host`, `cluster`, `region` and `all` respectively.
+type RollupNode struct {
+	chainnode `json:"-"`
+
+	// The ordered dimensions, from the least to the most detailed.
+	// tick:ignore
+	Dimensions []string `json:"dimensions"`
+
+	// The name of the tag indicating the level.
+	// Default: rollup
+	Tag string `json:"tag"`
+}
+
+func newRollupNode(e EdgeType, dimensions []string) *RollupNode {
+	return &RollupNode{
+		chainnode:  newBasicChainNode("rollup", e, e),
+		Dimensions: dimensions,
+		Tag:        "rollup",
+	}
+}
+
+// MarshalJSON converts RollupNode to JSON
+// tick:ignore
+func (n *RollupNode) MarshalJSON() ([]byte, error) {
+	type Alias RollupNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "rollup",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an RollupNode
+// tick:ignore
+func (n *RollupNode) UnmarshalJSON(data []byte) error {
+	type Alias RollupNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "rollup" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as RollupNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+func (n *RollupNode) validate() error {
+	if len(n.Dimensions) == 0 {
+		return errors.New("rollup must have at least one dimension")
+	}
+	if n.Tag == "" {
+		return errors.New("rollup tag must not be empty")
+	}
+	seen := make(map[string]bool, len(n.Dimensions))
+	for _, d := range n.Dimensions {
+		if d == "" {
+			return errors.New("rollup dimension must not be empty")
+		}
+		if seen[d] {
+			return fmt.Errorf("duplicate rollup dimension %q", d)
+		}
+		seen[d] = true
+		if d == n.Tag {
+			return fmt.Errorf("rollup tag %q must not be a dimension", d)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxql"
+)
+
+// Pass or drop points based on the time of day and calendar.
+// By default only the points that fall within the schedule are passed,
+// use `exclude` to drop the points that fall within the schedule instead.
+//
+// A schedule is made of named calendars and of windows.
+// Named calendars are defined in the configuration file or via the calendars API,
+// so the same calendar can be shared by many tasks.
+// A window starts each time its cron expression matches and lasts for its duration.
+// The schedule is active when any of its calendars or windows is active.
+//
+// The schedule is based on the time of the points, not the time they arrive.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('cpu')
+//	    |schedule()
+//	        .calendar('maintenance')
+//	        .exclude()
+//	    |alert()
+//	        .crit(lambda: "usage_idle" < 10)
+//
+// The above example drops the points during the windows of the maintenance calendar.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('requests')
+//	    |schedule()
+//	        .window('0 9 * * 1-5', 8h)
+//	        .timezone('America/New_York')
+//	        .holiday('2026-12-25', '2027-01-01')
+//	    |httpPost('http://example.com/business-hours')
+//
+// The above example passes the points from 9am to 5pm New York time on weekdays, except on holidays.
+type ScheduleNode struct {
+	chainnode `json:"-"`
+
+	// The names of the calendars.
+	// tick:ignore
+	Calendars []string `tick:"Calendar" json:"calendars"`
+
+	// The windows.
+	// tick:ignore
+	Windows []*ScheduleWindow `tick:"Window" json:"windows"`
+
+	// The dates on which the windows are not active, formatted as YYYY-MM-DD.
+	// The holidays do not apply to the named calendars, which define their own holidays.
+	// tick:ignore
+	Holidays []string `tick:"Holiday" json:"holidays"`
+
+	// The time zone of the windows and holidays.
+	// Default: UTC
+	Timezone string `json:"timezone"`
+
+	// Whether to drop the points that fall within the schedule.
+	// tick:ignore
+	IsExclude bool `tick:"Exclude" json:"exclude"`
+}
+
+// ScheduleWindow starts each time the cron expression matches and lasts for the duration.
+type ScheduleWindow struct {
+	Cron     string
+	Duration time.Duration
+}
+
+// MarshalJSON converts ScheduleWindow to JSON
+// tick:ignore
+func (w *ScheduleWindow) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Cron     string `json:"cron"`
+		Duration string `json:"duration"`
+	}{
+		Cron:     w.Cron,
+		Duration: influxql.FormatDuration(w.Duration),
+	})
+}
+
+// UnmarshalJSON converts JSON to a ScheduleWindow
+// tick:ignore
+func (w *ScheduleWindow) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Cron     string `json:"cron"`
+		Duration string `json:"duration"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	d, err := influxql.ParseDuration(raw.Duration)
+	if err != nil {
+		return err
+	}
+	w.Cron = raw.Cron
+	w.Duration = d
+	return nil
+}
+
+func newScheduleNode(e EdgeType) *ScheduleNode {
+	return &ScheduleNode{
+		chainnode: newBasicChainNode("schedule", e, e),
+		Timezone:  "UTC",
+	}
+}
+
+// MarshalJSON converts ScheduleNode to JSON
+// tick:ignore
+func (n *ScheduleNode) MarshalJSON() ([]byte, error) {
+	type Alias ScheduleNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "schedule",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an ScheduleNode
+// tick:ignore
+func (n *ScheduleNode) UnmarshalJSON(data []byte) error {
+	type Alias ScheduleNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "schedule" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as ScheduleNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// Add named calendars to the schedule.
+// tick:property
+func (n *ScheduleNode) Calendar(names ...string) *ScheduleNode {
+	n.Calendars = append(n.Calendars, names...)
+	return n
+}
+
+// Add a window to the schedule, starting each time the cron expression matches and lasting for the duration.
+// See https://github.com/gorhill/cronexpr#implementation for the syntax of the cron expression.
+// tick:property
+func (n *ScheduleNode) Window(cron string, duration time.Duration) *ScheduleNode {
+	n.Windows = append(n.Windows, &ScheduleWindow{
+		Cron:     cron,
+		Duration: duration,
+	})
+	return n
+}
+
+// Add dates on which the windows are not active, formatted as YYYY-MM-DD.
+// tick:property
+func (n *ScheduleNode) Holiday(dates ...string) *ScheduleNode {
+	n.Holidays = append(n.Holidays, dates...)
+	return n
+}
+
+// Drop the points that fall within the schedule, instead of the points that fall outside of it.
+// tick:property
+func (n *ScheduleNode) Exclude() *ScheduleNode {
+	n.IsExclude = true
+	return n
+}
+
+func (n *ScheduleNode) validate() error {
+	if len(n.Calendars) == 0 && len(n.Windows) == 0 {
+		return errors.New("schedule must have at least one calendar or window")
+	}
+	for _, c := range n.Calendars {
+		if c == "" {
+			return errors.New("schedule calendar name must not be empty")
+		}
+	}
+	for _, w := range n.Windows {
+		if w.Cron == "" {
+			return errors.New("schedule window must have a cron expression")
+		}
+		if w.Duration <= 0 {
+			return fmt.Errorf("duration of schedule window %q must be positive", w.Cron)
+		}
+	}
+	if _, err := time.LoadLocation(n.Timezone); err != nil {
+		return fmt.Errorf("invalid schedule timezone %q: %v", n.Timezone, err)
+	}
+	for _, h := range n.Holidays {
+		if _, err := time.Parse("2006-01-02", h); err != nil {
+			return fmt.Errorf("invalid schedule holiday %q, must be formatted as YYYY-MM-DD", h)
+		}
+	}
+	return nil
+}
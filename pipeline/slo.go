@@ -0,0 +1,151 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// Compute the burn rate and the remaining error budget of a service level objective.
+// Rolling counts of the good and total events are maintained per group over each of the windows.
+// A point is a total event if the `total` expression evaluates as true, or for every point if it is not set,
+// and it is also a good event if the `good` expression evaluates as true.
+//
+// The following fields are added to each point for each window, suffixed with the window duration:
+//
+//   - good_<window>: the number of good events within the window.
+//   - total_<window>: the number of total events within the window.
+//   - burn_rate_<window>: the rate at which the error budget is consumed within the window,
+//     a burn rate of 1 consumes exactly the error budget over the window.
+//   - error_budget_remaining_<window>: 1 - burn_rate_<window>, the fraction of the error budget that remains
+//     if the objective applied to the events within the window only, negative once the error budget is exhausted.
+//     It is not the budget remaining over an objective period longer than the window.
+//
+// Windows are based on the time of the points and include the points within the window duration of the newest point.
+// Late points are counted in the windows they fall within, points older than the longest window are not counted.
+// A window without total events has a burn rate of 0.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('requests')
+//	        .groupBy('service')
+//	    |slo()
+//	        .good(lambda: "status" < 500)
+//	        .objective(99.9)
+//	        .windows(5m, 1h, 6h)
+//	    |alert()
+//	        .crit(lambda: "burn_rate_1h" > 14.4 AND "burn_rate_5m" > 14.4)
+//	        .warn(lambda: "burn_rate_6h" > 6.0 AND "burn_rate_1h" > 6.0)
+//
+// The above example alerts on the burn rate of a 99.9% availability objective of each service,
+// using the multi-window multi-burn-rate pattern.
+type SloNode struct {
+	chainnode `json:"-"`
+
+	// Expression to determine whether an event is good.
+	Good *ast.LambdaNode `json:"good"`
+
+	// Expression to determine whether a point is an event.
+	// If empty every point is an event.
+	Total *ast.LambdaNode `json:"total"`
+
+	// The objective, as the percentage of good events, i.e. 99.9.
+	Objective float64 `json:"objective"`
+
+	// The durations of the windows.
+	// tick:ignore
+	Durations []time.Duration `tick:"Windows" json:"windows"`
+}
+
+func newSloNode(wants EdgeType) *SloNode {
+	return &SloNode{
+		chainnode: newBasicChainNode("slo", wants, wants),
+	}
+}
+
+// MarshalJSON converts SloNode to JSON
+// tick:ignore
+func (n *SloNode) MarshalJSON() ([]byte, error) {
+	type Alias SloNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Durations []string `json:"windows"`
+	}{
+		TypeOf: TypeOf{
+			Type: "slo",
+			ID:   n.ID(),
+		},
+		Alias:     (*Alias)(n),
+		Durations: make([]string, len(n.Durations)),
+	}
+	for i, d := range n.Durations {
+		raw.Durations[i] = influxql.FormatDuration(d)
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an SloNode
+// tick:ignore
+func (n *SloNode) UnmarshalJSON(data []byte) error {
+	type Alias SloNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Durations []string `json:"windows"`
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "slo" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as SloNode", raw.ID, raw.Type)
+	}
+	n.Durations = make([]time.Duration, len(raw.Durations))
+	for i, d := range raw.Durations {
+		n.Durations[i], err = influxql.ParseDuration(d)
+		if err != nil {
+			return err
+		}
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// Add windows over which the burn rate and remaining error budget are computed.
+// tick:property
+func (n *SloNode) Windows(durations ...time.Duration) *SloNode {
+	n.Durations = append(n.Durations, durations...)
+	return n
+}
+
+func (n *SloNode) validate() error {
+	if n.Good == nil {
+		return errors.New("slo must have a good expression")
+	}
+	if n.Objective <= 0 || n.Objective >= 100 {
+		return fmt.Errorf("slo objective must be greater than 0 and less than 100, got %v", n.Objective)
+	}
+	if len(n.Durations) == 0 {
+		return errors.New("slo must have at least one window")
+	}
+	seen := make(map[time.Duration]bool, len(n.Durations))
+	for _, d := range n.Durations {
+		if d <= 0 {
+			return fmt.Errorf("slo window %s must be positive", influxql.FormatDuration(d))
+		}
+		if seen[d] {
+			return fmt.Errorf("duplicate slo window %s", influxql.FormatDuration(d))
+		}
+		seen[d] = true
+	}
+	return nil
+}
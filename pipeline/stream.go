@@ -161,6 +161,32 @@ type FromNode struct {
 	//
 	// All incoming data will be rounded to the nearest 1 second boundary.
 	Round time.Duration `json:"round"`
+
+	// Optional duration a point may be behind the most recent point before it is considered late.
+	// Late points are not passed on and are counted in the `late_points` statistic.
+	// The most recent point time is shared by the task, including the points of windows restored from a snapshot,
+	// and the windows with `allowedLateness` trail it by their own allowed lateness.
+	// Example:
+	//    stream
+	//       |from()
+	//           .measurement('mydata')
+	//           .allowedLateness(30s)
+	//           .lateDatabase('late')
+	//           .lateRetentionPolicy('autogen')
+	//
+	// Points more than 30 seconds older than the most recent point are written to the `late` database.
+	AllowedLateness time.Duration `json:"allowedLateness"`
+
+	// The database to which late points are written.
+	// If empty late points are dropped.
+	LateDatabase string `json:"lateDatabase,omitempty"`
+
+	// The retention policy to which late points are written.
+	LateRetentionPolicy string `json:"lateRetentionPolicy,omitempty"`
+
+	// The measurement name of late points.
+	// If empty the original measurement is used.
+	LateMeasurement string `json:"lateMeasurement,omitempty"`
 }
 
 func newFromNode() *FromNode {
@@ -176,8 +202,9 @@ func (n *FromNode) MarshalJSON() ([]byte, error) {
 	var raw = &struct {
 		TypeOf
 		*Alias
-		Round    string `json:"round"`
-		Truncate string `json:"truncate"`
+		Round           string `json:"round"`
+		Truncate        string `json:"truncate"`
+		AllowedLateness string `json:"allowedLateness,omitempty"`
 	}{
 		TypeOf: TypeOf{
 			Type: "from",
@@ -187,6 +214,9 @@ func (n *FromNode) MarshalJSON() ([]byte, error) {
 		Round:    influxql.FormatDuration(n.Round),
 		Truncate: influxql.FormatDuration(n.Truncate),
 	}
+	if n.AllowedLateness != 0 {
+		raw.AllowedLateness = influxql.FormatDuration(n.AllowedLateness)
+	}
 	return json.Marshal(raw)
 }
 
@@ -197,8 +227,9 @@ func (n *FromNode) UnmarshalJSON(data []byte) error {
 	var raw = &struct {
 		TypeOf
 		*Alias
-		Round    string `json:"round"`
-		Truncate string `json:"truncate"`
+		Round           string `json:"round"`
+		Truncate        string `json:"truncate"`
+		AllowedLateness string `json:"allowedLateness"`
 	}{
 		Alias: (*Alias)(n),
 	}
@@ -220,6 +251,13 @@ func (n *FromNode) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if raw.AllowedLateness != "" {
+		n.AllowedLateness, err = influxql.ParseDuration(raw.AllowedLateness)
+		if err != nil {
+			return err
+		}
+	}
+
 	n.setID(raw.ID)
 	return nil
 }
@@ -374,5 +412,8 @@ func (n *FromNode) GroupByMeasurement() *FromNode {
 }
 
 func (s *FromNode) validate() error {
-	return validateDimensions(s.Dimensions, nil)
+	if err := validateDimensions(s.Dimensions, nil); err != nil {
+		return err
+	}
+	return validateLateness(s.AllowedLateness, s.LateDatabase, s.LateRetentionPolicy, s.LateMeasurement)
 }
@@ -0,0 +1,252 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// Route each point to exactly one named branch.
+// The cases are evaluated in order and a point is routed to the branch of the first case that matches.
+// Points that do not match any case are routed to the default branch, or dropped if there is no default branch.
+//
+// Unlike multiple where nodes, each expression is evaluated at most once per point
+// and the branches are mutually exclusive.
+//
+// The children of a switch node must be branch nodes, which select the points of a named branch.
+// Multiple branch nodes may select the same branch.
+//
+// Example:
+//
+//	var levels = stream
+//	    |from()
+//	        .measurement('cpu')
+//	    |switch()
+//	        .case(lambda: "usage_idle" < 10, 'crit')
+//	        .case(lambda: "usage_idle" < 30, 'warn')
+//	        .defaultBranch('ok')
+//
+//	levels
+//	    |branch('crit')
+//	    |alert()
+//	        .crit(lambda: TRUE)
+//
+//	levels
+//	    |branch('warn')
+//	    |log()
+//
+// The above example alerts on points with less than 10% idle and logs points with less than 30% idle.
+// Points with less than 10% idle are not logged.
+//
+// For batches each batch is split into a batch per branch, which may be empty.
+type SwitchNode struct {
+	chainnode `json:"-"`
+
+	// The ordered cases.
+	// tick:ignore
+	Cases []*SwitchCase `tick:"Case" json:"cases"`
+
+	// The branch of the points that do not match any case.
+	// tick:ignore
+	DefaultBranchName string `tick:"DefaultBranch" json:"defaultBranch"`
+}
+
+// SwitchCase routes the points that match its expression to a branch.
+type SwitchCase struct {
+	Lambda *ast.LambdaNode `json:"lambda"`
+	Branch string          `json:"branch"`
+}
+
+func newSwitchNode(e EdgeType) *SwitchNode {
+	return &SwitchNode{
+		chainnode: newBasicChainNode("switch", e, e),
+	}
+}
+
+// MarshalJSON converts SwitchNode to JSON
+// tick:ignore
+func (n *SwitchNode) MarshalJSON() ([]byte, error) {
+	type Alias SwitchNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "switch",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an SwitchNode
+// tick:ignore
+func (n *SwitchNode) UnmarshalJSON(data []byte) error {
+	type Alias SwitchNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "switch" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as SwitchNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// Route the points that match the expression to a branch,
+// unless they match an earlier case.
+// tick:property
+func (n *SwitchNode) Case(expression *ast.LambdaNode, branch string) *SwitchNode {
+	n.Cases = append(n.Cases, &SwitchCase{
+		Lambda: expression,
+		Branch: branch,
+	})
+	return n
+}
+
+// Route the points that do not match any case to a branch.
+// tick:property
+func (n *SwitchNode) DefaultBranch(branch string) *SwitchNode {
+	n.DefaultBranchName = branch
+	return n
+}
+
+// Select the points of a branch.
+func (n *SwitchNode) Branch(branch string) *BranchNode {
+	b := newBranchNode(n.provides, branch)
+	n.linkChild(b)
+	return b
+}
+
+// hasBranch reports whether the switch routes points to the branch.
+func (n *SwitchNode) hasBranch(branch string) bool {
+	if branch == n.DefaultBranchName {
+		return true
+	}
+	for _, c := range n.Cases {
+		if c.Branch == branch {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *SwitchNode) validate() error {
+	if len(n.Cases) == 0 {
+		return errors.New("switch must have at least one case")
+	}
+	for i, c := range n.Cases {
+		if c.Lambda == nil {
+			return fmt.Errorf("switch case %d must have an expression", i)
+		}
+		if c.Branch == "" {
+			return fmt.Errorf("switch case %d must have a branch name", i)
+		}
+	}
+	for _, c := range n.Children() {
+		b, ok := c.(*BranchNode)
+		if !ok {
+			return fmt.Errorf("children of a switch node must be branch nodes, got %s, use |branch('name') to select a branch", c.Name())
+		}
+		if !n.hasBranch(b.Branch) {
+			return fmt.Errorf("switch has no branch %q", b.Branch)
+		}
+	}
+	return nil
+}
+
+func (n *SwitchNode) dot(buf *bytes.Buffer) {
+	for _, c := range n.children {
+		if b, ok := c.(*BranchNode); ok {
+			buf.Write([]byte(fmt.Sprintf("%s -> %s [label=%q];\n", n.Name(), c.Name(), b.Branch)))
+		} else {
+			buf.Write([]byte(fmt.Sprintf("%s -> %s;\n", n.Name(), c.Name())))
+		}
+	}
+}
+
+// Select the points of a named branch of a switch node.
+//
+// Example:
+//
+//	var levels = stream
+//	    |from()
+//	    |switch()
+//	        .case(lambda: "value" > 90, 'high')
+//	        .defaultBranch('normal')
+//
+//	levels
+//	    |branch('high')
+//	    |httpOut('high')
+//
+// See SwitchNode for details.
+type BranchNode struct {
+	chainnode `json:"-"`
+
+	// The name of the branch.
+	// tick:ignore
+	Branch string `json:"branch"`
+}
+
+func newBranchNode(e EdgeType, branch string) *BranchNode {
+	return &BranchNode{
+		chainnode: newBasicChainNode("branch", e, e),
+		Branch:    branch,
+	}
+}
+
+// MarshalJSON converts BranchNode to JSON
+// tick:ignore
+func (n *BranchNode) MarshalJSON() ([]byte, error) {
+	type Alias BranchNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "branch",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an BranchNode
+// tick:ignore
+func (n *BranchNode) UnmarshalJSON(data []byte) error {
+	type Alias BranchNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "branch" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as BranchNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+func (n *BranchNode) validate() error {
+	if n.Branch == "" {
+		return errors.New("must provide the name of the branch")
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+func TestSwitchNode_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		cases   []*SwitchCase
+		def     string
+		id      ID
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "all fields set",
+			cases: []*SwitchCase{{
+				Lambda: &ast.LambdaNode{
+					Expression: &ast.BinaryNode{
+						Left: &ast.ReferenceNode{
+							Reference: "level",
+						},
+						Right: &ast.StringNode{
+							Literal: "critical",
+						},
+						Operator: ast.TokenEqual,
+					},
+				},
+				Branch: "crit",
+			}},
+			def: "ok",
+			want: `{
+    "typeOf": "switch",
+    "id": "0",
+    "cases": [
+        {
+            "lambda": {
+                "expression": {
+                    "left": {
+                        "reference": "level",
+                        "typeOf": "reference"
+                    },
+                    "operator": "==",
+                    "right": {
+                        "literal": "critical",
+                        "typeOf": "string"
+                    },
+                    "typeOf": "binary"
+                },
+                "typeOf": "lambda"
+            },
+            "branch": "crit"
+        }
+    ],
+    "defaultBranch": "ok"
+}`,
+		},
+		{
+			name: "different id",
+			id:   5,
+			want: `{
+    "typeOf": "switch",
+    "id": "5",
+    "cases": null,
+    "defaultBranch": ""
+}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newSwitchNode(StreamEdge)
+			w.setID(tt.id)
+			w.Cases = tt.cases
+			w.DefaultBranchName = tt.def
+			MarshalIndentTestHelper(t, w, tt.wantErr, tt.want)
+		})
+	}
+}
+
+func TestSwitchNode_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *SwitchNode
+		wantErr bool
+	}{
+		{
+			name: "all fields set",
+			input: `{
+    "typeOf": "switch",
+    "id": "0",
+    "cases": [
+        {
+            "lambda": {
+                "expression": {
+                    "left": {
+                        "reference": "level",
+                        "typeOf": "reference"
+                    },
+                    "operator": "==",
+                    "right": {
+                        "literal": "critical",
+                        "typeOf": "string"
+                    },
+                    "typeOf": "binary"
+                },
+                "typeOf": "lambda"
+            },
+            "branch": "crit"
+        }
+    ],
+    "defaultBranch": "ok"
+}`,
+			want: &SwitchNode{
+				Cases: []*SwitchCase{{
+					Lambda: &ast.LambdaNode{
+						Expression: &ast.BinaryNode{
+							Left: &ast.ReferenceNode{
+								Reference: "level",
+							},
+							Right: &ast.StringNode{
+								Literal: "critical",
+							},
+							Operator: ast.TokenEqual,
+						},
+					},
+					Branch: "crit",
+				}},
+				DefaultBranchName: "ok",
+			},
+		},
+		{
+			name:  "set id correctly",
+			input: `{"typeOf":"switch","id":"5"}`,
+			want: &SwitchNode{
+				chainnode: chainnode{
+					node: node{
+						id: 5,
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid data",
+			input:   `{"typeOf":"switch","id":"0", "defaultBranch": 1}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid node type",
+			input:   `{"typeOf":"invalid","id":"0"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &SwitchNode{}
+			err := json.Unmarshal([]byte(tt.input), w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SwitchNode.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(w, tt.want) {
+				t.Errorf("SwitchNode.UnmarshalJSON() =\n%#+v\nwant\n%#+v", w, tt.want)
+			}
+		})
+	}
+}
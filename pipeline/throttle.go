@@ -0,0 +1,153 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxql"
+)
+
+const (
+	// ThrottleDrop drops the points that exceed the rate.
+	ThrottleDrop = "drop"
+	// ThrottleDelay delays the points that exceed the rate.
+	ThrottleDelay = "delay"
+)
+
+// Limit the rate of points or batches with a token bucket per group.
+// Each point or batch takes a token from the bucket of its group.
+// The bucket is refilled at the rate and holds at most burst tokens,
+// so short bursts are passed through while the long term rate is limited.
+//
+// By default the buckets are per group, use `by` to limit the rate per tag values instead.
+//
+// If a bucket is empty the point is either dropped or delayed until a token is available.
+// Delaying a point slows down the whole node, so all groups are delayed while waiting.
+// Use the drop mode to limit noisy groups without affecting other groups.
+//
+// The rate is based on the real time points arrive, not on the time of the points.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('errors')
+//	    |throttle()
+//	        .rate(100)
+//	        .per(1s)
+//	        .burst(500)
+//	        .by('host')
+//	    |httpPost('http://example.com/errors')
+//
+// The above example posts at most 100 points per second per host, with bursts of up to 500 points.
+type ThrottleNode struct {
+	chainnode `json:"-"`
+
+	// The number of tokens added to a bucket per interval.
+	Rate int64 `json:"rate"`
+
+	// The interval of the rate.
+	// Default: 1s
+	Per time.Duration `json:"per"`
+
+	// The maximum number of tokens of a bucket.
+	// Default: the rate
+	Burst int64 `json:"burst"`
+
+	// The tags that identify a bucket.
+	// tick:ignore
+	Tags []string `tick:"By" json:"by"`
+
+	// Whether to drop or delay the points that exceed the rate.
+	// Default: drop
+	Mode string `json:"mode"`
+}
+
+func newThrottleNode(e EdgeType) *ThrottleNode {
+	return &ThrottleNode{
+		chainnode: newBasicChainNode("throttle", e, e),
+		Per:       time.Second,
+		Mode:      ThrottleDrop,
+	}
+}
+
+// MarshalJSON converts ThrottleNode to JSON
+// tick:ignore
+func (n *ThrottleNode) MarshalJSON() ([]byte, error) {
+	type Alias ThrottleNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Per string `json:"per"`
+	}{
+		TypeOf: TypeOf{
+			Type: "throttle",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+		Per:   influxql.FormatDuration(n.Per),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an ThrottleNode
+// tick:ignore
+func (n *ThrottleNode) UnmarshalJSON(data []byte) error {
+	type Alias ThrottleNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+		Per string `json:"per"`
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "throttle" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as ThrottleNode", raw.ID, raw.Type)
+	}
+	n.Per, err = influxql.ParseDuration(raw.Per)
+	if err != nil {
+		return err
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// The tags that identify a bucket.
+// tick:property
+func (n *ThrottleNode) By(tags ...string) *ThrottleNode {
+	n.Tags = tags
+	return n
+}
+
+// BurstSize returns the maximum number of tokens of a bucket.
+// tick:ignore
+func (n *ThrottleNode) BurstSize() int64 {
+	if n.Burst == 0 {
+		return n.Rate
+	}
+	return n.Burst
+}
+
+func (n *ThrottleNode) validate() error {
+	if n.Rate <= 0 {
+		return errors.New("throttle rate must be positive")
+	}
+	if n.Per <= 0 {
+		return errors.New("throttle per must be positive")
+	}
+	if n.Burst < 0 {
+		return errors.New("throttle burst must not be negative")
+	}
+	switch n.Mode {
+	case ThrottleDrop, ThrottleDelay:
+	default:
+		return fmt.Errorf("invalid throttle mode %q, must be one of %q or %q", n.Mode, ThrottleDrop, ThrottleDelay)
+	}
+	return nil
+}
@@ -53,6 +53,9 @@ func (n *AlertNode) Build(a *pipeline.AlertNode) (ast.Node, error) {
 		n.Dot("inhibit", args...)
 	}
 
+	n.DotNotEmpty("activeDuring", args(a.ActiveCalendars)...).
+		DotNotEmpty("inactiveDuring", args(a.InactiveCalendars)...)
+
 	if a.IsStateChangesOnly {
 		if a.StateChangesOnlyDuration == 0 {
 			n.Dot("stateChangesOnly")
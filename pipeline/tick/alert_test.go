@@ -94,6 +94,25 @@ func TestAlertStateChanges(t *testing.T) {
 	PipelineTickTestHelper(t, pipe, want)
 }
 
+func TestAlertActiveDuring(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	from.Alert().
+		ActiveDuring("business-hours", "on-call").
+		InactiveDuring("maintenance")
+
+	want := `stream
+    |from()
+    |alert()
+        .id('{{ .Name }}:{{ .Group }}')
+        .message('{{ .ID }} is {{ .Level }}')
+        .details('{{ json . }}')
+        .history(21)
+        .activeDuring('business-hours', 'on-call')
+        .inactiveDuring('maintenance')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
+
 func TestAlertHTTPPost(t *testing.T) {
 	pipe, _, from := StreamFrom()
 	handler := from.Alert().Post("http://coinop.com", "http://polybius.gov")
@@ -0,0 +1,33 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// AnomalyNode converts the Anomaly pipeline node into the TICKScript AST
+type AnomalyNode struct {
+	Function
+}
+
+// NewAnomaly creates an Anomaly function builder
+func NewAnomaly(parents []ast.Node) *AnomalyNode {
+	return &AnomalyNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates an Anomaly ast.Node
+func (n *AnomalyNode) Build(a *pipeline.AnomalyNode) (ast.Node, error) {
+	n.Pipe("anomaly", a.Field).
+		Dot("method", a.Method).
+		Dot("alpha", a.Alpha).
+		Dot("threshold", a.Threshold).
+		Dot("scoreAs", a.ScoreAs).
+		Dot("expectedAs", a.ExpectedAs).
+		Dot("lowerAs", a.LowerAs).
+		Dot("upperAs", a.UpperAs)
+	return n.prev, n.err
+}
@@ -0,0 +1,26 @@
+package tick_test
+
+import (
+	"testing"
+)
+
+func TestAnomaly(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	a := from.Anomaly("value")
+	a.Method = "seasonal"
+	a.Alpha = 0.1
+	a.ScoreAs = "z"
+
+	want := `stream
+    |from()
+    |anomaly('value')
+        .method('seasonal')
+        .alpha(0.1)
+        .threshold(3.0)
+        .scoreAs('z')
+        .expectedAs('expected')
+        .lowerAs('lower')
+        .upperAs('upper')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -92,6 +92,40 @@ func (a *AST) Create(n pipeline.Node, parents []ast.Node) (ast.Node, error) {
 		return NewBarrierNode(parents).Build(node)
 	case *pipeline.CombineNode:
 		return NewCombine(parents).Build(node)
+	case *pipeline.TopKNode:
+		return NewTopK(parents).Build(node)
+	case *pipeline.PivotNode:
+		return NewPivot(parents).Build(node)
+	case *pipeline.UnpivotNode:
+		return NewUnpivot(parents).Build(node)
+	case *pipeline.RenameNode:
+		return NewRename(parents).Build(node)
+	case *pipeline.FillNode:
+		return NewFill(parents).Build(node)
+	case *pipeline.SwitchNode:
+		return NewSwitch(parents).Build(node)
+	case *pipeline.BranchNode:
+		return NewBranch(parents).Build(node)
+	case *pipeline.ThrottleNode:
+		return NewThrottle(parents).Build(node)
+	case *pipeline.ScheduleNode:
+		return NewSchedule(parents).Build(node)
+	case *pipeline.RollupNode:
+		return NewRollup(parents).Build(node)
+	case *pipeline.SloNode:
+		return NewSlo(parents).Build(node)
+	case *pipeline.ForecastNode:
+		return NewForecast(parents).Build(node)
+	case *pipeline.RateNode:
+		return NewRate(parents).Build(node)
+	case *pipeline.QuantilesNode:
+		return NewQuantiles(parents).Build(node)
+	case *pipeline.HistogramNode:
+		return NewHistogram(parents).Build(node)
+	case *pipeline.AnomalyNode:
+		return NewAnomaly(parents).Build(node)
+	case *pipeline.DedupNode:
+		return NewDedup(parents).Build(node)
 	case *pipeline.DefaultNode:
 		return NewDefault(parents).Build(node)
 	case *pipeline.DeleteNode:
@@ -124,6 +158,8 @@ func (a *AST) Create(n pipeline.Node, parents []ast.Node) (ast.Node, error) {
 		return NewKapacitorLoopbackNode(parents).Build(node)
 	case *pipeline.LogNode:
 		return NewLog(parents).Build(node)
+	case *pipeline.LookupJoinNode:
+		return NewLookupJoin(parents).Build(node)
 	case *pipeline.QueryNode:
 		return NewQuery(parents).Build(node)
 	case *pipeline.QueryFluxNode:
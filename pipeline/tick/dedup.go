@@ -0,0 +1,28 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// DedupNode converts the Dedup pipeline node into the TICKScript AST
+type DedupNode struct {
+	Function
+}
+
+// NewDedup creates a Dedup function builder
+func NewDedup(parents []ast.Node) *DedupNode {
+	return &DedupNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a Dedup ast.Node
+func (n *DedupNode) Build(d *pipeline.DedupNode) (ast.Node, error) {
+	n.Pipe("dedup").
+		Dot("fields", args(d.FieldNames)...).
+		Dot("horizon", d.Horizon)
+	return n.prev, n.err
+}
@@ -0,0 +1,21 @@
+package tick_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDedup(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	d := from.Dedup()
+	d.Fields("order_id", "status")
+	d.Horizon = 5 * time.Minute
+
+	want := `stream
+    |from()
+    |dedup()
+        .fields('order_id', 'status')
+        .horizon(5m)
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -0,0 +1,35 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// FillNode converts the FillNode pipeline node into the TICKScript AST
+type FillNode struct {
+	Function
+}
+
+// NewFill creates a FillNode function builder
+func NewFill(parents []ast.Node) *FillNode {
+	return &FillNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a FillNode ast.Node
+func (n *FillNode) Build(f *pipeline.FillNode) (ast.Node, error) {
+	n.Pipe("fill").
+		Dot("every", f.Every)
+	if f.FillMethod == pipeline.FillNumber {
+		n.DotZeroValueOK("method", f.Number)
+	} else {
+		n.Dot("method", f.FillMethod)
+	}
+	n.Dot("maxGap", f.MaxGap).
+		Dot("tag", f.Tag)
+
+	return n.prev, n.err
+}
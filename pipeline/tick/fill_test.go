@@ -0,0 +1,42 @@
+package tick_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFill(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	fill := from.Fill()
+	fill.Every = 10 * time.Second
+	fill.Method("linear")
+	fill.MaxGap = 5 * time.Minute
+
+	want := `stream
+    |from()
+    |fill()
+        .every(10s)
+        .method('linear')
+        .maxGap(5m)
+        .tag('filled')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
+
+func TestFillNumber(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	fill := from.Fill()
+	fill.Every = time.Minute
+	fill.Method(0.0)
+	fill.Tag = "synthetic"
+
+	want := `stream
+    |from()
+    |fill()
+        .every(1m)
+        .method(0.0)
+        .maxGap(1h)
+        .tag('synthetic')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -0,0 +1,38 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// ForecastNode converts the Forecast pipeline node into the TICKScript AST
+type ForecastNode struct {
+	Function
+}
+
+// NewForecast creates a Forecast function builder
+func NewForecast(parents []ast.Node) *ForecastNode {
+	return &ForecastNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a Forecast ast.Node
+func (n *ForecastNode) Build(f *pipeline.ForecastNode) (ast.Node, error) {
+	n.Pipe("forecast", f.Field).
+		Dot("horizon", f.Horizon).
+		Dot("seasonality", f.Seasonality).
+		Dot("slots", f.Slots).
+		DotZeroValueOK("alpha", f.Alpha).
+		DotZeroValueOK("beta", f.Beta).
+		DotZeroValueOK("gamma", f.Gamma)
+	if f.ThresholdValue != nil {
+		n.DotZeroValueOK("threshold", *f.ThresholdValue)
+	}
+	n.Dot("unit", f.Unit).
+		Dot("forecastAs", f.ForecastAs).
+		Dot("timeToThresholdAs", f.TimeToThresholdAs)
+	return n.prev, n.err
+}
@@ -0,0 +1,32 @@
+package tick_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForecast(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	f := from.Forecast("used_percent")
+	f.Horizon = 24 * time.Hour
+	f.Seasonality = 24 * time.Hour
+	f.Gamma = 0
+	f.Threshold(100)
+	f.Unit = time.Hour
+
+	want := `stream
+    |from()
+    |forecast('used_percent')
+        .horizon(1d)
+        .seasonality(1d)
+        .slots(24)
+        .alpha(0.5)
+        .beta(0.1)
+        .gamma(0.0)
+        .threshold(100.0)
+        .unit(1h)
+        .forecastAs('forecast')
+        .timeToThresholdAs('time_to_threshold')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -28,6 +28,10 @@ func (n *FromNode) Build(f *pipeline.FromNode) (ast.Node, error) {
 		DotIf("groupByMeasurement", f.GroupByMeasurementFlag).
 		Dot("round", f.Round).
 		Dot("truncate", f.Truncate).
+		Dot("allowedLateness", f.AllowedLateness).
+		Dot("lateDatabase", f.LateDatabase).
+		Dot("lateRetentionPolicy", f.LateRetentionPolicy).
+		Dot("lateMeasurement", f.LateMeasurement).
 		Dot("where", f.Lambda)
 	if len(f.Dimensions) > 0 {
 		n.Dot("groupBy", f.Dimensions...)
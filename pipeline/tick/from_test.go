@@ -52,3 +52,22 @@ func TestFrom(t *testing.T) {
 `
 	PipelineTickTestHelper(t, pipe, want)
 }
+
+func TestFromAllowedLateness(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	from.Measurement = "cpu"
+	from.AllowedLateness = 30 * time.Second
+	from.LateDatabase = "late"
+	from.LateRetentionPolicy = "autogen"
+	from.LateMeasurement = "late_cpu"
+
+	want := `stream
+    |from()
+        .measurement('cpu')
+        .allowedLateness(30s)
+        .lateDatabase('late')
+        .lateRetentionPolicy('autogen')
+        .lateMeasurement('late_cpu')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -2,6 +2,7 @@ package tick
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/influxdata/kapacitor/tick/ast"
 )
@@ -327,6 +328,22 @@ func args(a []string) []interface{} {
 	return r
 }
 
+func fargs(a []float64) []interface{} {
+	r := make([]interface{}, len(a))
+	for i := range a {
+		r[i] = a[i]
+	}
+	return r
+}
+
+func dargs(a []time.Duration) []interface{} {
+	r := make([]interface{}, len(a))
+	for i := range a {
+		r[i] = a[i]
+	}
+	return r
+}
+
 func largs(a []*ast.LambdaNode) []interface{} {
 	r := make([]interface{}, len(a))
 	for i := range a {
@@ -0,0 +1,30 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// HistogramNode converts the Histogram pipeline node into the TICKScript AST
+type HistogramNode struct {
+	Function
+}
+
+// NewHistogram creates a Histogram function builder
+func NewHistogram(parents []ast.Node) *HistogramNode {
+	return &HistogramNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a Histogram ast.Node
+func (n *HistogramNode) Build(q *pipeline.HistogramNode) (ast.Node, error) {
+	n.Pipe("histogram", q.Field).
+		Dot("buckets", fargs(q.Bounds)...).
+		Dot("relativeAccuracy", q.RelativeAccuracy).
+		Dot("maxBins", q.MaxBins).
+		Dot("sketchAs", q.SketchAs)
+	return n.prev, n.err
+}
@@ -1,6 +1,8 @@
 package tick
 
 import (
+	"sort"
+
 	"github.com/influxdata/kapacitor/pipeline"
 	"github.com/influxdata/kapacitor/tick/ast"
 )
@@ -27,11 +29,24 @@ func (n *JoinNode) Build(j *pipeline.JoinNode) (ast.Node, error) {
 	}
 	n.Pipe("join", joined...).
 		Dot("as", args(j.Names)...).
-		Dot("on", args(j.Dimensions)...).
+		DotNotEmpty("on", args(j.Dimensions)...).
 		Dot("delimiter", j.Delimiter).
 		Dot("streamName", j.StreamName).
 		Dot("tolerance", j.Tolerance).
 		Dot("deleteAll", j.DeleteAll).
-		DotNotNil("fill", j.Fill)
+		DotNotNil("fill", j.Fill).
+		DotIf("left", j.LeftFlag).
+		DotIf("right", j.RightFlag).
+		DotIf("full", j.FullFlag)
+
+	var names []string
+	for name := range j.FillValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		n.DotZeroValueOK("fillFor", name, j.FillValues[name])
+	}
+	n.Dot("timeout", j.Timeout)
 	return n.prev, n.err
 }
@@ -43,3 +43,35 @@ stream
 `
 	PipelineTickTestHelper(t, pipe, want)
 }
+
+func TestJoinOuter(t *testing.T) {
+	stream1 := &pipeline.StreamNode{}
+	stream2 := &pipeline.StreamNode{}
+	pipe := pipeline.CreatePipelineSources(stream1, stream2)
+
+	from1 := stream1.From()
+	from1.Measurement = "requests"
+
+	from2 := stream2.From()
+	from2.Measurement = "errors"
+
+	join := from1.Join(from2)
+	join.As("requests", "errors").Left().FillFor("errors", int64(0))
+	join.Timeout = time.Minute
+
+	want := `var from3 = stream
+    |from()
+        .measurement('errors')
+
+stream
+    |from()
+        .measurement('requests')
+    |join(from3)
+        .as('requests', 'errors')
+        .delimiter('.')
+        .left()
+        .fillFor('errors', 0)
+        .timeout(1m)
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
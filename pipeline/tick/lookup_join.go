@@ -0,0 +1,28 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// LookupJoinNode converts the LookupJoinNode pipeline node into the TICKScript AST
+type LookupJoinNode struct {
+	Function
+}
+
+// NewLookupJoin creates a LookupJoinNode function builder
+func NewLookupJoin(parents []ast.Node) *LookupJoinNode {
+	return &LookupJoinNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a LookupJoinNode ast.Node
+func (n *LookupJoinNode) Build(j *pipeline.LookupJoinNode) (ast.Node, error) {
+	n.Pipe("lookupJoin", n.Parents[1]).
+		Dot("on", args(j.Dimensions)...).
+		Dot("prefix", j.Prefix)
+	return n.prev, n.err
+}
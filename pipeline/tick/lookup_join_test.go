@@ -0,0 +1,51 @@
+package tick_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/stateful"
+)
+
+func TestLookupJoin(t *testing.T) {
+	stream := &pipeline.StreamNode{}
+	batch := &pipeline.BatchNode{}
+	pipe := pipeline.CreatePipelineSources(stream, batch)
+
+	from := stream.From()
+	from.Measurement = "cpu"
+
+	query := batch.Query(`SELECT owner FROM "cmdb"."autogen"."hosts"`)
+	query.Period = 24 * time.Hour
+	query.Every = 10 * time.Minute
+
+	lookup := from.LookupJoin(query)
+	lookup.On("host")
+	lookup.Prefix = "cmdb."
+
+	want := `var query3 = batch
+    |query('SELECT owner FROM "cmdb"."autogen"."hosts"')
+        .period(1d)
+        .every(10m)
+
+stream
+    |from()
+        .measurement('cpu')
+    |lookupJoin(query3)
+        .on('host')
+        .prefix('cmdb.')
+`
+	got, err := PipelineTick(pipe)
+	if err != nil {
+		t.Fatalf("Unexpected error building pipeline %v", err)
+	}
+	if got != want {
+		t.Errorf("unexpected TICKscript:\n %s", cmp.Diff(got, want))
+	}
+	// The script refers to batch but it is a stream task.
+	if _, err := pipeline.CreatePipeline(got, pipeline.StreamEdge, stateful.NewScope(), deadman{}, nil); err != nil {
+		t.Errorf("TICKscript not able to be parsed %v", err)
+	}
+}
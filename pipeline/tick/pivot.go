@@ -0,0 +1,55 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// PivotNode converts the PivotNode pipeline node into the TICKScript AST
+type PivotNode struct {
+	Function
+}
+
+// NewPivot creates a PivotNode function builder
+func NewPivot(parents []ast.Node) *PivotNode {
+	return &PivotNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a PivotNode ast.Node
+func (n *PivotNode) Build(p *pipeline.PivotNode) (ast.Node, error) {
+	n.Pipe("pivot").
+		DotNotEmpty("rowKey", args(p.RowKeys)...).
+		Dot("columnKey", args(p.ColumnKeys)...).
+		Dot("valueField", p.ValueField).
+		Dot("delimiter", p.Delimiter)
+
+	return n.prev, n.err
+}
+
+// UnpivotNode converts the UnpivotNode pipeline node into the TICKScript AST
+type UnpivotNode struct {
+	Function
+}
+
+// NewUnpivot creates an UnpivotNode function builder
+func NewUnpivot(parents []ast.Node) *UnpivotNode {
+	return &UnpivotNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates an UnpivotNode ast.Node
+func (n *UnpivotNode) Build(u *pipeline.UnpivotNode) (ast.Node, error) {
+	n.Pipe("unpivot").
+		DotNotEmpty("fields", args(u.FieldNames)...).
+		Dot("tag", u.Tag).
+		Dot("valueField", u.ValueField)
+
+	return n.prev, n.err
+}
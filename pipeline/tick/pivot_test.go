@@ -0,0 +1,39 @@
+package tick_test
+
+import (
+	"testing"
+)
+
+func TestPivot(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	pivot := from.Pivot()
+	pivot.RowKey("host", "region")
+	pivot.ColumnKey("metric")
+	pivot.ValueField = "v"
+
+	want := `stream
+    |from()
+    |pivot()
+        .rowKey('host', 'region')
+        .columnKey('metric')
+        .valueField('v')
+        .delimiter('_')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
+
+func TestUnpivot(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	unpivot := from.Unpivot()
+	unpivot.Fields("cpu", "mem")
+	unpivot.Tag = "metric"
+
+	want := `stream
+    |from()
+    |unpivot()
+        .fields('cpu', 'mem')
+        .tag('metric')
+        .valueField('value')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -0,0 +1,30 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// QuantilesNode converts the Quantiles pipeline node into the TICKScript AST
+type QuantilesNode struct {
+	Function
+}
+
+// NewQuantiles creates a Quantiles function builder
+func NewQuantiles(parents []ast.Node) *QuantilesNode {
+	return &QuantilesNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a Quantiles ast.Node
+func (n *QuantilesNode) Build(q *pipeline.QuantilesNode) (ast.Node, error) {
+	n.Pipe("quantiles", q.Field).
+		Dot("q", fargs(q.Quantiles)...).
+		Dot("relativeAccuracy", q.RelativeAccuracy).
+		Dot("maxBins", q.MaxBins).
+		Dot("sketchAs", q.SketchAs)
+	return n.prev, n.err
+}
@@ -0,0 +1,51 @@
+package tick_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuantiles(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	w := from.Window()
+	w.Period = time.Minute
+	w.Every = time.Minute
+	q := w.Quantiles("latency")
+	q.Q(0.5, 0.99)
+	q.SketchAs = "latency_sketch"
+
+	want := `stream
+    |from()
+    |window()
+        .period(1m)
+        .every(1m)
+    |quantiles('latency')
+        .q(0.5, 0.99)
+        .relativeAccuracy(0.01)
+        .maxBins(2048)
+        .sketchAs('latency_sketch')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
+
+func TestHistogram(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	w := from.Window()
+	w.Period = time.Minute
+	w.Every = time.Minute
+	h := w.Histogram("latency")
+	h.Buckets(10, 50.5)
+	h.MaxBins = 100
+
+	want := `stream
+    |from()
+    |window()
+        .period(1m)
+        .every(1m)
+    |histogram('latency')
+        .buckets(10.0, 50.5)
+        .relativeAccuracy(0.01)
+        .maxBins(100)
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
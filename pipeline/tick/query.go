@@ -27,6 +27,8 @@ func (n *QueryNode) Build(q *pipeline.QueryNode) (ast.Node, error) {
 		DotIf("align", q.AlignFlag).
 		Dot("cron", q.Cron).
 		Dot("offset", q.Offset).
+		Dot("calendar", q.Calendar).
+		Dot("timezone", q.Timezone).
 		DotIf("alignGroup", q.AlignGroupFlag).
 		Dot("groupBy", q.Dimensions).
 		DotIf("groupByMeasurement", q.GroupByMeasurementFlag).
@@ -32,3 +32,19 @@ func TestQuery(t *testing.T) {
 `
 	PipelineTickTestHelper(t, pipe, want)
 }
+
+func TestQueryCalendar(t *testing.T) {
+	pipe, _, query := BatchQuery("select count(value) from requests")
+
+	query.Calendar = "1d"
+	query.Timezone = "Europe/Berlin"
+	query.Offset = time.Hour
+
+	want := `batch
+    |query('select count(value) from requests')
+        .offset(1h)
+        .calendar('1d')
+        .timezone('Europe/Berlin')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -0,0 +1,29 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// RateNode converts the Rate pipeline node into the TICKScript AST
+type RateNode struct {
+	Function
+}
+
+// NewRate creates a Rate function builder
+func NewRate(parents []ast.Node) *RateNode {
+	return &RateNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a Rate ast.Node
+func (n *RateNode) Build(r *pipeline.RateNode) (ast.Node, error) {
+	n.Pipe(r.Method, r.Field).
+		Dot("as", r.As).
+		Dot("unit", r.Unit).
+		Dot("range", r.Range)
+	return n.prev, n.err
+}
@@ -0,0 +1,42 @@
+package tick_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRate(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	w := from.Window()
+	w.Period = 5 * time.Minute
+	w.Every = time.Minute
+	r := w.Rate("counter")
+	r.Unit = time.Minute
+	r.Range = 10 * time.Minute
+
+	want := `stream
+    |from()
+    |window()
+        .period(5m)
+        .every(1m)
+    |rate('counter')
+        .as('rate')
+        .unit(1m)
+        .range(10m)
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
+
+func TestIncrease(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	i := from.Increase("counter")
+	i.As = "requests"
+
+	want := `stream
+    |from()
+    |increase('counter')
+        .as('requests')
+        .unit(1s)
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
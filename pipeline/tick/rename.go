@@ -0,0 +1,50 @@
+package tick
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// RenameNode converts the Rename pipeline node into the TICKScript AST
+type RenameNode struct {
+	Function
+}
+
+// NewRename creates a Rename function builder
+func NewRename(parents []ast.Node) *RenameNode {
+	return &RenameNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a Rename ast.Node
+func (n *RenameNode) Build(r *pipeline.RenameNode) (ast.Node, error) {
+	n.Pipe("rename")
+	n.renames("measurement", r.Measurements, r.MeasurementRegexes)
+	n.renames("tag", r.Tags, r.TagRegexes)
+	n.renames("field", r.Fields, r.FieldRegexes)
+	return n.prev, n.err
+}
+
+func (n *RenameNode) renames(property string, renames map[string]string, regexes []*pipeline.RenameRegex) {
+	var keys []string
+	for k := range renames {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		n.Dot(property, k, renames[k])
+	}
+	for _, r := range regexes {
+		regex := &ast.RegexNode{
+			Regex:   r.Regex,
+			Literal: strings.Replace(r.Regex.String(), "/", `\/`, -1),
+		}
+		n.DotZeroValueOK(property+"Regex", regex, r.Replacement)
+	}
+}
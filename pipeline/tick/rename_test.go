@@ -0,0 +1,29 @@
+package tick_test
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRename(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	from.Rename().
+		Measurement("container_cpu", "cpu").
+		Tag("hostname", "host").
+		Tag("dc", "datacenter").
+		TagRegex(regexp.MustCompile("^k8s_(.*)"), "$1").
+		Field("usage_percent", "usage").
+		FieldRegex(regexp.MustCompile("^tmp_"), "")
+
+	want := `stream
+    |from()
+    |rename()
+        .measurement('container_cpu', 'cpu')
+        .tag('dc', 'datacenter')
+        .tag('hostname', 'host')
+        .tagRegex(/^k8s_(.*)/, '$1')
+        .field('usage_percent', 'usage')
+        .fieldRegex(/^tmp_/, '')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -0,0 +1,28 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// RollupNode converts the RollupNode pipeline node into the TICKScript AST
+type RollupNode struct {
+	Function
+}
+
+// NewRollup creates a RollupNode function builder
+func NewRollup(parents []ast.Node) *RollupNode {
+	return &RollupNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a RollupNode ast.Node
+func (n *RollupNode) Build(r *pipeline.RollupNode) (ast.Node, error) {
+	n.Pipe("rollup", args(r.Dimensions)...).
+		Dot("tag", r.Tag)
+
+	return n.prev, n.err
+}
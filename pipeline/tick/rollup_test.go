@@ -0,0 +1,18 @@
+package tick_test
+
+import (
+	"testing"
+)
+
+func TestRollup(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	rollup := from.Rollup("region", "cluster", "host")
+	rollup.Tag = "level"
+
+	want := `stream
+    |from()
+    |rollup('region', 'cluster', 'host')
+        .tag('level')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
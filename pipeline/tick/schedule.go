@@ -0,0 +1,34 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// ScheduleNode converts the ScheduleNode pipeline node into the TICKScript AST
+type ScheduleNode struct {
+	Function
+}
+
+// NewSchedule creates a ScheduleNode function builder
+func NewSchedule(parents []ast.Node) *ScheduleNode {
+	return &ScheduleNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a ScheduleNode ast.Node
+func (n *ScheduleNode) Build(s *pipeline.ScheduleNode) (ast.Node, error) {
+	n.Pipe("schedule").
+		DotNotEmpty("calendar", args(s.Calendars)...)
+	for _, w := range s.Windows {
+		n.Dot("window", w.Cron, w.Duration)
+	}
+	n.DotNotEmpty("holiday", args(s.Holidays)...).
+		Dot("timezone", s.Timezone).
+		DotIf("exclude", s.IsExclude)
+
+	return n.prev, n.err
+}
@@ -0,0 +1,29 @@
+package tick_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	schedule := from.Schedule()
+	schedule.Calendar("maintenance")
+	schedule.Window("0 9 * * 1-5", 8*time.Hour)
+	schedule.Window("0 2 * * 6", 4*time.Hour)
+	schedule.Holiday("2026-12-25")
+	schedule.Timezone = "Europe/Paris"
+	schedule.Exclude()
+
+	want := `stream
+    |from()
+    |schedule()
+        .calendar('maintenance')
+        .window('0 9 * * 1-5', 8h)
+        .window('0 2 * * 6', 4h)
+        .holiday('2026-12-25')
+        .timezone('Europe/Paris')
+        .exclude()
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -0,0 +1,31 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// SloNode converts the SloNode pipeline node into the TICKScript AST
+type SloNode struct {
+	Function
+}
+
+// NewSlo creates a SloNode function builder
+func NewSlo(parents []ast.Node) *SloNode {
+	return &SloNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a SloNode ast.Node
+func (n *SloNode) Build(s *pipeline.SloNode) (ast.Node, error) {
+	n.Pipe("slo").
+		Dot("good", s.Good).
+		Dot("total", s.Total).
+		Dot("objective", s.Objective).
+		DotNotEmpty("windows", dargs(s.Durations)...)
+
+	return n.prev, n.err
+}
@@ -0,0 +1,37 @@
+package tick_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+func TestSlo(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	slo := from.Slo()
+	slo.Good = &ast.LambdaNode{
+		Expression: &ast.BinaryNode{
+			Left: &ast.ReferenceNode{
+				Reference: "status",
+			},
+			Right: &ast.NumberNode{
+				IsInt: true,
+				Int64: 500,
+				Base:  10,
+			},
+			Operator: ast.TokenLess,
+		},
+	}
+	slo.Objective = 99.9
+	slo.Windows(time.Hour, 6*time.Hour)
+
+	want := `stream
+    |from()
+    |slo()
+        .good(lambda: "status" < 500)
+        .objective(99.9)
+        .windows(1h, 6h)
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
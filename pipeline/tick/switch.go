@@ -0,0 +1,50 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// SwitchNode converts the SwitchNode pipeline node into the TICKScript AST
+type SwitchNode struct {
+	Function
+}
+
+// NewSwitch creates a SwitchNode function builder
+func NewSwitch(parents []ast.Node) *SwitchNode {
+	return &SwitchNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a SwitchNode ast.Node
+func (n *SwitchNode) Build(s *pipeline.SwitchNode) (ast.Node, error) {
+	n.Pipe("switch")
+	for _, c := range s.Cases {
+		n.Dot("case", c.Lambda, c.Branch)
+	}
+	n.Dot("defaultBranch", s.DefaultBranchName)
+	return n.prev, n.err
+}
+
+// BranchNode converts the BranchNode pipeline node into the TICKScript AST
+type BranchNode struct {
+	Function
+}
+
+// NewBranch creates a BranchNode function builder
+func NewBranch(parents []ast.Node) *BranchNode {
+	return &BranchNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a BranchNode ast.Node
+func (n *BranchNode) Build(b *pipeline.BranchNode) (ast.Node, error) {
+	n.Pipe("branch", b.Branch)
+	return n.prev, n.err
+}
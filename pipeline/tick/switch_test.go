@@ -0,0 +1,38 @@
+package tick_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+func TestSwitch(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	s := from.Switch()
+	s.Case(&ast.LambdaNode{
+		Expression: &ast.BinaryNode{
+			Left: &ast.ReferenceNode{
+				Reference: "usage_idle",
+			},
+			Right: &ast.NumberNode{
+				IsInt: true,
+				Int64: 10,
+				Base:  10,
+			},
+			Operator: ast.TokenLess,
+		},
+	}, "crit")
+	s.DefaultBranch("ok")
+	s.Branch("crit").Log()
+
+	want := `stream
+    |from()
+    |switch()
+        .case(lambda: "usage_idle" < 10, 'crit')
+        .defaultBranch('ok')
+    |branch('crit')
+    |log()
+        .level('INFO')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -0,0 +1,32 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// ThrottleNode converts the ThrottleNode pipeline node into the TICKScript AST
+type ThrottleNode struct {
+	Function
+}
+
+// NewThrottle creates a ThrottleNode function builder
+func NewThrottle(parents []ast.Node) *ThrottleNode {
+	return &ThrottleNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a ThrottleNode ast.Node
+func (n *ThrottleNode) Build(t *pipeline.ThrottleNode) (ast.Node, error) {
+	n.Pipe("throttle").
+		Dot("rate", t.Rate).
+		Dot("per", t.Per).
+		Dot("burst", t.Burst).
+		DotNotEmpty("by", args(t.Tags)...).
+		Dot("mode", t.Mode)
+
+	return n.prev, n.err
+}
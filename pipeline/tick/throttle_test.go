@@ -0,0 +1,27 @@
+package tick_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThrottle(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	throttle := from.Throttle()
+	throttle.Rate = 100
+	throttle.Per = time.Minute
+	throttle.Burst = 500
+	throttle.By("host", "region")
+	throttle.Mode = "delay"
+
+	want := `stream
+    |from()
+    |throttle()
+        .rate(100)
+        .per(1m)
+        .burst(500)
+        .by('host', 'region')
+        .mode('delay')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
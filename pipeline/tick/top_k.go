@@ -0,0 +1,30 @@
+package tick
+
+import (
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+)
+
+// TopKNode converts the TopK pipeline node into the TICKScript AST
+type TopKNode struct {
+	Function
+}
+
+// NewTopK creates a TopK function builder
+func NewTopK(parents []ast.Node) *TopKNode {
+	return &TopKNode{
+		Function{
+			Parents: parents,
+		},
+	}
+}
+
+// Build creates a TopK ast.Node
+func (n *TopKNode) Build(t *pipeline.TopKNode) (ast.Node, error) {
+	n.Pipe("topK", t.K, t.Field).
+		Dot("by", args(t.Tags)...).
+		Dot("method", t.Method).
+		Dot("capacity", t.Capacity).
+		Dot("rankAs", t.RankAs)
+	return n.prev, n.err
+}
@@ -0,0 +1,30 @@
+package tick_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTopK(t *testing.T) {
+	pipe, _, from := StreamFrom()
+	w := from.Window()
+	w.Period = time.Minute
+	w.Every = time.Minute
+	k := w.TopK(10, "usage_user")
+	k.By("host")
+	k.Method = "sum"
+	k.Capacity = 1000
+
+	want := `stream
+    |from()
+    |window()
+        .period(1m)
+        .every(1m)
+    |topK(10, 'usage_user')
+        .by('host')
+        .method('sum')
+        .capacity(1000)
+        .rankAs('rank')
+`
+	PipelineTickTestHelper(t, pipe, want)
+}
@@ -26,6 +26,13 @@ func (n *WindowNode) Build(w *pipeline.WindowNode) (ast.Node, error) {
 		Dot("every", w.Every).
 		Dot("periodCount", w.PeriodCount).
 		Dot("everyCount", w.EveryCount).
+		Dot("sessionGap", w.SessionGap).
+		Dot("allowedLateness", w.AllowedLateness).
+		Dot("lateDatabase", w.LateDatabase).
+		Dot("lateRetentionPolicy", w.LateRetentionPolicy).
+		Dot("lateMeasurement", w.LateMeasurement).
+		Dot("calendar", w.Calendar).
+		Dot("timezone", w.Timezone).
 		DotIf("align", w.AlignFlag).
 		DotIf("fillPeriod", w.FillPeriodFlag)
 	return n.prev, n.err
@@ -15,6 +15,12 @@ func TestWindowNode(t *testing.T) {
 		fillPeriod  bool
 		periodCount int64
 		everyCount  int64
+		sessionGap  time.Duration
+
+		allowedLateness time.Duration
+
+		calendar string
+		timezone string
 	}
 	tests := []struct {
 		name string
@@ -50,6 +56,47 @@ func TestWindowNode(t *testing.T) {
     |window()
         .periodCount(10)
         .everyCount(15)
+`,
+		},
+		{
+			name: "window with session gap",
+			args: args{
+				sessionGap: 5 * time.Minute,
+			},
+			want: `stream
+    |from()
+    |window()
+        .sessionGap(5m)
+`,
+		},
+		{
+			name: "window with allowed lateness",
+			args: args{
+				period:          time.Minute,
+				every:           time.Minute,
+				align:           true,
+				allowedLateness: 30 * time.Second,
+			},
+			want: `stream
+    |from()
+    |window()
+        .period(1m)
+        .every(1m)
+        .allowedLateness(30s)
+        .align()
+`,
+		},
+		{
+			name: "window with calendar",
+			args: args{
+				calendar: "1mo",
+				timezone: "Europe/Berlin",
+			},
+			want: `stream
+    |from()
+    |window()
+        .calendar('1mo')
+        .timezone('Europe/Berlin')
 `,
 		},
 	}
@@ -65,6 +112,10 @@ func TestWindowNode(t *testing.T) {
 			w.FillPeriodFlag = tt.args.fillPeriod
 			w.PeriodCount = tt.args.periodCount
 			w.EveryCount = tt.args.everyCount
+			w.SessionGap = tt.args.sessionGap
+			w.AllowedLateness = tt.args.allowedLateness
+			w.Calendar = tt.args.calendar
+			w.Timezone = tt.args.timezone
 
 			got, err := PipelineTick(pipe)
 			if err != nil {
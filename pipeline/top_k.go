@@ -0,0 +1,170 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// TopKMax ranks items by the maximum value of the field.
+	TopKMax = "max"
+	// TopKSum ranks items by the sum of the field.
+	TopKSum = "sum"
+)
+
+// Rank the items with the highest values of a field across all groups of a window.
+// Unlike top, which selects points within a single group,
+// topK compares all batches with the same time, so no regrouping is needed.
+//
+// An item is identified by the `by` tags, or by the group of its batch if no tags are given.
+// Items are ranked by the maximum or the sum of the field within the window.
+//
+// The ranking is emitted as a single batch with the K points of the highest ranked items.
+// Each point keeps the tags of the most recent point of its item and gets a `rank` field, starting at 1.
+// Because the groups of a window arrive independently, a window is ranked once every group of the previous window
+// has sent its batch for the window, has sent a barrier past the end of the window or has been deleted.
+// Otherwise, as for the first window, the ranking is emitted once the first batch of the next window arrives.
+// The ranking of the last window is emitted when the task stops.
+//
+// By default all items of a window are tracked.
+// For unbounded cardinality a capacity limits the number of tracked items.
+// With the sum method the items are then tracked with a space-saving sketch,
+// which may overestimate the sums of items that entered the sketch late.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('cpu')
+//	        .groupBy('host', 'cpu')
+//	    |window()
+//	        .period(1m)
+//	        .every(1m)
+//	        .align()
+//	    |topK(10, 'usage_user')
+//	        .by('host')
+//	    |httpOut('top_hosts')
+//
+// The above example ranks the 10 hosts with the highest CPU usage of any of their CPUs every minute.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('requests')
+//	        .groupBy('client_ip')
+//	    |window()
+//	        .period(1m)
+//	        .every(1m)
+//	        .align()
+//	    |topK(20, 'count')
+//	        .method('sum')
+//	        .capacity(10000)
+//
+// The above example finds the 20 clients with the most requests, tracking at most 10000 clients at a time.
+type TopKNode struct {
+	chainnode `json:"-"`
+
+	// The number of items to rank.
+	// tick:ignore
+	K int64 `json:"k"`
+
+	// The field to rank by.
+	// tick:ignore
+	Field string `json:"field"`
+
+	// The tags that identify an item.
+	// tick:ignore
+	Tags []string `tick:"By" json:"by"`
+
+	// How the values of an item are aggregated, either max or sum.
+	// Default: max
+	Method string `json:"method"`
+
+	// The maximum number of items to track, zero tracks all items.
+	Capacity int64 `json:"capacity"`
+
+	// The name of the rank field.
+	// Default: rank
+	RankAs string `json:"rankAs"`
+}
+
+func newTopKNode(k int64, field string) *TopKNode {
+	return &TopKNode{
+		chainnode: newBasicChainNode("topK", BatchEdge, BatchEdge),
+		K:         k,
+		Field:     field,
+		Method:    TopKMax,
+		RankAs:    "rank",
+	}
+}
+
+// MarshalJSON converts TopKNode to JSON
+// tick:ignore
+func (n *TopKNode) MarshalJSON() ([]byte, error) {
+	type Alias TopKNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		TypeOf: TypeOf{
+			Type: "topK",
+			ID:   n.ID(),
+		},
+		Alias: (*Alias)(n),
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON converts JSON to an TopKNode
+// tick:ignore
+func (n *TopKNode) UnmarshalJSON(data []byte) error {
+	type Alias TopKNode
+	var raw = &struct {
+		TypeOf
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	err := json.Unmarshal(data, raw)
+	if err != nil {
+		return err
+	}
+	if raw.Type != "topK" {
+		return fmt.Errorf("error unmarshaling node %d of type %s as TopKNode", raw.ID, raw.Type)
+	}
+	n.setID(raw.ID)
+	return nil
+}
+
+// The tags that identify an item.
+// tick:property
+func (n *TopKNode) By(tags ...string) *TopKNode {
+	n.Tags = tags
+	return n
+}
+
+func (n *TopKNode) validate() error {
+	if n.K <= 0 {
+		return fmt.Errorf("topK k must be positive, got %d", n.K)
+	}
+	if n.Field == "" {
+		return errors.New("must provide the field to rank by")
+	}
+	switch n.Method {
+	case TopKMax, TopKSum:
+	default:
+		return fmt.Errorf("invalid topK method %q, must be one of %q or %q", n.Method, TopKMax, TopKSum)
+	}
+	if n.Capacity < 0 {
+		return errors.New("topK capacity must not be negative")
+	}
+	if n.Capacity > 0 && n.Capacity < n.K {
+		return fmt.Errorf("topK capacity %d must be at least k %d", n.Capacity, n.K)
+	}
+	if n.RankAs == "" {
+		return errors.New("topK rankAs must not be empty")
+	}
+	return nil
+}
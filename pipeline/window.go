@@ -32,6 +32,72 @@ import (
 // new data and `5 minutes` of the previous period's data.
 //
 // NOTE: Because no `align` property is defined, the `window` edge is defined relative to the first data point.
+//
+// The `sessionGap` property of `window` creates session windows instead.
+// A session window collects points per group until no point has arrived for the gap duration,
+// at which point the session is emitted and a new session starts with the next point.
+// Time is tracked across all groups, so the session of a group that goes quiet is emitted
+// once the points or barriers of any group are past the gap. Open sessions are emitted when the task stops.
+// The gap is measured using the time of the data and not the wall clock.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('requests')
+//	        .groupBy('user')
+//	    |window()
+//	        .sessionGap(5m)
+//	    |count('value')
+//
+// This example counts the requests of each user session, where a session ends after 5 minutes without requests.
+//
+// The `allowedLateness` property of `window` emits windows based on a watermark instead of the most recent point.
+// The watermark trails the most recent point time received by the node, across all groups, by the allowed lateness.
+// A window is only emitted once the watermark has passed its end, so points that arrive out of order
+// within the allowed lateness still land in the correct window.
+// When the watermark advances, the windows of every group that end at or before it are emitted,
+// even if their group has not received a new point.
+// The most recent point time is also shared with the task, so a `from` node with `allowedLateness`
+// drops the points that are behind the windows restored from a snapshot.
+// Points that arrive after their window was emitted are counted in the `late_points` statistic
+// and are dropped, unless a `lateDatabase` and `lateRetentionPolicy` are set,
+// in which case they are written back into Kapacitor to that database and retention policy.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('cpu')
+//	        .groupBy('host')
+//	    |window()
+//	        .period(1m)
+//	        .every(1m)
+//	        .align()
+//	        .allowedLateness(30s)
+//	        .lateDatabase('late')
+//	        .lateRetentionPolicy('autogen')
+//	    |mean('usage_idle')
+//
+// This example waits up to 30 seconds for out of order points before emitting each minute,
+// any points later than that are written to the `late` database.
+//
+// The `calendar` property of `window` creates windows aligned to the calendar instead of fixed durations,
+// so days start at midnight, weeks on Monday, months on the first day of the month and years on the first of January
+// in the `timezone` of the window, taking daylight saving time into account.
+// The window is emitted once a point or barrier past its end arrives, with the end of the window as the batch time.
+//
+// Example:
+//
+//	stream
+//	    |from()
+//	        .measurement('requests')
+//	    |window()
+//	        .calendar('1mo')
+//	        .timezone('Europe/Berlin')
+//	    |count('value')
+//
+// This example counts the requests of each month, from midnight on the first day of the month in Berlin.
 type WindowNode struct {
 	chainnode `json:"-"`
 	// The period, or length in time, of the window.
@@ -51,6 +117,34 @@ type WindowNode struct {
 	// EveryCount determines how often the window is emitted based on the count of points.
 	// A value of 1 means that every new point will emit the window.
 	EveryCount int64 `json:"everyCount"`
+
+	// SessionGap is the duration without new points after which a session window is closed and emitted.
+	// The time of the emitted batch is the time of the last point in the session.
+	SessionGap time.Duration `json:"sessionGap"`
+
+	// AllowedLateness is how far behind the most recent point a point may be and still be added to its window.
+	// Windows are emitted once the watermark, the most recent point time minus the allowed lateness, has passed their end.
+	AllowedLateness time.Duration `json:"allowedLateness"`
+
+	// The database to which late points are written.
+	// If empty late points are dropped.
+	LateDatabase string `json:"lateDatabase,omitempty"`
+
+	// The retention policy to which late points are written.
+	LateRetentionPolicy string `json:"lateRetentionPolicy,omitempty"`
+
+	// The measurement name of late points.
+	// If empty the original measurement is used.
+	LateMeasurement string `json:"lateMeasurement,omitempty"`
+
+	// Calendar is the calendar interval of the window, a number followed by one of
+	// `d` for days, `w` for weeks, `mo` for months or `y` for years, i.e. `1d`, `2w` or `1mo`.
+	// Calendar windows are tumbling and cannot be combined with the period or count properties.
+	Calendar string `json:"calendar,omitempty"`
+
+	// The time zone of the calendar.
+	// Default: UTC
+	Timezone string `json:"timezone,omitempty"`
 }
 
 func newWindowNode() *WindowNode {
@@ -66,8 +160,10 @@ func (n *WindowNode) MarshalJSON() ([]byte, error) {
 	var raw = &struct {
 		TypeOf
 		*Alias
-		Period string `json:"period"`
-		Every  string `json:"every"`
+		Period          string `json:"period"`
+		Every           string `json:"every"`
+		SessionGap      string `json:"sessionGap,omitempty"`
+		AllowedLateness string `json:"allowedLateness,omitempty"`
 	}{
 		TypeOf: TypeOf{
 			Type: "window",
@@ -77,6 +173,12 @@ func (n *WindowNode) MarshalJSON() ([]byte, error) {
 		Period: influxql.FormatDuration(n.Period),
 		Every:  influxql.FormatDuration(n.Every),
 	}
+	if n.SessionGap != 0 {
+		raw.SessionGap = influxql.FormatDuration(n.SessionGap)
+	}
+	if n.AllowedLateness != 0 {
+		raw.AllowedLateness = influxql.FormatDuration(n.AllowedLateness)
+	}
 	return json.Marshal(raw)
 }
 
@@ -87,8 +189,10 @@ func (n *WindowNode) UnmarshalJSON(data []byte) error {
 	var raw = &struct {
 		TypeOf
 		*Alias
-		Period string `json:"period"`
-		Every  string `json:"every"`
+		Period          string `json:"period"`
+		Every           string `json:"every"`
+		SessionGap      string `json:"sessionGap"`
+		AllowedLateness string `json:"allowedLateness"`
 	}{
 		Alias: (*Alias)(n),
 	}
@@ -110,6 +214,20 @@ func (n *WindowNode) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if raw.SessionGap != "" {
+		n.SessionGap, err = influxql.ParseDuration(raw.SessionGap)
+		if err != nil {
+			return err
+		}
+	}
+
+	if raw.AllowedLateness != "" {
+		n.AllowedLateness, err = influxql.ParseDuration(raw.AllowedLateness)
+		if err != nil {
+			return err
+		}
+	}
+
 	n.setID(raw.ID)
 	return nil
 }
@@ -144,5 +262,52 @@ func (w *WindowNode) validate() error {
 	if w.PeriodCount != 0 && w.EveryCount <= 0 {
 		return errors.New("everyCount must be greater than zero")
 	}
+	if w.SessionGap < 0 {
+		return errors.New("sessionGap must be greater than zero")
+	}
+	if w.SessionGap != 0 {
+		if w.Period != 0 || w.Every != 0 || w.PeriodCount != 0 || w.EveryCount != 0 {
+			return errors.New("cannot specify sessionGap with period, every, periodCount or everyCount")
+		}
+		if w.AlignFlag || w.FillPeriodFlag {
+			return errors.New("cannot align or fill the period of session windows")
+		}
+	}
+	if w.Calendar != "" {
+		if w.Period != 0 || w.Every != 0 || w.PeriodCount != 0 || w.EveryCount != 0 || w.SessionGap != 0 || w.AllowedLateness != 0 {
+			return errors.New("can only use one window type: calendar, period and every, count or session")
+		}
+		if w.AlignFlag {
+			return errors.New("calendar windows are always aligned, cannot use align")
+		}
+		if err := validateCalendar(w.Calendar, w.Timezone); err != nil {
+			return err
+		}
+	} else if w.Timezone != "" {
+		return errors.New("can only use timezone with calendar windows")
+	}
+	if w.AllowedLateness != 0 && (w.Period == 0 || w.Every == 0) {
+		return errors.New("allowedLateness requires a non zero period and every")
+	}
+	return validateLateness(w.AllowedLateness, w.LateDatabase, w.LateRetentionPolicy, w.LateMeasurement)
+}
+
+// validateLateness validates the allowed lateness and the late point output of a node.
+func validateLateness(allowedLateness time.Duration, database, retentionPolicy, measurement string) error {
+	if allowedLateness < 0 {
+		return errors.New("allowedLateness must be greater than zero")
+	}
+	if database == "" && retentionPolicy == "" && measurement == "" {
+		return nil
+	}
+	if allowedLateness == 0 {
+		return errors.New("must specify allowedLateness to write late points")
+	}
+	if database == "" {
+		return errors.New("must specify a lateDatabase")
+	}
+	if retentionPolicy == "" {
+		return errors.New("must specify a lateRetentionPolicy")
+	}
 	return nil
 }
@@ -15,6 +15,11 @@ func TestWindowNode_MarshalJSON(t *testing.T) {
 		FillPeriodFlag bool
 		PeriodCount    int64
 		EveryCount     int64
+		SessionGap     time.Duration
+
+		AllowedLateness     time.Duration
+		LateDatabase        string
+		LateRetentionPolicy string
 	}
 	tests := []struct {
 		name    string
@@ -42,6 +47,25 @@ func TestWindowNode_MarshalJSON(t *testing.T) {
 			},
 			want: `{"typeOf":"window","id":"0","align":false,"fillPeriod":false,"periodCount":0,"everyCount":0,"period":"1h","every":"1m"}`,
 		},
+		{
+			name: "session gap",
+			fields: fields{
+				SessionGap: 5 * time.Minute,
+			},
+			want: `{"typeOf":"window","id":"0","align":false,"fillPeriod":false,"periodCount":0,"everyCount":0,"period":"0s","every":"0s","sessionGap":"5m"}`,
+		},
+		{
+			name: "allowed lateness",
+			fields: fields{
+				Period:              time.Minute,
+				Every:               time.Minute,
+				AlignFlag:           true,
+				AllowedLateness:     30 * time.Second,
+				LateDatabase:        "late",
+				LateRetentionPolicy: "autogen",
+			},
+			want: `{"typeOf":"window","id":"0","align":true,"fillPeriod":false,"periodCount":0,"everyCount":0,"lateDatabase":"late","lateRetentionPolicy":"autogen","period":"1m","every":"1m","allowedLateness":"30s"}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -52,6 +76,10 @@ func TestWindowNode_MarshalJSON(t *testing.T) {
 			w.FillPeriodFlag = tt.fields.FillPeriodFlag
 			w.PeriodCount = tt.fields.PeriodCount
 			w.EveryCount = tt.fields.EveryCount
+			w.SessionGap = tt.fields.SessionGap
+			w.AllowedLateness = tt.fields.AllowedLateness
+			w.LateDatabase = tt.fields.LateDatabase
+			w.LateRetentionPolicy = tt.fields.LateRetentionPolicy
 			MarshalTestHelper(t, w, tt.wantErr, tt.want)
 		})
 	}
@@ -84,6 +112,25 @@ func TestWindowNode_UnmarshalJSON(t *testing.T) {
 				Every:  time.Minute,
 			},
 		},
+		{
+			name:  "session gap",
+			input: `{"typeOf":"window","id":"0","period":"0s","every":"0s","align":false,"fillPeriod":false,"periodCount":0,"everyCount":0,"sessionGap":"5m"}`,
+			want: &WindowNode{
+				SessionGap: 5 * time.Minute,
+			},
+		},
+		{
+			name:  "allowed lateness",
+			input: `{"typeOf":"window","id":"0","period":"1m","every":"1m","align":true,"fillPeriod":false,"periodCount":0,"everyCount":0,"allowedLateness":"30s","lateDatabase":"late","lateRetentionPolicy":"autogen"}`,
+			want: &WindowNode{
+				Period:              time.Minute,
+				Every:               time.Minute,
+				AlignFlag:           true,
+				AllowedLateness:     30 * time.Second,
+				LateDatabase:        "late",
+				LateRetentionPolicy: "autogen",
+			},
+		},
 		{
 			name:  "set id correctly",
 			input: `{"typeOf":"window","id":"5","period":"1h","every":"1m","align":false,"fillPeriod":false,"periodCount":0,"everyCount":0}`,
@@ -0,0 +1,352 @@
+package kapacitor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/keyvalue"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+type PivotNode struct {
+	node
+	p    *pipeline.PivotNode
+	dims models.Dimensions
+
+	// the points of a stream with the same time
+	name  string
+	db    string
+	rp    string
+	time  time.Time
+	table *pivotTable
+
+	// the batch being received
+	begin  edge.BeginBatchMessage
+	points []edge.BatchPointMessage
+}
+
+// Create a new PivotNode, which turns the values of tags into fields.
+func newPivotNode(et *ExecutingTask, n *pipeline.PivotNode, d NodeDiagnostic) (*PivotNode, error) {
+	tagNames := make([]string, len(n.RowKeys))
+	copy(tagNames, n.RowKeys)
+	sort.Strings(tagNames)
+	pn := &PivotNode{
+		node:  node{Node: n, et: et, diag: d},
+		p:     n,
+		dims:  models.Dimensions{TagNames: tagNames},
+		table: newPivotTable(),
+	}
+	pn.node.runF = pn.runPivot
+	return pn, nil
+}
+
+func (n *PivotNode) runPivot([]byte) error {
+	consumer := edge.NewConsumerWithReceiver(
+		n.ins[0],
+		edge.NewReceiverFromForwardReceiverWithStats(
+			n.outs,
+			edge.NewTimedForwardReceiver(n.timer, n),
+		),
+	)
+	return consumer.Consume()
+}
+
+// pivotTable holds the rows of a pivot in the order they were first seen.
+type pivotTable struct {
+	rows  []*pivotRow
+	index map[pivotKey]*pivotRow
+}
+
+type pivotKey struct {
+	time int64
+	id   models.GroupID
+}
+
+type pivotRow struct {
+	tags   models.Tags
+	fields models.Fields
+	time   time.Time
+}
+
+func newPivotTable() *pivotTable {
+	return &pivotTable{
+		index: make(map[pivotKey]*pivotRow),
+	}
+}
+
+// add adds the value of a point to its row and column.
+func (n *PivotNode) add(table *pivotTable, p edge.FieldsTagsTimeGetter) {
+	tags := p.Tags()
+	column, err := n.column(tags)
+	if err != nil {
+		n.diag.Error("cannot pivot point", err)
+		return
+	}
+	value, ok := p.Fields()[n.p.ValueField]
+	if !ok {
+		n.diag.Error("cannot pivot point", errors.New("value field is missing"), keyvalue.KV("field", n.p.ValueField))
+		return
+	}
+	key := pivotKey{
+		time: p.Time().UnixNano(),
+		id:   models.ToGroupID("", tags, n.dims),
+	}
+	row, ok := table.index[key]
+	if !ok {
+		rowTags := make(models.Tags, len(n.p.RowKeys))
+		for _, k := range n.p.RowKeys {
+			if v, ok := tags[k]; ok {
+				rowTags[k] = v
+			}
+		}
+		row = &pivotRow{
+			tags:   rowTags,
+			fields: make(models.Fields),
+			time:   p.Time(),
+		}
+		table.index[key] = row
+		table.rows = append(table.rows, row)
+	}
+	row.fields[column] = value
+}
+
+// column returns the name of the column of a point.
+func (n *PivotNode) column(tags models.Tags) (string, error) {
+	values := make([]string, len(n.p.ColumnKeys))
+	for i, k := range n.p.ColumnKeys {
+		v, ok := tags[k]
+		if !ok {
+			return "", fmt.Errorf("tag %s is missing from point", k)
+		}
+		values[i] = v
+	}
+	return strings.Join(values, n.p.Delimiter), nil
+}
+
+// flush returns the pivoted points of the buffered stream and resets the buffer.
+func (n *PivotNode) flush() []edge.Message {
+	msgs := make([]edge.Message, len(n.table.rows))
+	for i, row := range n.table.rows {
+		msgs[i] = edge.NewPointMessage(
+			n.name, n.db, n.rp,
+			n.dims,
+			row.fields,
+			row.tags,
+			row.time,
+		)
+	}
+	n.table = newPivotTable()
+	return msgs
+}
+
+func (n *PivotNode) Point(p edge.PointMessage) (edge.Message, error) {
+	var msgs []edge.Message
+	if !p.Time().Equal(n.time) {
+		msgs = n.flush()
+		n.time = p.Time()
+	}
+	n.name = p.Name()
+	n.db = p.Database()
+	n.rp = p.RetentionPolicy()
+	n.add(n.table, p)
+	return n.emitMessages(msgs)
+}
+
+func (n *PivotNode) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	n.begin = begin
+	n.points = n.points[:0]
+	return nil, nil
+}
+
+func (n *PivotNode) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	n.points = append(n.points, bp)
+	return nil, nil
+}
+
+func (n *PivotNode) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	return n.pivotBatch(n.begin, n.points), nil
+}
+
+func (n *PivotNode) BufferedBatch(batch edge.BufferedBatchMessage) (edge.Message, error) {
+	return n.pivotBatch(batch.Begin(), batch.Points()), nil
+}
+
+// pivotBatch returns a batch with the rows of the points.
+// The column keys are removed from the group of the batch.
+func (n *PivotNode) pivotBatch(begin edge.BeginBatchMessage, points []edge.BatchPointMessage) edge.Message {
+	table := newPivotTable()
+	for _, bp := range points {
+		n.add(table, bp)
+	}
+	sort.SliceStable(table.rows, func(i, j int) bool {
+		return table.rows[i].time.Before(table.rows[j].time)
+	})
+	pivoted := make([]edge.BatchPointMessage, len(table.rows))
+	for i, row := range table.rows {
+		pivoted[i] = edge.NewBatchPointMessage(row.fields, row.tags, row.time)
+	}
+
+	tags := begin.Tags().Copy()
+	dims := begin.Dimensions()
+	tagNames := make([]string, 0, len(dims.TagNames))
+TAGS:
+	for _, t := range dims.TagNames {
+		for _, c := range n.p.ColumnKeys {
+			if t == c {
+				delete(tags, t)
+				continue TAGS
+			}
+		}
+		tagNames = append(tagNames, t)
+	}
+	dims.TagNames = tagNames
+
+	begin = begin.ShallowCopy()
+	begin.SetTagsAndDimensions(tags, dims)
+	begin.SetSizeHint(len(pivoted))
+	return edge.NewBufferedBatchMessage(begin, pivoted, edge.NewEndBatchMessage())
+}
+
+func (n *PivotNode) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	var msgs []edge.Message
+	if b.Time().After(n.time) {
+		msgs = n.flush()
+	}
+	return n.emitMessages(append(msgs, b))
+}
+
+func (n *PivotNode) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	return d, nil
+}
+
+// Done emits the pivoted points of the buffered stream.
+func (n *PivotNode) Done() {
+	for _, m := range n.flush() {
+		if err := edge.Forward(n.outs, m); err != nil {
+			n.diag.Error("failed to emit pivoted points", err)
+			return
+		}
+	}
+}
+
+type UnpivotNode struct {
+	node
+	u *pipeline.UnpivotNode
+}
+
+// Create a new UnpivotNode, which turns multiple fields into separate points.
+func newUnpivotNode(et *ExecutingTask, n *pipeline.UnpivotNode, d NodeDiagnostic) (*UnpivotNode, error) {
+	un := &UnpivotNode{
+		node: node{Node: n, et: et, diag: d},
+		u:    n,
+	}
+	un.node.runF = un.runUnpivot
+	return un, nil
+}
+
+func (n *UnpivotNode) runUnpivot([]byte) error {
+	consumer := edge.NewConsumerWithReceiver(
+		n.ins[0],
+		edge.NewReceiverFromForwardReceiverWithStats(
+			n.outs,
+			edge.NewTimedForwardReceiver(n.timer, n),
+		),
+	)
+	return consumer.Consume()
+}
+
+// unpivot returns the tags and fields of a point for each unpivoted field.
+func (n *UnpivotNode) unpivot(fields models.Fields, tags models.Tags) ([]models.Fields, []models.Tags) {
+	names := n.u.FieldNames
+	if len(names) == 0 {
+		names = make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	unpivoted := make(map[string]bool, len(names))
+	for _, name := range names {
+		unpivoted[name] = true
+	}
+
+	var fs []models.Fields
+	var ts []models.Tags
+	for _, name := range names {
+		value, ok := fields[name]
+		if !ok {
+			continue
+		}
+		f := make(models.Fields, len(fields))
+		for k, v := range fields {
+			if !unpivoted[k] {
+				f[k] = v
+			}
+		}
+		f[n.u.ValueField] = value
+		t := tags.Copy()
+		t[n.u.Tag] = name
+		fs = append(fs, f)
+		ts = append(ts, t)
+	}
+	return fs, ts
+}
+
+func (n *UnpivotNode) Point(p edge.PointMessage) (edge.Message, error) {
+	fs, ts := n.unpivot(p.Fields(), p.Tags())
+	msgs := make([]edge.Message, len(fs))
+	for i := range fs {
+		np := p.ShallowCopy()
+		np.SetFields(fs[i])
+		np.SetTags(ts[i])
+		msgs[i] = np
+	}
+	return n.emitMessages(msgs)
+}
+
+func (n *UnpivotNode) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	begin = begin.ShallowCopy()
+	begin.SetSizeHint(0)
+	return begin, nil
+}
+
+func (n *UnpivotNode) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	fs, ts := n.unpivot(bp.Fields(), bp.Tags())
+	msgs := make([]edge.Message, len(fs))
+	for i := range fs {
+		msgs[i] = edge.NewBatchPointMessage(fs[i], ts[i], bp.Time())
+	}
+	return n.emitMessages(msgs)
+}
+
+func (n *UnpivotNode) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	return end, nil
+}
+
+func (n *UnpivotNode) BufferedBatch(batch edge.BufferedBatchMessage) (edge.Message, error) {
+	var points []edge.BatchPointMessage
+	for _, bp := range batch.Points() {
+		fs, ts := n.unpivot(bp.Fields(), bp.Tags())
+		for i := range fs {
+			points = append(points, edge.NewBatchPointMessage(fs[i], ts[i], bp.Time()))
+		}
+	}
+	begin := batch.Begin().ShallowCopy()
+	begin.SetSizeHint(len(points))
+	return edge.NewBufferedBatchMessage(begin, points, batch.End()), nil
+}
+
+func (n *UnpivotNode) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	return b, nil
+}
+
+func (n *UnpivotNode) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	return d, nil
+}
+
+func (n *UnpivotNode) Done() {}
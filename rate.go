@@ -0,0 +1,248 @@
+package kapacitor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/keyvalue"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+type RateNode struct {
+	node
+	r          *pipeline.RateNode
+	batchRange time.Duration
+
+	// mu protects the groups so they can be snapshotted.
+	mu       sync.Mutex
+	groups   map[models.GroupID]*rateGroup
+	restored map[models.GroupID]counterSample
+}
+
+// Create a new rate or increase node.
+func newRateNode(et *ExecutingTask, n *pipeline.RateNode, d NodeDiagnostic) (*RateNode, error) {
+	rn := &RateNode{
+		node:       node{Node: n, et: et, diag: d},
+		r:          n,
+		batchRange: n.BatchRange(),
+		groups:     make(map[models.GroupID]*rateGroup),
+	}
+	rn.node.runF = rn.runRate
+	return rn, nil
+}
+
+func (n *RateNode) runRate(snapshot []byte) error {
+	if snapshot != nil {
+		if err := n.restore(snapshot); err != nil {
+			n.diag.Error("failed to restore counters from snapshot", err)
+		}
+	}
+	consumer := edge.NewGroupedConsumer(
+		n.ins[0],
+		n,
+	)
+	n.statMap.Set(statCardinalityGauge, consumer.CardinalityVar())
+	return consumer.Consume()
+}
+
+func (n *RateNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.Receiver, error) {
+	g := &rateGroup{
+		n:     n,
+		group: group,
+	}
+	n.mu.Lock()
+	if s, ok := n.restored[group.ID]; ok {
+		g.previous = &s
+		delete(n.restored, group.ID)
+	}
+	n.groups[group.ID] = g
+	n.mu.Unlock()
+	return edge.NewReceiverFromForwardReceiverWithStats(
+		n.outs,
+		edge.NewTimedForwardReceiver(n.timer, edge.NewLockedForwardReceiver(&n.mu, g)),
+	), nil
+}
+
+func (n *RateNode) snapshot() ([]byte, error) {
+	n.mu.Lock()
+	previous := make(map[models.GroupID]counterSample, len(n.groups))
+	for id, g := range n.groups {
+		if g.previous != nil {
+			previous[id] = *g.previous
+		}
+	}
+	n.mu.Unlock()
+	return encodeNodeSnapshot(previous)
+}
+
+func (n *RateNode) restore(data []byte) error {
+	previous := make(map[models.GroupID]counterSample)
+	if err := decodeNodeSnapshot(data, &previous); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.restored = previous
+	n.mu.Unlock()
+	return nil
+}
+
+// sample returns the counter value of the fields.
+func (n *RateNode) sample(fields models.Fields, t time.Time) (counterSample, bool) {
+	v, ok := numToFloat(fields[n.r.Field])
+	if !ok {
+		n.diag.Error(fmt.Sprintf("cannot perform %s", n.r.Method),
+			errors.New("field is missing or the wrong type"),
+			keyvalue.KV("field", n.r.Field),
+			keyvalue.KV("type", fmt.Sprintf("%T", fields[n.r.Field])),
+		)
+		return counterSample{}, false
+	}
+	return counterSample{Value: v, Time: t}, true
+}
+
+// result converts an increase over elapsed time to the result of the method.
+func (n *RateNode) result(increase float64, elapsed time.Duration) float64 {
+	if n.r.Method == pipeline.IncreaseMethod {
+		return increase
+	}
+	return increase / (float64(elapsed) / float64(n.r.Unit))
+}
+
+type counterSample struct {
+	Value float64
+	Time  time.Time
+}
+
+type rateGroup struct {
+	n     *RateNode
+	group edge.GroupInfo
+
+	// previous is the previous sample of a stream.
+	previous *counterSample
+
+	begin   edge.BeginBatchMessage
+	samples []counterSample
+}
+
+func (g *rateGroup) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	g.begin = begin
+	g.samples = g.samples[:0]
+	return nil, nil
+}
+
+func (g *rateGroup) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	if s, ok := g.n.sample(bp.Fields(), bp.Time()); ok {
+		g.samples = append(g.samples, s)
+	}
+	return nil, nil
+}
+
+func (g *rateGroup) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	if len(g.samples) < 2 {
+		// Not enough samples to compute an increase
+		return nil, nil
+	}
+	sort.SliceStable(g.samples, func(i, j int) bool {
+		return g.samples[i].Time.Before(g.samples[j].Time)
+	})
+	value := extrapolatedIncrease(g.samples, g.begin.Time(), g.n.batchRange)
+	elapsed := g.n.batchRange
+	if elapsed == 0 {
+		elapsed = g.samples[len(g.samples)-1].Time.Sub(g.samples[0].Time)
+	}
+	if elapsed == 0 {
+		return nil, nil
+	}
+	return edge.NewPointMessage(
+		g.begin.Name(), "", "",
+		g.group.Dimensions,
+		models.Fields{g.n.r.As: g.n.result(value, elapsed)},
+		g.begin.Tags(),
+		g.begin.Time(),
+	), nil
+}
+
+func (g *rateGroup) Point(p edge.PointMessage) (edge.Message, error) {
+	s, ok := g.n.sample(p.Fields(), p.Time())
+	if !ok {
+		return nil, nil
+	}
+	previous := g.previous
+	g.previous = &s
+	if previous == nil {
+		return nil, nil
+	}
+	elapsed := s.Time.Sub(previous.Time)
+	if elapsed <= 0 {
+		g.n.diag.Error(fmt.Sprintf("cannot perform %s", g.n.r.Method), errors.New("elapsed time was not positive"))
+		return nil, nil
+	}
+	increase := s.Value - previous.Value
+	if increase < 0 {
+		// Counter reset, the counter increased from zero.
+		increase = s.Value
+	}
+	p = p.ShallowCopy()
+	fields := p.Fields().Copy()
+	fields[g.n.r.As] = g.n.result(increase, elapsed)
+	p.SetFields(fields)
+	return p, nil
+}
+
+func (g *rateGroup) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	return b, nil
+}
+func (g *rateGroup) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	delete(g.n.groups, d.GroupID())
+	return d, nil
+}
+func (g *rateGroup) Done() {}
+
+// extrapolatedIncrease returns the increase of the counter over the range ending at end,
+// the same way PromQL does for the increase and rate functions.
+// The samples must be sorted by time and contain at least two samples.
+// A range of zero returns the increase between the first and last sample.
+func extrapolatedIncrease(samples []counterSample, end time.Time, rng time.Duration) float64 {
+	first, last := samples[0], samples[len(samples)-1]
+	increase := last.Value - first.Value
+	for i := 1; i < len(samples); i++ {
+		if samples[i].Value < samples[i-1].Value {
+			// Counter reset
+			increase += samples[i-1].Value
+		}
+	}
+	sampled := last.Time.Sub(first.Time).Seconds()
+	if rng == 0 || sampled == 0 {
+		return increase
+	}
+
+	durationToStart := first.Time.Sub(end.Add(-rng)).Seconds()
+	durationToEnd := end.Sub(last.Time).Seconds()
+	if increase > 0 && first.Value >= 0 {
+		// The counter cannot be extrapolated below zero.
+		durationToZero := sampled * (first.Value / increase)
+		if durationToZero < durationToStart {
+			durationToStart = durationToZero
+		}
+	}
+
+	averageBetweenSamples := sampled / float64(len(samples)-1)
+	threshold := averageBetweenSamples * 1.1
+	extrapolateTo := sampled
+	if durationToStart < threshold {
+		extrapolateTo += durationToStart
+	} else {
+		extrapolateTo += averageBetweenSamples / 2
+	}
+	if durationToEnd < threshold {
+		extrapolateTo += durationToEnd
+	} else {
+		extrapolateTo += averageBetweenSamples / 2
+	}
+	return increase * (extrapolateTo / sampled)
+}
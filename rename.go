@@ -0,0 +1,161 @@
+package kapacitor
+
+import (
+	"sort"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+type RenameNode struct {
+	node
+	r *pipeline.RenameNode
+}
+
+// Create a new RenameNode, which renames measurements, tags and fields.
+func newRenameNode(et *ExecutingTask, n *pipeline.RenameNode, d NodeDiagnostic) (*RenameNode, error) {
+	rn := &RenameNode{
+		node: node{Node: n, et: et, diag: d},
+		r:    n,
+	}
+	rn.node.runF = rn.runRename
+	return rn, nil
+}
+
+func (n *RenameNode) runRename([]byte) error {
+	consumer := edge.NewConsumerWithReceiver(
+		n.ins[0],
+		edge.NewReceiverFromForwardReceiverWithStats(
+			n.outs,
+			edge.NewTimedForwardReceiver(n.timer, n),
+		),
+	)
+	return consumer.Consume()
+}
+
+// rename returns the new name and whether the name was renamed.
+func rename(name string, renames map[string]string, regexes []*pipeline.RenameRegex) (string, bool) {
+	if new, ok := renames[name]; ok {
+		return new, true
+	}
+	for _, r := range regexes {
+		if r.Regex.MatchString(name) {
+			return r.Regex.ReplaceAllString(name, r.Replacement), true
+		}
+	}
+	return name, false
+}
+
+func (n *RenameNode) renameMeasurement(name string) string {
+	name, _ = rename(name, n.r.Measurements, n.r.MeasurementRegexes)
+	return name
+}
+
+// renameTags returns the renamed tags and dimensions.
+// The original tags and dimensions are returned if nothing is renamed.
+func (n *RenameNode) renameTags(tags models.Tags, dims models.Dimensions) (models.Tags, models.Dimensions) {
+	if len(n.r.Tags) == 0 && len(n.r.TagRegexes) == 0 {
+		return tags, dims
+	}
+	var renamed models.Tags
+	for k, v := range tags {
+		if new, ok := rename(k, n.r.Tags, n.r.TagRegexes); ok {
+			if renamed == nil {
+				renamed = make(models.Tags, len(tags))
+			}
+			renamed[new] = v
+		}
+	}
+	if renamed == nil {
+		return tags, dims
+	}
+	// Renamed tags overwrite existing tags with the same name.
+	for k, v := range tags {
+		if _, ok := rename(k, n.r.Tags, n.r.TagRegexes); ok {
+			continue
+		}
+		if _, ok := renamed[k]; !ok {
+			renamed[k] = v
+		}
+	}
+	tagNames := make([]string, 0, len(dims.TagNames))
+	seen := make(map[string]bool, len(dims.TagNames))
+	for _, t := range dims.TagNames {
+		t, _ = rename(t, n.r.Tags, n.r.TagRegexes)
+		if !seen[t] {
+			seen[t] = true
+			tagNames = append(tagNames, t)
+		}
+	}
+	sort.Strings(tagNames)
+	dims.TagNames = tagNames
+	return renamed, dims
+}
+
+// renameFields returns the renamed fields.
+// The original fields are returned if nothing is renamed.
+func (n *RenameNode) renameFields(fields models.Fields) models.Fields {
+	if len(n.r.Fields) == 0 && len(n.r.FieldRegexes) == 0 {
+		return fields
+	}
+	var renamed models.Fields
+	for k, v := range fields {
+		if new, ok := rename(k, n.r.Fields, n.r.FieldRegexes); ok {
+			if renamed == nil {
+				renamed = make(models.Fields, len(fields))
+			}
+			renamed[new] = v
+		}
+	}
+	if renamed == nil {
+		return fields
+	}
+	// Renamed fields overwrite existing fields with the same name.
+	for k, v := range fields {
+		if _, ok := rename(k, n.r.Fields, n.r.FieldRegexes); ok {
+			continue
+		}
+		if _, ok := renamed[k]; !ok {
+			renamed[k] = v
+		}
+	}
+	return renamed
+}
+
+func (n *RenameNode) Point(p edge.PointMessage) (edge.Message, error) {
+	p = p.ShallowCopy()
+	p.SetName(n.renameMeasurement(p.Name()))
+	p.SetTagsAndDimensions(n.renameTags(p.Tags(), p.Dimensions()))
+	p.SetFields(n.renameFields(p.Fields()))
+	return p, nil
+}
+
+func (n *RenameNode) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	begin = begin.ShallowCopy()
+	begin.SetName(n.renameMeasurement(begin.Name()))
+	begin.SetTagsAndDimensions(n.renameTags(begin.Tags(), begin.Dimensions()))
+	return begin, nil
+}
+
+func (n *RenameNode) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	bp = bp.ShallowCopy()
+	tags, _ := n.renameTags(bp.Tags(), models.Dimensions{})
+	bp.SetTags(tags)
+	bp.SetFields(n.renameFields(bp.Fields()))
+	return bp, nil
+}
+
+func (n *RenameNode) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	return end, nil
+}
+
+func (n *RenameNode) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	return b, nil
+}
+
+func (n *RenameNode) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	return d, nil
+}
+
+func (n *RenameNode) Done() {}
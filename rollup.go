@@ -0,0 +1,198 @@
+package kapacitor
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/expvar"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+type RollupNode struct {
+	node
+	r *pipeline.RollupNode
+
+	// the levels from the most to the least detailed
+	levels []rollupLevel
+
+	begin edge.BeginBatchMessage
+
+	mu       sync.RWMutex
+	lastTime time.Time
+	groups   map[models.GroupID]edge.BufferedBatchMessage
+}
+
+// rollupLevel is a prefix of the dimensions.
+type rollupLevel struct {
+	// the value of the level tag
+	name string
+	// the sorted tag names of the group, including the level tag
+	tagNames []string
+	// the tags of the dimensions below the level
+	removed []string
+}
+
+// Create a new RollupNode, which groups the data at every level of a hierarchy of dimensions.
+func newRollupNode(et *ExecutingTask, n *pipeline.RollupNode, d NodeDiagnostic) (*RollupNode, error) {
+	rn := &RollupNode{
+		node:   node{Node: n, et: et, diag: d},
+		r:      n,
+		levels: make([]rollupLevel, 0, len(n.Dimensions)+1),
+		groups: make(map[models.GroupID]edge.BufferedBatchMessage),
+	}
+	for i := len(n.Dimensions); i >= 0; i-- {
+		level := rollupLevel{
+			name:     pipeline.RollupAll,
+			tagNames: make([]string, 0, i+1),
+			removed:  n.Dimensions[i:],
+		}
+		if i > 0 {
+			level.name = n.Dimensions[i-1]
+		}
+		level.tagNames = append(level.tagNames, n.Dimensions[:i]...)
+		level.tagNames = append(level.tagNames, n.Tag)
+		sort.Strings(level.tagNames)
+		rn.levels = append(rn.levels, level)
+	}
+	rn.node.runF = rn.runRollup
+	return rn, nil
+}
+
+func (n *RollupNode) runRollup([]byte) error {
+	valueF := func() int64 {
+		n.mu.RLock()
+		l := len(n.groups)
+		n.mu.RUnlock()
+		return int64(l)
+	}
+	n.statMap.Set(statCardinalityGauge, expvar.NewIntFuncGauge(valueF))
+
+	consumer := edge.NewConsumerWithReceiver(
+		n.ins[0],
+		n,
+	)
+	return consumer.Consume()
+}
+
+// tags returns the tags of a point at a level.
+func (n *RollupNode) tags(tags models.Tags, level rollupLevel) models.Tags {
+	newTags := tags.Copy()
+	for _, t := range level.removed {
+		delete(newTags, t)
+	}
+	newTags[n.r.Tag] = level.name
+	return newTags
+}
+
+func (n *RollupNode) Point(p edge.PointMessage) error {
+	n.timer.Start()
+	defer n.timer.Stop()
+	for _, level := range n.levels {
+		lp := p.ShallowCopy()
+		dims := p.Dimensions()
+		dims.TagNames = level.tagNames
+		lp.SetTagsAndDimensions(n.tags(p.Tags(), level), dims)
+		n.timer.Pause()
+		err := edge.Forward(n.outs, lp)
+		n.timer.Resume()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *RollupNode) BeginBatch(begin edge.BeginBatchMessage) error {
+	n.timer.Start()
+	defer n.timer.Stop()
+
+	if err := n.emit(begin.Time()); err != nil {
+		return err
+	}
+	n.begin = begin
+	return nil
+}
+
+func (n *RollupNode) BatchPoint(bp edge.BatchPointMessage) error {
+	n.timer.Start()
+	defer n.timer.Stop()
+
+	for _, level := range n.levels {
+		tags := n.tags(bp.Tags(), level)
+		dims := n.begin.Dimensions()
+		dims.TagNames = level.tagNames
+		groupID := models.ToGroupID(n.begin.Name(), tags, dims)
+		group, ok := n.groups[groupID]
+		if !ok {
+			// Create new begin message
+			newBegin := n.begin.ShallowCopy()
+			newBegin.SetTagsAndDimensions(tags, dims)
+
+			// Create buffer for group batch
+			group = edge.NewBufferedBatchMessage(
+				newBegin,
+				make([]edge.BatchPointMessage, 0, newBegin.SizeHint()),
+				edge.NewEndBatchMessage(),
+			)
+			n.mu.Lock()
+			n.groups[groupID] = group
+			n.mu.Unlock()
+		}
+		group.SetPoints(append(group.Points(), edge.NewBatchPointMessage(bp.Fields(), tags, bp.Time())))
+	}
+	return nil
+}
+
+func (n *RollupNode) EndBatch(end edge.EndBatchMessage) error {
+	return nil
+}
+
+func (n *RollupNode) Barrier(b edge.BarrierMessage) error {
+	n.timer.Start()
+	err := n.emit(b.Time())
+	n.timer.Stop()
+	if err != nil {
+		return err
+	}
+	return edge.Forward(n.outs, b)
+}
+
+func (n *RollupNode) DeleteGroup(d edge.DeleteGroupMessage) error {
+	n.timer.Start()
+	n.mu.Lock()
+	delete(n.groups, d.GroupID())
+	n.mu.Unlock()
+	n.timer.Stop()
+	return edge.Forward(n.outs, d)
+}
+
+func (n *RollupNode) Done() {}
+
+// emit sends all groups before time t to children nodes.
+// The batches of all groups with the same time are rolled up together.
+// The node timer must be started when calling this method.
+func (n *RollupNode) emit(t time.Time) error {
+	if t.Equal(n.lastTime) {
+		return nil
+	}
+	n.lastTime = t
+	for id, group := range n.groups {
+		// Update SizeHint since we know the final point count
+		group.Begin().SetSizeHint(len(group.Points()))
+		// Sort points since the points of many batches were merged
+		sort.Sort(edge.BatchPointMessages(group.Points()))
+		n.timer.Pause()
+		err := edge.Forward(n.outs, group)
+		n.timer.Resume()
+		if err != nil {
+			return err
+		}
+		n.mu.Lock()
+		delete(n.groups, id)
+		n.mu.Unlock()
+	}
+	return nil
+}
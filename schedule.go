@@ -0,0 +1,136 @@
+package kapacitor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/expvar"
+	"github.com/influxdata/kapacitor/keyvalue"
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/services/calendar"
+)
+
+const (
+	statsScheduleDropped = "dropped"
+)
+
+type ScheduleNode struct {
+	node
+	s *pipeline.ScheduleNode
+
+	// the calendar of the windows of the node, nil if there are no windows
+	windows *calendar.Calendar
+
+	dropped *expvar.Int
+}
+
+// Create a new ScheduleNode, which passes or drops points based on the time of day and calendar.
+func newScheduleNode(et *ExecutingTask, n *pipeline.ScheduleNode, d NodeDiagnostic) (*ScheduleNode, error) {
+	sn := &ScheduleNode{
+		node:    node{Node: n, et: et, diag: d},
+		s:       n,
+		dropped: new(expvar.Int),
+	}
+	if len(n.Windows) > 0 {
+		spec := calendar.Spec{
+			Timezone: n.Timezone,
+			Windows:  make([]calendar.WindowSpec, len(n.Windows)),
+			Holidays: n.Holidays,
+		}
+		for i, w := range n.Windows {
+			spec.Windows[i] = calendar.WindowSpec{
+				Cron:     w.Cron,
+				Duration: w.Duration,
+			}
+		}
+		windows, err := calendar.New(spec)
+		if err != nil {
+			return nil, err
+		}
+		sn.windows = windows
+	}
+	for _, name := range n.Calendars {
+		if et.tm.CalendarService == nil {
+			return nil, errors.New("calendar service is not available")
+		}
+		if _, err := et.tm.CalendarService.Calendar(name); err != nil {
+			return nil, err
+		}
+	}
+	sn.node.runF = sn.runSchedule
+	return sn, nil
+}
+
+func (n *ScheduleNode) runSchedule([]byte) error {
+	n.statMap.Set(statsScheduleDropped, n.dropped)
+	consumer := edge.NewConsumerWithReceiver(
+		n.ins[0],
+		edge.NewReceiverFromForwardReceiverWithStats(
+			n.outs,
+			edge.NewTimedForwardReceiver(n.timer, n),
+		),
+	)
+	return consumer.Consume()
+}
+
+// active reports whether t falls within the schedule.
+func (n *ScheduleNode) active(t time.Time) bool {
+	if n.windows != nil && n.windows.Active(t) {
+		return true
+	}
+	for _, name := range n.s.Calendars {
+		active, err := n.et.tm.CalendarService.Active(name, t)
+		if err != nil {
+			n.diag.Error("failed to check calendar", err, keyvalue.KV("calendar", name))
+			continue
+		}
+		if active {
+			return true
+		}
+	}
+	return false
+}
+
+// pass reports whether a point at time t is passed.
+func (n *ScheduleNode) pass(t time.Time) bool {
+	if n.active(t) != n.s.IsExclude {
+		return true
+	}
+	n.dropped.Add(1)
+	return false
+}
+
+func (n *ScheduleNode) Point(p edge.PointMessage) (edge.Message, error) {
+	if !n.pass(p.Time()) {
+		return nil, nil
+	}
+	return p, nil
+}
+
+func (n *ScheduleNode) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	begin = begin.ShallowCopy()
+	begin.SetSizeHint(0)
+	return begin, nil
+}
+
+func (n *ScheduleNode) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	if !n.pass(bp.Time()) {
+		return nil, nil
+	}
+	return bp, nil
+}
+
+func (n *ScheduleNode) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	return end, nil
+}
+
+func (n *ScheduleNode) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	return b, nil
+}
+
+func (n *ScheduleNode) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	return d, nil
+}
+
+func (n *ScheduleNode) Done() {}
@@ -20,6 +20,7 @@ import (
 	"github.com/influxdata/kapacitor/services/auth"
 	"github.com/influxdata/kapacitor/services/azure"
 	"github.com/influxdata/kapacitor/services/bigpanda"
+	"github.com/influxdata/kapacitor/services/calendar"
 	"github.com/influxdata/kapacitor/services/config"
 	"github.com/influxdata/kapacitor/services/consul"
 	"github.com/influxdata/kapacitor/services/deadman"
@@ -81,6 +82,7 @@ type Config struct {
 	Task           task_store.Config `toml:"task"`
 	FluxTask       task.Config       `toml:"fluxtask"`
 	Load           load.Config       `toml:"load"`
+	Calendars      calendar.Configs  `toml:"calendar"`
 	InfluxDB       []influxdb.Config `toml:"influxdb" override:"influxdb,element-key=name"`
 	Logging        diagnostic.Config `toml:"logging"`
 	ConfigOverride config.Config     `toml:"config-override"`
@@ -257,6 +259,9 @@ func (c *Config) Validate() error {
 	if err := c.Load.Validate(); err != nil {
 		return err
 	}
+	if err := c.Calendars.Validate(); err != nil {
+		return errors.Wrap(err, "calendar")
+	}
 	// Validate the set of InfluxDB configs.
 	// All names should be unique.
 	names := make(map[string]bool, len(c.InfluxDB))
@@ -31,6 +31,7 @@ import (
 	authservice "github.com/influxdata/kapacitor/services/auth"
 	"github.com/influxdata/kapacitor/services/azure"
 	"github.com/influxdata/kapacitor/services/bigpanda"
+	"github.com/influxdata/kapacitor/services/calendar"
 	"github.com/influxdata/kapacitor/services/config"
 	"github.com/influxdata/kapacitor/services/consul"
 	"github.com/influxdata/kapacitor/services/deadman"
@@ -132,6 +133,7 @@ type Server struct {
 
 	LoadService           *load.Service
 	SideloadService       *sideload.Service
+	CalendarService       *calendar.Service
 	AuthService           auth.Interface
 	HTTPDService          *httpd.Service
 	StorageService        *storage.Service
@@ -252,6 +254,7 @@ func New(c *Config, buildInfo BuildInfo, diagService *diagnostic.Service, disabl
 	s.appendConfigOverrideService()
 	s.appendTesterService()
 	s.appendSideloadService()
+	s.appendCalendarService()
 
 	// Init alert service
 	s.initAlertService()
@@ -413,6 +416,9 @@ func (s *Server) initAlertService() {
 	srv.HTTPDService = s.HTTPDService
 	srv.StorageService = s.StorageService
 	srv.PersistTopics = s.config.Alert.PersistTopics
+	srv.RecordHistory = s.config.Alert.RecordHistory
+	srv.HistoryRetention = time.Duration(s.config.Alert.HistoryRetention)
+	srv.HistoryMaxEntries = s.config.Alert.HistoryMaxEntries
 	s.AlertService = srv
 	s.TaskMaster.AlertService = srv
 }
@@ -450,6 +456,18 @@ func (s *Server) appendSideloadService() {
 	s.AppendService("sideload", srv)
 }
 
+func (s *Server) appendCalendarService() {
+	c := s.config.Calendars
+	d := s.DiagService.NewCalendarHandler()
+	srv := calendar.NewService(c, d)
+	srv.StorageService = s.StorageService
+	srv.HTTPDService = s.HTTPDService
+
+	s.CalendarService = srv
+	s.TaskMaster.CalendarService = srv
+	s.AppendService("calendar", srv)
+}
+
 func (s *Server) appendSMTPService() {
 	c := s.config.SMTP
 	d := s.DiagService.NewSMTPHandler()
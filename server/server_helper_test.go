@@ -73,6 +73,8 @@ func (s *Server) Start() {
 
 func (s *Server) Restart() {
 	s.Stop()
+	// The connections to the stopped server cannot be reused by the writes.
+	http.DefaultClient.CloseIdleConnections()
 	s.Start()
 }
 
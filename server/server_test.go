@@ -44,6 +44,7 @@ import (
 	"github.com/influxdata/kapacitor/services/auth"
 	"github.com/influxdata/kapacitor/services/auth/meta"
 	"github.com/influxdata/kapacitor/services/bigpanda/bigpandatest"
+	"github.com/influxdata/kapacitor/services/calendar"
 	"github.com/influxdata/kapacitor/services/discord/discordtest"
 	"github.com/influxdata/kapacitor/services/hipchat/hipchattest"
 	"github.com/influxdata/kapacitor/services/httppost"
@@ -2256,6 +2257,131 @@ test value=1 0000000011
 	}
 }
 
+func TestServer_StreamTask_Snapshot(t *testing.T) {
+	c := NewConfig(t)
+	c.Task.SnapshotInterval = toml.Duration(10 * time.Millisecond)
+	s := OpenServer(c)
+	cli := Client(s)
+	defer s.Close()
+
+	id := "testStreamTaskSnapshot"
+	tick := `var data = stream
+    |from()
+        .measurement('cpu')
+
+data
+    |derivative('value')
+    |httpOut('derivative')
+
+data
+    |window()
+        .period(5s)
+        .every(5s)
+    |count('value')
+    |httpOut('count')
+
+data
+    |stateCount(lambda: "value" > 10)
+    |httpOut('state')
+
+data
+    |alert()
+        .crit(lambda: "value" > 10)
+        .durationField('duration')
+    |httpOut('alert')
+`
+	task, err := cli.CreateTask(client.CreateTaskOptions{
+		ID:   id,
+		Type: client.StreamTask,
+		DBRPs: []client.DBRP{{
+			Database:        "mydb",
+			RetentionPolicy: "myrp",
+		}},
+		TICKscript: tick,
+		Status:     client.Enabled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := url.Values{}
+	v.Add("precision", "s")
+	s.MustWrite("mydb", "myrp", `cpu value=5 0000000000
+cpu value=11 0000000001
+cpu value=12 0000000002
+`, v)
+
+	endpoint := func(name string) string {
+		return fmt.Sprintf("%s/tasks/%s/%s", s.URL(), id, name)
+	}
+	exp := `{"series":[{"name":"cpu","columns":["time","state_count","value"],"values":[["1970-01-01T00:00:02Z",2,12]]}]}`
+	if err := s.HTTPGetRetry(endpoint("state"), exp, 100, 5*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	// Wait for a snapshot of the processed points to be saved.
+	time.Sleep(100 * time.Millisecond)
+
+	// The nodes continue from the state of the snapshot after a restart.
+	s.Restart()
+	cli = Client(s)
+
+	s.MustWrite("mydb", "myrp", `cpu value=18 0000000005
+`, v)
+
+	testCases := []struct {
+		name string
+		exp  string
+	}{
+		{
+			name: "derivative",
+			exp:  `{"series":[{"name":"cpu","columns":["time","value"],"values":[["1970-01-01T00:00:05Z",2]]}]}`,
+		},
+		{
+			name: "count",
+			exp:  `{"series":[{"name":"cpu","columns":["time","count"],"values":[["1970-01-01T00:00:05Z",3]]}]}`,
+		},
+		{
+			name: "state",
+			exp:  `{"series":[{"name":"cpu","columns":["time","state_count","value"],"values":[["1970-01-01T00:00:05Z",3,18]]}]}`,
+		},
+		{
+			name: "alert",
+			exp:  `{"series":[{"name":"cpu","columns":["time","duration","value"],"values":[["1970-01-01T00:00:05Z",4000000000,18]]}]}`,
+		},
+	}
+	for _, tc := range testCases {
+		if err := s.HTTPGetRetry(endpoint(tc.name), tc.exp, 100, 5*time.Millisecond); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// The snapshot of a changed pipeline is discarded.
+	if _, err := cli.UpdateTask(task.Link, client.UpdateTaskOptions{
+		TICKscript: strings.Replace(tick, "|derivative('value')", "|derivative('value')\n        .unit(2s)", 1),
+		Status:     client.Disabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.UpdateTask(task.Link, client.UpdateTaskOptions{
+		Status: client.Enabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.MustWrite("mydb", "myrp", `cpu value=20 0000000006
+`, v)
+
+	exp = `{"series":[{"name":"cpu","columns":["time","state_count","value"],"values":[["1970-01-01T00:00:06Z",1,20]]}]}`
+	if err := s.HTTPGetRetry(endpoint("state"), exp, 100, 5*time.Millisecond); err != nil {
+		t.Error(err)
+	}
+	exp = `{"series":null}`
+	if err := s.HTTPGetRetry(endpoint("derivative"), exp, 100, 5*time.Millisecond); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestServer_StreamTemplateTask(t *testing.T) {
 	s, cli := OpenDefaultServer(t)
 	defer s.Close()
@@ -3357,6 +3483,116 @@ func TestServer_BatchTask(t *testing.T) {
 		}
 	}
 }
+func TestServer_StreamTask_LookupJoin(t *testing.T) {
+	c := NewConfig(t)
+	c.InfluxDB[0].Enabled = true
+	db := NewInfluxDB(func(q string) *iclient.Response {
+		if !strings.HasPrefix(q, "SELECT") {
+			return nil
+		}
+		return &iclient.Response{
+			Results: []iclient.Result{{
+				Series: []imodels.Row{
+					{
+						Name:    "hosts",
+						Tags:    map[string]string{"host": "A", "rack": "r1"},
+						Columns: []string{"time", "owner"},
+						Values: [][]interface{}{
+							{"1970-01-01T00:00:00Z", "alice"},
+							{"1970-01-01T00:00:01Z", "bob"},
+						},
+					},
+					{
+						Name:    "hosts",
+						Tags:    map[string]string{"host": "B", "rack": "r1"},
+						Columns: []string{"time", "owner"},
+						Values: [][]interface{}{
+							{"1970-01-01T00:00:01Z", "carol"},
+						},
+					},
+				},
+			}},
+		}
+	})
+	defer db.Close()
+	c.InfluxDB[0].URLs = []string{db.URL()}
+	s := OpenServer(c)
+	defer s.Close()
+	cli := Client(s)
+
+	id := "testStreamTaskLookupJoin"
+	tick := `var owners = batch
+    |query('SELECT owner FROM "cmdb"."autogen"."hosts"')
+        .period(1d)
+        .every(10ms)
+        .groupBy('host')
+
+stream
+    |from()
+        .measurement('cpu')
+        .groupBy('host')
+    |lookupJoin(owners)
+        .on('host')
+        .prefix('cmdb.')
+    |httpOut('enriched')
+`
+	task, err := cli.CreateTask(client.CreateTaskOptions{
+		ID:   id,
+		Type: client.StreamTask,
+		DBRPs: []client.DBRP{
+			{
+				Database:        "mydb",
+				RetentionPolicy: "myrp",
+			},
+			{
+				Database:        "cmdb",
+				RetentionPolicy: "autogen",
+			},
+		},
+		TICKscript: tick,
+		Status:     client.Enabled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the table to be received by the lookupJoin node.
+	cardinality := func() interface{} {
+		ti, err := cli.Task(task.Link, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, stats := range ti.ExecutionStats.NodeStats {
+			if strings.HasPrefix(name, "lookupJoin") {
+				return stats["working_cardinality"]
+			}
+		}
+		return nil
+	}
+	for i := 0; i < 100 && cardinality() != 2.0; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got, exp := cardinality(), 2.0; got != exp {
+		t.Fatalf("unexpected table cardinality got %v exp %v", got, exp)
+	}
+
+	v := url.Values{}
+	v.Add("precision", "s")
+	s.MustWrite("mydb", "myrp", `cpu,host=A value=1 0000000001
+cpu,host=B,rack=r2 value=2,cmdb.owner="dave" 0000000001
+cpu,host=C value=3 0000000001
+`, v)
+
+	// The latest owner and rack are added, existing fields and tags are kept and points without a table entry are passed on unchanged.
+	endpoint := fmt.Sprintf("%s/tasks/%s/enriched", s.URL(), id)
+	exp := `{"series":[{"name":"cpu","tags":{"host":"A","rack":"r1"},"columns":["time","cmdb.owner","value"],"values":[["1970-01-01T00:00:01Z","bob",1]]},` +
+		`{"name":"cpu","tags":{"host":"B","rack":"r2"},"columns":["time","cmdb.owner","value"],"values":[["1970-01-01T00:00:01Z","dave",2]]},` +
+		`{"name":"cpu","tags":{"host":"C"},"columns":["time","value"],"values":[["1970-01-01T00:00:01Z",3]]}]}`
+	if err := s.HTTPGetRetry(endpoint, exp, 100, 5*time.Millisecond); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestServer_BatchTask_InfluxDBConfigUpdate(t *testing.T) {
 	c := NewConfig(t)
 	c.InfluxDB[0].Enabled = true
@@ -12610,6 +12846,885 @@ stream
 		}
 	}
 }
+
+func TestServer_AlertTopic_AckEvent(t *testing.T) {
+	// Create default config
+	c := NewConfig(t)
+	s := OpenServer(c)
+	cli := Client(s)
+	defer s.Close()
+
+	tick := `
+stream
+	|from()
+		.measurement('alert')
+	|alert()
+		.topic('test')
+		.id('id')
+		.message('message')
+		.details('details')
+		.warn(lambda: TRUE)
+`
+
+	if _, err := cli.CreateTask(client.CreateTaskOptions{
+		ID:   "testAlertAck",
+		Type: client.StreamTask,
+		DBRPs: []client.DBRP{{
+			Database:        "mydb",
+			RetentionPolicy: "myrp",
+		}},
+		TICKscript: tick,
+		Status:     client.Enabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	point := "alert value=1 0000000000"
+	v := url.Values{}
+	v.Add("precision", "s")
+	s.MustWrite("mydb", "myrp", point, v)
+
+	// Restart the server
+	s.Restart()
+	// The connections of the client do not survive the restart and the ack is not retried.
+	cli = Client(s)
+
+	event, err := cli.AckTopicEvent(cli.TopicEventAckLink("test", "id"), client.TopicEventAckOptions{
+		Author: "ops",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.State.AckedAt.IsZero() {
+		t.Error("expected the ack time to be set")
+	}
+	expEvent := client.TopicEvent{
+		Link: client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/topics/test/events/id"},
+		ID:   "id",
+		State: client.EventState{
+			Message:  "message",
+			Details:  "details",
+			Time:     time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			Duration: 0,
+			Level:    "WARNING",
+			Acked:    true,
+			AckedBy:  "ops",
+			AckedAt:  event.State.AckedAt,
+		},
+	}
+	if !reflect.DeepEqual(event, expEvent) {
+		t.Errorf("unexpected acked event:\ngot\n%+v\nexp\n%+v\n", event, expEvent)
+	}
+
+	// The ack is part of the state of the event
+	event, err = cli.TopicEvent(expEvent.Link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(event, expEvent) {
+		t.Errorf("unexpected topic event:\ngot\n%+v\nexp\n%+v\n", event, expEvent)
+	}
+
+	if _, err := cli.AckTopicEvent(cli.TopicEventAckLink("test", "missing"), client.TopicEventAckOptions{}); err == nil {
+		t.Error("expected error acking an unknown event")
+	}
+}
+
+func TestServer_AlertHistory(t *testing.T) {
+	// Create default config
+	c := NewConfig(t)
+	c.Alert.RecordHistory = true
+	// Keep the entries of 1970
+	c.Alert.HistoryRetention = 0
+	s := OpenServer(c)
+	cli := Client(s)
+	defer s.Close()
+
+	tick := `
+stream
+	|from()
+		.measurement('alert')
+		.groupBy('host')
+	|alert()
+		.topic('history')
+		.id('{{ index .Tags "host" }}')
+		.message('{{ .ID }} is {{ .Level }}')
+		.details('details')
+		.warn(lambda: "value" > 10)
+		.crit(lambda: "value" > 20)
+`
+
+	if _, err := cli.CreateTask(client.CreateTaskOptions{
+		ID:   "testAlertHistory",
+		Type: client.StreamTask,
+		DBRPs: []client.DBRP{{
+			Database:        "mydb",
+			RetentionPolicy: "myrp",
+		}},
+		TICKscript: tick,
+		Status:     client.Enabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	points := `alert,host=A value=15 0000000000
+alert,host=B value=25 0000000001
+alert,host=A value=25 0000000002
+alert,host=A value=25 0000000003
+alert,host=A value=5 0000000004
+`
+	v := url.Values{}
+	v.Add("precision", "s")
+	s.MustWrite("mydb", "myrp", points, v)
+
+	// Wait for the level changes to be recorded
+	retry := 0
+	for {
+		history, err := cli.AlertHistory(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(history.Entries) == 4 {
+			break
+		}
+		retry++
+		if retry > 100 {
+			t.Fatalf("unexpected number of alert history entries: got %d exp 4", len(history.Entries))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	entry := func(id string, sec int, level, previousLevel string) client.AlertHistoryEntry {
+		return client.AlertHistoryEntry{
+			Topic:         "history",
+			ID:            id,
+			Time:          time.Date(1970, 1, 1, 0, 0, sec, 0, time.UTC),
+			Level:         level,
+			PreviousLevel: previousLevel,
+			Message:       id + " is " + level,
+			Details:       "details",
+			Tags:          map[string]string{"host": id},
+		}
+	}
+	// Only the level changes are recorded
+	all := []client.AlertHistoryEntry{
+		entry("A", 0, "WARNING", "OK"),
+		entry("B", 1, "CRITICAL", "OK"),
+		entry("A", 2, "CRITICAL", "WARNING"),
+		entry("A", 4, "OK", "CRITICAL"),
+	}
+	testCases := []struct {
+		name string
+		opt  *client.AlertHistoryOptions
+		exp  client.AlertHistory
+	}{
+		{
+			name: "all",
+			exp: client.AlertHistory{
+				Link:    client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/history"},
+				Entries: all,
+			},
+		},
+		{
+			name: "event",
+			opt:  &client.AlertHistoryOptions{Topic: "hist*", Event: "A"},
+			exp: client.AlertHistory{
+				Link:    client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/history?event=A&topic=hist%2A"},
+				Entries: []client.AlertHistoryEntry{all[0], all[2], all[3]},
+			},
+		},
+		{
+			name: "tag",
+			opt:  &client.AlertHistoryOptions{Tags: map[string]string{"host": "B"}},
+			exp: client.AlertHistory{
+				Link:    client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/history?tag=host%3DB"},
+				Entries: []client.AlertHistoryEntry{all[1]},
+			},
+		},
+		{
+			name: "time range",
+			opt: &client.AlertHistoryOptions{
+				Start: time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC),
+				Stop:  time.Date(1970, 1, 1, 0, 0, 4, 0, time.UTC),
+			},
+			exp: client.AlertHistory{
+				Link:    client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/history?start=1970-01-01T00%3A00%3A01Z&stop=1970-01-01T00%3A00%3A04Z"},
+				Entries: []client.AlertHistoryEntry{all[1], all[2]},
+			},
+		},
+		{
+			name: "limit",
+			opt:  &client.AlertHistoryOptions{Limit: 2},
+			exp: client.AlertHistory{
+				Link:    client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/history?limit=2"},
+				Entries: []client.AlertHistoryEntry{all[2], all[3]},
+			},
+		},
+		{
+			name: "unknown topic",
+			opt:  &client.AlertHistoryOptions{Topic: "other"},
+			exp: client.AlertHistory{
+				Link:    client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/history?topic=other"},
+				Entries: []client.AlertHistoryEntry{},
+			},
+		},
+	}
+	check := func(when string) {
+		t.Helper()
+		for _, tc := range testCases {
+			history, err := cli.AlertHistory(tc.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(history, tc.exp) {
+				t.Errorf("unexpected alert history %s %s:\ngot\n%+v\nexp\n%+v\n", tc.name, when, history, tc.exp)
+			}
+		}
+	}
+	check("")
+
+	if _, err := cli.AlertHistory(&client.AlertHistoryOptions{Topic: "["}); err == nil {
+		t.Error("expected error querying the alert history with an invalid pattern")
+	}
+
+	// The history is persisted
+	s.Restart()
+	cli = Client(s)
+	check("after restart")
+}
+
+func TestServer_AlertSilences(t *testing.T) {
+	// Create default config
+	c := NewConfig(t)
+	s := OpenServer(c)
+	cli := Client(s)
+	defer s.Close()
+
+	expiresAt := time.Now().UTC().Add(time.Hour).Round(time.Second)
+	silence, err := cli.CreateSilence(client.SilenceOptions{
+		ID:    "maintenance",
+		Topic: "main:*",
+		Matchers: []client.TagMatcher{{
+			Name:  "host",
+			Value: "server0[12]",
+			Regex: true,
+		}},
+		Author:    "ops",
+		Comment:   "planned maintenance",
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expSilence := client.Silence{
+		Link:  client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/silences/maintenance"},
+		ID:    "maintenance",
+		Topic: "main:*",
+		Matchers: []client.TagMatcher{{
+			Name:  "host",
+			Value: "server0[12]",
+			Regex: true,
+		}},
+		Author:    "ops",
+		Comment:   "planned maintenance",
+		CreatedAt: silence.CreatedAt,
+		ExpiresAt: expiresAt,
+	}
+	if silence.CreatedAt.IsZero() {
+		t.Error("expected the creation time to be set")
+	}
+	if !reflect.DeepEqual(silence, expSilence) {
+		t.Errorf("unexpected created silence:\ngot\n%+v\nexp\n%+v\n", silence, expSilence)
+	}
+
+	// A silence without an ID gets a generated ID
+	generated, err := cli.CreateSilence(client.SilenceOptions{
+		Author:   "ops",
+		Duration: client.Duration(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if generated.ID == "" {
+		t.Error("expected a generated silence ID")
+	}
+
+	// Silences must expire
+	if _, err := cli.CreateSilence(client.SilenceOptions{
+		ID:     "forever",
+		Author: "ops",
+	}); err == nil {
+		t.Error("expected error creating a silence without an expiry")
+	}
+
+	silence, err = cli.Silence(cli.SilenceLink("maintenance"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(silence, expSilence) {
+		t.Errorf("unexpected silence:\ngot\n%+v\nexp\n%+v\n", silence, expSilence)
+	}
+
+	silences, err := cli.ListSilences(&client.ListSilencesOptions{
+		Pattern: "main*",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expSilences := client.Silences{
+		Link:     client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/silences?pattern=main%2A"},
+		Silences: []client.Silence{expSilence},
+	}
+	if !reflect.DeepEqual(silences, expSilences) {
+		t.Errorf("unexpected silences:\ngot\n%+v\nexp\n%+v\n", silences, expSilences)
+	}
+
+	silences, err = cli.ListSilences(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := 2, len(silences.Silences); got != exp {
+		t.Errorf("unexpected number of silences: got %d exp %d", got, exp)
+	}
+
+	if err := cli.DeleteSilence(cli.SilenceLink("maintenance")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Silence(cli.SilenceLink("maintenance")); err == nil {
+		t.Error("expected error getting a deleted silence")
+	}
+
+	// Expired silences are removed
+	if _, err := cli.CreateSilence(client.SilenceOptions{
+		ID:        "expired",
+		Author:    "ops",
+		ExpiresAt: time.Now().UTC().Add(-time.Minute),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Silence(cli.SilenceLink("expired")); err == nil {
+		t.Error("expected error getting an expired silence")
+	}
+	silences, err = cli.ListSilences(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expSilences = client.Silences{
+		Link:     client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/silences?pattern="},
+		Silences: []client.Silence{generated},
+	}
+	if !reflect.DeepEqual(silences, expSilences) {
+		t.Errorf("unexpected silences after expiry:\ngot\n%+v\nexp\n%+v\n", silences, expSilences)
+	}
+
+	// Expired silences are not restored
+	s.Restart()
+	silences, err = cli.ListSilences(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(silences, expSilences) {
+		t.Errorf("unexpected silences after restart:\ngot\n%+v\nexp\n%+v\n", silences, expSilences)
+	}
+}
+
+func TestServer_AlertInhibitions(t *testing.T) {
+	// Create default config
+	c := NewConfig(t)
+	s := OpenServer(c)
+	cli := Client(s)
+	defer s.Close()
+
+	inhibitions, err := cli.ListInhibitions(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := 0, len(inhibitions.Inhibitions); got != exp {
+		t.Errorf("unexpected number of inhibitions: got %d exp %d", got, exp)
+	}
+
+	inhibition, err := cli.CreateInhibition(client.InhibitionOptions{
+		ID:     "datacenter",
+		Source: client.InhibitionMatch{Category: "datacenter"},
+		Target: client.InhibitionMatch{Category: "host"},
+		Equal:  []string{"dc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expInhibition := client.Inhibition{
+		Link:   client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/inhibitions/datacenter"},
+		ID:     "datacenter",
+		Source: client.InhibitionMatch{Category: "datacenter", Matchers: []client.TagMatcher{}},
+		Target: client.InhibitionMatch{Category: "host", Matchers: []client.TagMatcher{}},
+		Equal:  []string{"dc"},
+	}
+	if !reflect.DeepEqual(inhibition, expInhibition) {
+		t.Errorf("unexpected created inhibition:\ngot\n%+v\nexp\n%+v\n", inhibition, expInhibition)
+	}
+
+	errorCases := []struct {
+		name string
+		opt  client.InhibitionOptions
+	}{
+		{
+			name: "duplicate",
+			opt: client.InhibitionOptions{
+				ID:     "datacenter",
+				Source: client.InhibitionMatch{Category: "datacenter"},
+				Target: client.InhibitionMatch{Category: "host"},
+			},
+		},
+		{
+			name: "invalid ID",
+			opt: client.InhibitionOptions{
+				ID:     "data center",
+				Source: client.InhibitionMatch{Category: "datacenter"},
+				Target: client.InhibitionMatch{Category: "host"},
+			},
+		},
+		{
+			name: "no source",
+			opt: client.InhibitionOptions{
+				ID:     "nosource",
+				Target: client.InhibitionMatch{Category: "host"},
+			},
+		},
+		{
+			name: "invalid regex",
+			opt: client.InhibitionOptions{
+				ID:     "regex",
+				Source: client.InhibitionMatch{Category: "datacenter"},
+				Target: client.InhibitionMatch{Matchers: []client.TagMatcher{{Name: "host", Value: "(", Regex: true}}},
+			},
+		},
+	}
+	for _, tc := range errorCases {
+		if _, err := cli.CreateInhibition(tc.opt); err == nil {
+			t.Errorf("expected error creating inhibition %s", tc.name)
+		}
+	}
+	if _, err := cli.ReplaceInhibition(cli.InhibitionLink("missing"), client.InhibitionOptions{
+		ID:     "missing",
+		Source: client.InhibitionMatch{Category: "datacenter"},
+		Target: client.InhibitionMatch{Category: "host"},
+	}); err == nil {
+		t.Error("expected error replacing a missing inhibition")
+	}
+
+	// Inhibit the alerts of the web servers of the datacenters
+	inhibition, err = cli.ReplaceInhibition(cli.InhibitionLink("datacenter"), client.InhibitionOptions{
+		ID:     "datacenter",
+		Source: client.InhibitionMatch{Category: "datacenter"},
+		Target: client.InhibitionMatch{
+			Category: "host",
+			Matchers: []client.TagMatcher{{Name: "host", Value: "web.*", Regex: true}},
+		},
+		Equal: []string{"dc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expInhibition.Target.Matchers = []client.TagMatcher{{Name: "host", Value: "web.*", Regex: true}}
+	if !reflect.DeepEqual(inhibition, expInhibition) {
+		t.Errorf("unexpected replaced inhibition:\ngot\n%+v\nexp\n%+v\n", inhibition, expInhibition)
+	}
+	inhibition, err = cli.Inhibition(cli.InhibitionLink("datacenter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(inhibition, expInhibition) {
+		t.Errorf("unexpected inhibition:\ngot\n%+v\nexp\n%+v\n", inhibition, expInhibition)
+	}
+
+	if _, err := cli.CreateInhibition(client.InhibitionOptions{
+		ID:     "rack",
+		Source: client.InhibitionMatch{Category: "rack"},
+		Target: client.InhibitionMatch{Category: "host"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	inhibitions, err = cli.ListInhibitions(&client.ListInhibitionsOptions{
+		Pattern: "data*",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expInhibitions := client.Inhibitions{
+		Link:        client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/inhibitions?pattern=data%2A"},
+		Inhibitions: []client.Inhibition{expInhibition},
+	}
+	if !reflect.DeepEqual(inhibitions, expInhibitions) {
+		t.Errorf("unexpected inhibitions:\ngot\n%+v\nexp\n%+v\n", inhibitions, expInhibitions)
+	}
+
+	tick := `
+stream
+	|from()
+		.measurement('datacenter')
+		.groupBy('dc')
+	|alert()
+		.category('datacenter')
+		.topic('datacenter')
+		.id('{{ index .Tags "dc" }}')
+		.crit(lambda: "value" > 0)
+
+stream
+	|from()
+		.measurement('host')
+		.groupBy('dc', 'host')
+	|alert()
+		.category('host')
+		.topic('host')
+		.id('{{ index .Tags "host" }}')
+		.crit(lambda: "value" > 0)
+`
+	if _, err := cli.CreateTask(client.CreateTaskOptions{
+		ID:   "testInhibitions",
+		Type: client.StreamTask,
+		DBRPs: []client.DBRP{{
+			Database:        "mydb",
+			RetentionPolicy: "myrp",
+		}},
+		TICKscript: tick,
+		Status:     client.Enabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	v := url.Values{}
+	v.Add("precision", "s")
+	// checkEvents waits for the last written point to be processed,
+	// which must be the last of the expected events.
+	checkEvents := func(when string, exp []string) {
+		t.Helper()
+		var got []string
+		for retry := 0; retry < 100; retry++ {
+			te, err := cli.ListTopicEvents(cli.TopicEventsLink("host"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = make([]string, len(te.Events))
+			for i, e := range te.Events {
+				got[i] = e.ID + ":" + e.State.Level
+			}
+			sort.Strings(got)
+			if reflect.DeepEqual(got, exp) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Errorf("unexpected host events %s:\ngot\n%v\nexp\n%v\n", when, got, exp)
+	}
+
+	// The web server of the failed datacenter is inhibited
+	s.MustWrite("mydb", "myrp", `datacenter,dc=east value=1 0000000000
+host,dc=east,host=web01 value=1 0000000001
+host,dc=east,host=db01 value=1 0000000002
+host,dc=west,host=web02 value=1 0000000003
+`, v)
+	checkEvents("while the datacenter is down", []string{"db01:CRITICAL", "web02:CRITICAL"})
+
+	// The sources of the inhibitions are restored from the topic state
+	s.Restart()
+	cli = Client(s)
+	s.MustWrite("mydb", "myrp", `host,dc=east,host=web03 value=1 0000000004
+host,dc=west,host=web04 value=1 0000000004
+`, v)
+	checkEvents("after restart", []string{"db01:CRITICAL", "web02:CRITICAL", "web04:CRITICAL"})
+
+	// The inhibition ends once the datacenter recovers
+	s.MustWrite("mydb", "myrp", `datacenter,dc=east value=0 0000000005
+host,dc=east,host=web01 value=1 0000000006
+`, v)
+	checkEvents("once the datacenter recovered", []string{"db01:CRITICAL", "web01:CRITICAL", "web02:CRITICAL", "web04:CRITICAL"})
+
+	if err := cli.DeleteInhibition(cli.InhibitionLink("datacenter")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Inhibition(cli.InhibitionLink("datacenter")); err == nil {
+		t.Error("expected error getting a deleted inhibition")
+	}
+	inhibitions, err = cli.ListInhibitions(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := 1, len(inhibitions.Inhibitions); got != exp {
+		t.Errorf("unexpected number of inhibitions after delete: got %d exp %d", got, exp)
+	}
+}
+
+func TestServer_LoadInhibitions(t *testing.T) {
+	s, c, cli := OpenLoadServer(t)
+	defer s.Close()
+
+	datacenter := client.Inhibition{
+		Link:   client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/inhibitions/datacenter"},
+		ID:     "datacenter",
+		Source: client.InhibitionMatch{Category: "datacenter", Matchers: []client.TagMatcher{}},
+		Target: client.InhibitionMatch{Category: "host", Matchers: []client.TagMatcher{}},
+		Equal:  []string{"dc"},
+	}
+	rack := client.Inhibition{
+		Link:   client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/inhibitions/rack"},
+		ID:     "rack",
+		Source: client.InhibitionMatch{Category: "rack", Matchers: []client.TagMatcher{}},
+		Target: client.InhibitionMatch{
+			Matchers: []client.TagMatcher{{Name: "role", Value: "web|db", Regex: true}},
+		},
+		Equal: []string{"rack"},
+	}
+	checkInhibitions := func(when string, exp []client.Inhibition) {
+		t.Helper()
+		inhibitions, err := cli.ListInhibitions(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(inhibitions.Inhibitions, exp) {
+			t.Errorf("unexpected inhibitions %s:\ngot\n%+v\nexp\n%+v\n", when, inhibitions.Inhibitions, exp)
+		}
+	}
+	// The ID of the YAML inhibition is the name of its file
+	checkInhibitions("after load", []client.Inhibition{datacenter, rack})
+
+	// Update an inhibition and remove the other
+	update := `id: datacenter
+source:
+  category: datacenter
+target:
+  category: host
+  matchers:
+    - name: host
+      value: web01
+equal:
+  - dc
+`
+	if err := os.WriteFile(path.Join(c.Load.Dir, "inhibitions", "datacenter.yaml"), []byte(update), 0600); err != nil {
+		t.Fatalf("failed to write inhibition: %v", err)
+	}
+	if err := os.Remove(path.Join(c.Load.Dir, "inhibitions", "rack.json")); err != nil {
+		t.Fatalf("failed to remove inhibition file: %v", err)
+	}
+
+	s.Reload()
+
+	datacenter.Target.Matchers = []client.TagMatcher{{Name: "host", Value: "web01"}}
+	checkInhibitions("after reload", []client.Inhibition{datacenter})
+}
+
+func TestServer_Calendars(t *testing.T) {
+	// Create default config
+	c := NewConfig(t)
+	c.Calendars = calendar.Configs{{
+		Name:     "business-hours",
+		Timezone: "Europe/Paris",
+		Windows: []calendar.WindowConfig{{
+			Cron:     "0 9 * * 1-5",
+			Duration: toml.Duration(8 * time.Hour),
+		}},
+	}}
+	s := OpenServer(c)
+	cli := Client(s)
+	defer s.Close()
+
+	expConfigured := client.Calendar{
+		Link:     client.Link{Relation: client.Self, Href: "/kapacitor/v1/calendars/business-hours"},
+		Name:     "business-hours",
+		Timezone: "Europe/Paris",
+		Windows: []client.CalendarWindow{{
+			Cron:     "0 9 * * 1-5",
+			Duration: client.Duration(8 * time.Hour),
+		}},
+		Configured: true,
+	}
+	calendars, err := cli.ListCalendars(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []client.Calendar{expConfigured}; !reflect.DeepEqual(calendars, exp) {
+		t.Errorf("unexpected calendars:\ngot\n%+v\nexp\n%+v\n", calendars, exp)
+	}
+
+	cal, err := cli.CreateCalendar(client.CalendarOptions{
+		Name: "maintenance",
+		Windows: []client.CalendarWindow{{
+			Cron:     "0 0 * * *",
+			Duration: client.Duration(time.Hour),
+		}},
+		Holidays: []string{"1970-01-02"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expCalendar := client.Calendar{
+		Link:     client.Link{Relation: client.Self, Href: "/kapacitor/v1/calendars/maintenance"},
+		Name:     "maintenance",
+		Timezone: "UTC",
+		Windows: []client.CalendarWindow{{
+			Cron:     "0 0 * * *",
+			Duration: client.Duration(time.Hour),
+		}},
+		Holidays: []string{"1970-01-02"},
+	}
+	if !reflect.DeepEqual(cal, expCalendar) {
+		t.Errorf("unexpected created calendar:\ngot\n%+v\nexp\n%+v\n", cal, expCalendar)
+	}
+
+	if _, err := cli.CreateCalendar(client.CalendarOptions{
+		Name: "maintenance",
+		Windows: []client.CalendarWindow{{
+			Cron:     "0 0 * * *",
+			Duration: client.Duration(time.Hour),
+		}},
+	}); err == nil {
+		t.Error("expected error creating a duplicate calendar")
+	}
+	if _, err := cli.CreateCalendar(client.CalendarOptions{
+		Name: "invalid",
+		Windows: []client.CalendarWindow{{
+			Cron:     "not a cron",
+			Duration: client.Duration(time.Hour),
+		}},
+	}); err == nil {
+		t.Error("expected error creating a calendar with an invalid cron expression")
+	}
+	if _, err := cli.CreateCalendar(client.CalendarOptions{
+		Name: "empty",
+	}); err == nil {
+		t.Error("expected error creating a calendar without windows")
+	}
+
+	// Configured calendars cannot be modified
+	if _, err := cli.ReplaceCalendar(cli.CalendarLink("business-hours"), client.CalendarOptions{
+		Name: "business-hours",
+		Windows: []client.CalendarWindow{{
+			Cron:     "0 8 * * 1-5",
+			Duration: client.Duration(8 * time.Hour),
+		}},
+	}); err == nil {
+		t.Error("expected error replacing a configured calendar")
+	}
+	if err := cli.DeleteCalendar(cli.CalendarLink("business-hours")); err == nil {
+		t.Error("expected error deleting a configured calendar")
+	}
+
+	cal, err = cli.ReplaceCalendar(cli.CalendarLink("maintenance"), client.CalendarOptions{
+		Name: "maintenance",
+		Windows: []client.CalendarWindow{{
+			Cron:     "0 0 * * *",
+			Duration: client.Duration(2 * time.Hour),
+		}},
+		Holidays: []string{"1970-01-02"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expCalendar.Windows[0].Duration = client.Duration(2 * time.Hour)
+	if !reflect.DeepEqual(cal, expCalendar) {
+		t.Errorf("unexpected replaced calendar:\ngot\n%+v\nexp\n%+v\n", cal, expCalendar)
+	}
+	if _, err := cli.ReplaceCalendar(cli.CalendarLink("missing"), client.CalendarOptions{
+		Name: "missing",
+		Windows: []client.CalendarWindow{{
+			Cron:     "0 0 * * *",
+			Duration: client.Duration(time.Hour),
+		}},
+	}); err == nil {
+		t.Error("expected error replacing an unknown calendar")
+	}
+
+	cal, err = cli.Calendar(cli.CalendarLink("maintenance"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cal, expCalendar) {
+		t.Errorf("unexpected calendar:\ngot\n%+v\nexp\n%+v\n", cal, expCalendar)
+	}
+
+	calendars, err = cli.ListCalendars(&client.ListCalendarsOptions{
+		Pattern: "main*",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []client.Calendar{expCalendar}; !reflect.DeepEqual(calendars, exp) {
+		t.Errorf("unexpected calendars:\ngot\n%+v\nexp\n%+v\n", calendars, exp)
+	}
+
+	// Data is ignored during the maintenance
+	// and an alert still triggered after it is sent as a state change.
+	tick := `
+stream
+	|from()
+		.measurement('alert')
+	|alert()
+		.topic('calendar')
+		.id('id')
+		.message('{{ .Level }}')
+		.details('details')
+		.crit(lambda: "value" > 10)
+		.stateChangesOnly()
+		.inactiveDuring('maintenance')
+`
+	if _, err := cli.CreateTask(client.CreateTaskOptions{
+		ID:   "testCalendar",
+		Type: client.StreamTask,
+		DBRPs: []client.DBRP{{
+			Database:        "mydb",
+			RetentionPolicy: "myrp",
+		}},
+		TICKscript: tick,
+		Status:     client.Enabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	points := `alert value=20 0000000000
+alert value=20 0000003600
+alert value=20 0000007200
+alert value=20 0000010800
+`
+	v := url.Values{}
+	v.Add("precision", "s")
+	s.MustWrite("mydb", "myrp", points, v)
+
+	event, err := cli.TopicEvent(cli.TopicEventLink("calendar", "id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expEvent := client.TopicEvent{
+		Link: client.Link{Relation: client.Self, Href: "/kapacitor/v1/alerts/topics/calendar/events/id"},
+		ID:   "id",
+		State: client.EventState{
+			Message:  "CRITICAL",
+			Details:  "details",
+			Time:     time.Date(1970, 1, 1, 2, 0, 0, 0, time.UTC),
+			Duration: 0,
+			Level:    "CRITICAL",
+		},
+	}
+	if !reflect.DeepEqual(event, expEvent) {
+		t.Errorf("unexpected topic event:\ngot\n%+v\nexp\n%+v\n", event, expEvent)
+	}
+
+	// Created calendars are restored
+	s.Restart()
+	cli = Client(s)
+	calendars, err = cli.ListCalendars(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []client.Calendar{expConfigured, expCalendar}; !reflect.DeepEqual(calendars, exp) {
+		t.Errorf("unexpected calendars after restart:\ngot\n%+v\nexp\n%+v\n", calendars, exp)
+	}
+
+	if err := cli.DeleteCalendar(cli.CalendarLink("maintenance")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.Calendar(cli.CalendarLink("maintenance")); err == nil {
+		t.Error("expected error getting a deleted calendar")
+	}
+}
+
 func TestServer_Alert_Inhibition(t *testing.T) {
 	// Test Overview
 	// Create several alerts:
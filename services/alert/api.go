@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/influxdata/kapacitor/alert"
@@ -25,14 +27,28 @@ const (
 	topicsBasePathAnchored = httpd.BasePath + topicsPathAnchored
 
 	topicEventsPath           = "events"
+	topicEventAckPath         = "ack"
 	topicHandlersPath         = "handlers"
 	topicHandlersPathAnchored = topicHandlersPath + "/"
 
 	eventsPattern   = "*/" + topicEventsPath
 	eventPattern    = "*/" + topicEventsPath + "/*"
+	eventAckPattern = "*/" + topicEventsPath + "/*/" + topicEventAckPath
 	handlersPattern = "*/" + topicHandlersPath
 	handlerPattern  = "*/" + topicHandlersPath + "/*"
 
+	historyPath = alertsPath + "/history"
+
+	inhibitionsPath             = alertsPath + "/inhibitions"
+	inhibitionsPathAnchored     = alertsPath + "/inhibitions/"
+	inhibitionsBasePath         = httpd.BasePath + inhibitionsPath
+	inhibitionsBasePathAnchored = httpd.BasePath + inhibitionsPathAnchored
+
+	silencesPath             = alertsPath + "/silences"
+	silencesPathAnchored     = alertsPath + "/silences/"
+	silencesBasePath         = httpd.BasePath + silencesPath
+	silencesBasePathAnchored = httpd.BasePath + silencesPathAnchored
+
 	eventsRelation   = "events"
 	handlersRelation = "handlers"
 )
@@ -41,6 +57,10 @@ type apiServer struct {
 	Registrar    HandlerSpecRegistrar
 	Topics       Topics
 	Persister    TopicPersister
+	Acknowledger EventAcknowledger
+	Silencer     Silencer
+	History      History
+	Inhibitor    Inhibitor
 	routes       []httpd.Route
 	HTTPDService interface {
 		AddRoutes([]httpd.Route) error
@@ -88,6 +108,68 @@ func (s *apiServer) Open() error {
 			Pattern:     topicsPathAnchored,
 			HandlerFunc: httpd.ServeOptions,
 		},
+		{
+			Method:      "GET",
+			Pattern:     historyPath,
+			HandlerFunc: s.handleAlertHistory,
+		},
+		{
+			Method:      "GET",
+			Pattern:     inhibitionsPath,
+			HandlerFunc: s.handleListInhibitions,
+		},
+		{
+			Method:      "POST",
+			Pattern:     inhibitionsPath,
+			HandlerFunc: s.handleCreateInhibition,
+		},
+		{
+			Method:      "GET",
+			Pattern:     inhibitionsPathAnchored,
+			HandlerFunc: s.handleGetInhibition,
+		},
+		{
+			Method:      "PUT",
+			Pattern:     inhibitionsPathAnchored,
+			HandlerFunc: s.handleReplaceInhibition,
+		},
+		{
+			Method:      "DELETE",
+			Pattern:     inhibitionsPathAnchored,
+			HandlerFunc: s.handleDeleteInhibition,
+		},
+		{
+			// Satisfy CORS checks.
+			Method:      "OPTIONS",
+			Pattern:     inhibitionsPathAnchored,
+			HandlerFunc: httpd.ServeOptions,
+		},
+		{
+			Method:      "GET",
+			Pattern:     silencesPath,
+			HandlerFunc: s.handleListSilences,
+		},
+		{
+			Method:      "POST",
+			Pattern:     silencesPath,
+			HandlerFunc: s.handleCreateSilence,
+		},
+		{
+			Method:      "GET",
+			Pattern:     silencesPathAnchored,
+			HandlerFunc: s.handleGetSilence,
+		},
+		{
+			Method:      "DELETE",
+			Pattern:     silencesPathAnchored,
+			HandlerFunc: s.handleDeleteSilence,
+		},
+		{
+			// Satisfy CORS checks.
+			Method:      "OPTIONS",
+			Pattern:     silencesPathAnchored,
+			HandlerFunc: httpd.ServeOptions,
+		},
 	}
 
 	return s.HTTPDService.AddRoutes(s.routes)
@@ -195,6 +277,11 @@ func (s *apiServer) handleRouteTopicGet(w http.ResponseWriter, r *http.Request)
 func (s *apiServer) handleRouteTopicPost(w http.ResponseWriter, r *http.Request) {
 	p := strings.TrimPrefix(r.URL.Path, topicsBasePathAnchored)
 	topic := s.topicIDFromPath(p)
+	if pathMatch(eventAckPattern, p) {
+		event := s.eventIDFromPath(path.Dir(p))
+		s.handleAckEvent(topic, event, w, r)
+		return
+	}
 	s.handleCreateHandler(topic, w, r)
 }
 
@@ -273,6 +360,9 @@ func (s *apiServer) convertEventStateToClient(state alert.EventState) client.Eve
 		Time:     state.Time,
 		Duration: client.Duration(state.Duration),
 		Level:    state.Level.String(),
+		Acked:    state.Acked(),
+		AckedBy:  state.AckedBy,
+		AckedAt:  state.AckedAt,
 	}
 }
 
@@ -508,3 +598,351 @@ func (s *apiServer) handleGetHandler(topic, handler string, w http.ResponseWrite
 	w.WriteHeader(http.StatusOK)
 	w.Write(httpd.MarshalJSON(h, true))
 }
+
+func (s *apiServer) handleAckEvent(topic, eventID string, w http.ResponseWriter, r *http.Request) {
+	opt := client.TopicEventAckOptions{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&opt); err != nil && err != io.EOF {
+			httpd.HttpError(w, fmt.Sprint("invalid ack json: ", err.Error()), true, http.StatusBadRequest)
+			return
+		}
+	}
+	state, ok, err := s.Acknowledger.AckEvent(topic, eventID, opt.Author)
+	if err != nil {
+		httpd.HttpError(w, fmt.Sprintf("failed to ack event: %s", err.Error()), true, http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		httpd.HttpError(w, fmt.Sprintf("unknown event %q in topic %q", eventID, topic), true, http.StatusNotFound)
+		return
+	}
+	event := client.TopicEvent{
+		Link:  s.topicEventLink(topic, eventID),
+		ID:    eventID,
+		State: s.convertEventStateToClient(state),
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpd.MarshalJSON(event, true))
+}
+
+func (s *apiServer) silenceLink(id string) client.Link {
+	return client.Link{Relation: client.Self, Href: path.Join(silencesBasePath, id)}
+}
+
+func convertMatchersToClient(matchers []Matcher) []client.TagMatcher {
+	cm := make([]client.TagMatcher, len(matchers))
+	for i, m := range matchers {
+		cm[i] = client.TagMatcher{
+			Name:  m.Name,
+			Value: m.Value,
+			Regex: m.Regex,
+		}
+	}
+	return cm
+}
+
+func convertMatchersFromClient(matchers []client.TagMatcher) []Matcher {
+	ms := make([]Matcher, len(matchers))
+	for i, m := range matchers {
+		ms[i] = Matcher{
+			Name:  m.Name,
+			Value: m.Value,
+			Regex: m.Regex,
+		}
+	}
+	return ms
+}
+
+func (s *apiServer) convertSilence(sil Silence) client.Silence {
+	return client.Silence{
+		Link:      s.silenceLink(sil.ID),
+		ID:        sil.ID,
+		Topic:     sil.Topic,
+		Matchers:  convertMatchersToClient(sil.Matchers),
+		Author:    sil.Author,
+		Comment:   sil.Comment,
+		CreatedAt: sil.CreatedAt,
+		ExpiresAt: sil.ExpiresAt,
+	}
+}
+
+type sortedSilences []client.Silence
+
+func (s sortedSilences) Len() int               { return len(s) }
+func (s sortedSilences) Less(i int, j int) bool { return s[i].ID < s[j].ID }
+func (s sortedSilences) Swap(i int, j int)      { s[i], s[j] = s[j], s[i] }
+
+func (s *apiServer) handleListSilences(w http.ResponseWriter, r *http.Request) {
+	pattern := r.URL.Query().Get("pattern")
+	if err := validatePattern(pattern); err != nil {
+		httpd.HttpError(w, fmt.Sprint("invalid pattern: ", err.Error()), true, http.StatusBadRequest)
+		return
+	}
+	silences, err := s.Silencer.Silences(pattern)
+	if err != nil {
+		httpd.HttpError(w, fmt.Sprint("failed to get silences: ", err.Error()), true, http.StatusInternalServerError)
+		return
+	}
+	list := make([]client.Silence, len(silences))
+	for i, sil := range silences {
+		list[i] = s.convertSilence(sil)
+	}
+	sort.Sort(sortedSilences(list))
+	res := client.Silences{
+		Link:     client.Link{Relation: client.Self, Href: r.URL.String()},
+		Silences: list,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpd.MarshalJSON(res, true))
+}
+
+func (s *apiServer) handleCreateSilence(w http.ResponseWriter, r *http.Request) {
+	opt := client.SilenceOptions{}
+	if err := json.NewDecoder(r.Body).Decode(&opt); err != nil {
+		httpd.HttpError(w, fmt.Sprint("invalid silence json: ", err.Error()), true, http.StatusBadRequest)
+		return
+	}
+	sil := Silence{
+		ID:        opt.ID,
+		Topic:     opt.Topic,
+		Matchers:  convertMatchersFromClient(opt.Matchers),
+		Author:    opt.Author,
+		Comment:   opt.Comment,
+		ExpiresAt: opt.ExpiresAt,
+	}
+	if opt.ExpiresAt.IsZero() && opt.Duration > 0 {
+		sil.ExpiresAt = time.Now().UTC().Add(time.Duration(opt.Duration))
+	}
+	created, err := s.Silencer.CreateSilence(sil)
+	if err != nil {
+		httpd.HttpError(w, fmt.Sprint("failed to create silence: ", err.Error()), true, http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpd.MarshalJSON(s.convertSilence(created), true))
+}
+
+func (s *apiServer) handleGetSilence(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, silencesBasePathAnchored)
+	sil, ok, err := s.Silencer.Silence(id)
+	if err != nil {
+		httpd.HttpError(w, fmt.Sprintf("failed to get silence %q: %v", id, err), true, http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		httpd.HttpError(w, fmt.Sprintf("unknown silence: %q", id), true, http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpd.MarshalJSON(s.convertSilence(sil), true))
+}
+
+func (s *apiServer) handleDeleteSilence(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, silencesBasePathAnchored)
+	if err := s.Silencer.DeleteSilence(id); err != nil {
+		httpd.HttpError(w, fmt.Sprint("failed to delete silence: ", err.Error()), true, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *apiServer) handleAlertHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := HistoryQuery{
+		Topic: query.Get("topic"),
+		Event: query.Get("event"),
+	}
+	for _, pattern := range []string{q.Topic, q.Event} {
+		if err := validatePattern(pattern); err != nil {
+			httpd.HttpError(w, fmt.Sprint("invalid pattern: ", err.Error()), true, http.StatusBadRequest)
+			return
+		}
+	}
+	for _, tag := range query["tag"] {
+		kv := strings.SplitN(tag, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			httpd.HttpError(w, fmt.Sprintf("invalid tag %q, must be formatted as name=value", tag), true, http.StatusBadRequest)
+			return
+		}
+		if q.Tags == nil {
+			q.Tags = make(map[string]string)
+		}
+		q.Tags[kv[0]] = kv[1]
+	}
+	for param, t := range map[string]*time.Time{"start": &q.Start, "stop": &q.Stop} {
+		if v := query.Get(param); v != "" {
+			var err error
+			*t, err = time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				httpd.HttpError(w, fmt.Sprintf("invalid %s time: %s", param, err.Error()), true, http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			httpd.HttpError(w, fmt.Sprintf("invalid limit %q, must be a non negative integer", v), true, http.StatusBadRequest)
+			return
+		}
+		q.Limit = limit
+	}
+
+	entries, err := s.History.AlertHistory(q)
+	if err != nil {
+		httpd.HttpError(w, fmt.Sprint("failed to get alert history: ", err.Error()), true, http.StatusInternalServerError)
+		return
+	}
+	res := client.AlertHistory{
+		Link:    client.Link{Relation: client.Self, Href: r.URL.String()},
+		Entries: make([]client.AlertHistoryEntry, len(entries)),
+	}
+	for i, e := range entries {
+		res.Entries[i] = client.AlertHistoryEntry{
+			Topic:         e.Topic,
+			ID:            e.ID,
+			Time:          e.Time,
+			Level:         e.Level.String(),
+			PreviousLevel: e.PreviousLevel.String(),
+			Message:       e.Message,
+			Details:       e.Details,
+			Tags:          e.Tags,
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpd.MarshalJSON(res, true))
+}
+
+func (s *apiServer) inhibitionLink(id string) client.Link {
+	return client.Link{Relation: client.Self, Href: path.Join(inhibitionsBasePath, id)}
+}
+
+func (s *apiServer) convertInhibition(in Inhibition) client.Inhibition {
+	return client.Inhibition{
+		Link: s.inhibitionLink(in.ID),
+		ID:   in.ID,
+		Source: client.InhibitionMatch{
+			Category: in.Source.Category,
+			Matchers: convertMatchersToClient(in.Source.Matchers),
+		},
+		Target: client.InhibitionMatch{
+			Category: in.Target.Category,
+			Matchers: convertMatchersToClient(in.Target.Matchers),
+		},
+		Equal: in.Equal,
+	}
+}
+
+func convertInhibitionOptions(opt client.InhibitionOptions) Inhibition {
+	return Inhibition{
+		ID: opt.ID,
+		Source: InhibitionMatch{
+			Category: opt.Source.Category,
+			Matchers: convertMatchersFromClient(opt.Source.Matchers),
+		},
+		Target: InhibitionMatch{
+			Category: opt.Target.Category,
+			Matchers: convertMatchersFromClient(opt.Target.Matchers),
+		},
+		Equal: opt.Equal,
+	}
+}
+
+type sortedInhibitions []client.Inhibition
+
+func (s sortedInhibitions) Len() int               { return len(s) }
+func (s sortedInhibitions) Less(i int, j int) bool { return s[i].ID < s[j].ID }
+func (s sortedInhibitions) Swap(i int, j int)      { s[i], s[j] = s[j], s[i] }
+
+func (s *apiServer) handleListInhibitions(w http.ResponseWriter, r *http.Request) {
+	pattern := r.URL.Query().Get("pattern")
+	if err := validatePattern(pattern); err != nil {
+		httpd.HttpError(w, fmt.Sprint("invalid pattern: ", err.Error()), true, http.StatusBadRequest)
+		return
+	}
+	inhibitions, err := s.Inhibitor.Inhibitions(pattern)
+	if err != nil {
+		httpd.HttpError(w, fmt.Sprint("failed to get inhibitions: ", err.Error()), true, http.StatusInternalServerError)
+		return
+	}
+	list := make([]client.Inhibition, len(inhibitions))
+	for i, in := range inhibitions {
+		list[i] = s.convertInhibition(in)
+	}
+	sort.Sort(sortedInhibitions(list))
+	res := client.Inhibitions{
+		Link:        client.Link{Relation: client.Self, Href: r.URL.String()},
+		Inhibitions: list,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpd.MarshalJSON(res, true))
+}
+
+func (s *apiServer) handleCreateInhibition(w http.ResponseWriter, r *http.Request) {
+	opt := client.InhibitionOptions{}
+	if err := json.NewDecoder(r.Body).Decode(&opt); err != nil {
+		httpd.HttpError(w, fmt.Sprint("invalid inhibition json: ", err.Error()), true, http.StatusBadRequest)
+		return
+	}
+	in := convertInhibitionOptions(opt)
+	if err := s.Inhibitor.CreateInhibition(in); err != nil {
+		httpd.HttpError(w, fmt.Sprint("failed to create inhibition: ", err.Error()), true, http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpd.MarshalJSON(s.convertInhibition(in), true))
+}
+
+func (s *apiServer) handleGetInhibition(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, inhibitionsBasePathAnchored)
+	in, ok, err := s.Inhibitor.Inhibition(id)
+	if err != nil {
+		httpd.HttpError(w, fmt.Sprintf("failed to get inhibition %q: %v", id, err), true, http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		httpd.HttpError(w, fmt.Sprintf("unknown inhibition: %q", id), true, http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpd.MarshalJSON(s.convertInhibition(in), true))
+}
+
+func (s *apiServer) handleReplaceInhibition(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, inhibitionsBasePathAnchored)
+	opt := client.InhibitionOptions{}
+	if err := json.NewDecoder(r.Body).Decode(&opt); err != nil {
+		httpd.HttpError(w, fmt.Sprint("invalid inhibition json: ", err.Error()), true, http.StatusBadRequest)
+		return
+	}
+	if opt.ID == "" {
+		opt.ID = id
+	}
+	if opt.ID != id {
+		httpd.HttpError(w, fmt.Sprintf("cannot change the ID of inhibition %q", id), true, http.StatusBadRequest)
+		return
+	}
+	in := convertInhibitionOptions(opt)
+	if _, ok, err := s.Inhibitor.Inhibition(id); err != nil {
+		httpd.HttpError(w, fmt.Sprintf("failed to get inhibition %q: %v", id, err), true, http.StatusInternalServerError)
+		return
+	} else if !ok {
+		httpd.HttpError(w, fmt.Sprintf("unknown inhibition: %q", id), true, http.StatusNotFound)
+		return
+	}
+	if err := s.Inhibitor.ReplaceInhibition(in); err != nil {
+		httpd.HttpError(w, fmt.Sprint("failed to replace inhibition: ", err.Error()), true, http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpd.MarshalJSON(s.convertInhibition(in), true))
+}
+
+func (s *apiServer) handleDeleteInhibition(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, inhibitionsBasePathAnchored)
+	if err := s.Inhibitor.DeleteInhibition(id); err != nil {
+		httpd.HttpError(w, fmt.Sprint("failed to delete inhibition: ", err.Error()), true, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,6 +1,7 @@
 package alert
 
 import (
+	"errors"
 	"time"
 
 	"github.com/influxdata/influxdb/toml"
@@ -8,22 +9,39 @@ import (
 )
 
 const (
-	DefaultShutdownTimeout = toml.Duration(time.Second * 10)
+	DefaultShutdownTimeout   = toml.Duration(time.Second * 10)
+	DefaultHistoryRetention  = toml.Duration(7 * 24 * time.Hour)
+	DefaultHistoryMaxEntries = 10000
 )
 
 type Config struct {
 	// Whether we persist the alert topics to BoltDB or not
 	PersistTopics     bool `toml:"persist-topics"`
 	TopicBufferLength int  `toml:"topic-buffer-length"`
+	// Whether we record the level changes of the events in the alert history.
+	// Default: false
+	RecordHistory bool `toml:"record-history"`
+	// How long the alert history is kept, 0 keeps it forever.
+	HistoryRetention toml.Duration `toml:"history-retention"`
+	// The maximum number of history entries kept per topic, 0 for no limit.
+	HistoryMaxEntries int `toml:"history-max-entries"`
 }
 
 func NewConfig() Config {
 	return Config{
 		PersistTopics:     true,
 		TopicBufferLength: alert.DefaultEventBufferSize,
+		HistoryRetention:  DefaultHistoryRetention,
+		HistoryMaxEntries: DefaultHistoryMaxEntries,
 	}
 }
 
 func (c Config) Validate() error {
+	if c.HistoryRetention < 0 {
+		return errors.New("history-retention must not be negative")
+	}
+	if c.HistoryMaxEntries < 0 {
+		return errors.New("history-max-entries must not be negative")
+	}
 	return nil
 }
@@ -240,6 +240,11 @@ type EventState struct {
 	Time     time.Time     `json:"time,omitempty"`
 	Duration time.Duration `json:"duration,omitempty"`
 	Level    alert.Level   `json:"level"`
+	AckedBy  string        `json:"acked-by,omitempty"`
+	AckedAt  *time.Time    `json:"acked-at,omitempty"`
+	// Category and tags of the event, used to restore the sources of the inhibitions.
+	Category string            `json:"category,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
 }
 
 func (e *EventState) Reset() {
@@ -248,17 +253,26 @@ func (e *EventState) Reset() {
 	e.Time = time.Time{}
 	e.Duration = 0
 	e.Level = 0
+	e.AckedBy = ""
+	e.AckedAt = nil
+	e.Category = ""
+	e.Tags = nil
 }
 
 func (e *EventState) AlertEventState(id string) *alert.EventState {
-	return &alert.EventState{
+	state := &alert.EventState{
 		ID:       id,
 		Message:  e.Message,
 		Details:  e.Details,
 		Time:     e.Time,
 		Duration: e.Duration,
 		Level:    e.Level,
+		AckedBy:  e.AckedBy,
 	}
+	if e.AckedAt != nil {
+		state.AckedAt = *e.AckedAt
+	}
+	return state
 }
 
 func (t TopicState) ObjectID() string {
@@ -374,3 +388,357 @@ func (kv *topicStateKV) DeleteMultiple(keys []string) error {
 	}
 	return nil
 }
+
+var (
+	ErrSilenceExists   = errors.New("silence already exists")
+	ErrNoSilenceExists = errors.New("no silence exists")
+)
+
+// Data access object for Silence data.
+type SilenceDAO interface {
+	// Retrieve a silence
+	Get(id string) (Silence, error)
+
+	// Create a silence.
+	// ErrSilenceExists is returned if a silence already exists with the same ID.
+	Create(s Silence) error
+
+	// Delete a silence.
+	// It is not an error to delete an non-existent silence.
+	Delete(id string) error
+
+	// List silences matching a pattern.
+	// The pattern is shell/glob matching see https://golang.org/pkg/path/#Match
+	// Offset and limit are pagination bounds. Offset is inclusive starting at index 0.
+	// More results may exist while the number of returned items is equal to limit.
+	List(pattern string, offset, limit int) ([]Silence, error)
+
+	Rebuild() error
+}
+
+const (
+	silenceVersion1 = 1
+)
+
+// Silence prevents the handlers of the matching topics from handling the matching events until it expires.
+type Silence struct {
+	ID string `json:"id"`
+	// Topic is a glob pattern matching the topics of the events, empty matches every topic.
+	Topic string `json:"topic"`
+	// Matchers on the tags of the events, all of which must match.
+	Matchers  []Matcher `json:"matchers"`
+	Author    string    `json:"author"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created-at"`
+	ExpiresAt time.Time `json:"expires-at"`
+}
+
+// Matcher matches the value of a tag, either exactly or with a regular expression.
+type Matcher struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Regex bool   `json:"regex"`
+}
+
+func (s Silence) Validate() error {
+	if !validHandlerID.MatchString(s.ID) {
+		return fmt.Errorf("silence ID must contain only letters, numbers, '-', '.' and '_'. %q", s.ID)
+	}
+	if err := validatePattern(s.Topic); err != nil {
+		return errors.Wrapf(err, "invalid silence topic %q", s.Topic)
+	}
+	if s.Author == "" {
+		return errors.New("silence author must not be empty")
+	}
+	if s.ExpiresAt.IsZero() {
+		return errors.New("silence must have an expiry")
+	}
+	return errors.Wrap(validateMatchers(s.Matchers), "invalid silence")
+}
+
+func validateMatchers(matchers []Matcher) error {
+	for _, m := range matchers {
+		if m.Name == "" {
+			return errors.New("matcher name must not be empty")
+		}
+		if m.Regex {
+			if _, err := regexp.Compile(m.Value); err != nil {
+				return errors.Wrapf(err, "invalid regex for matcher %q", m.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (s Silence) ObjectID() string {
+	return s.ID
+}
+
+func (s Silence) MarshalBinary() ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid silence")
+	}
+	return storage.VersionJSONEncode(silenceVersion1, s)
+}
+
+func (s *Silence) UnmarshalBinary(data []byte) error {
+	return storage.VersionJSONDecode(data, func(version int, dec *json.Decoder) error {
+		switch version {
+		case silenceVersion1:
+			return dec.Decode(s)
+		default:
+			return fmt.Errorf("unknown silence version %d: cannot decode", version)
+		}
+	})
+}
+
+// Key/Value store based implementation of the SilenceDAO
+type silenceKV struct {
+	store *storage.IndexedStore
+}
+
+const (
+	silencePrefix = "silences"
+)
+
+func newSilenceKV(store storage.Interface) (*silenceKV, error) {
+	c := storage.DefaultIndexedStoreConfig(silencePrefix, func() storage.BinaryObject {
+		return new(Silence)
+	})
+	istore, err := storage.NewIndexedStore(store, c)
+	if err != nil {
+		return nil, err
+	}
+	return &silenceKV{
+		store: istore,
+	}, nil
+}
+
+func (kv *silenceKV) error(err error) error {
+	if err == storage.ErrObjectExists {
+		return ErrSilenceExists
+	} else if err == storage.ErrNoObjectExists {
+		return ErrNoSilenceExists
+	}
+	return err
+}
+
+func (kv *silenceKV) Get(id string) (Silence, error) {
+	o, err := kv.store.Get(id)
+	if err != nil {
+		return Silence{}, kv.error(err)
+	}
+	s, ok := o.(*Silence)
+	if !ok {
+		return Silence{}, storage.ImpossibleTypeErr(s, o)
+	}
+	return *s, nil
+}
+
+func (kv *silenceKV) Create(s Silence) error {
+	return kv.error(kv.store.Create(&s))
+}
+
+func (kv *silenceKV) Delete(id string) error {
+	return kv.error(kv.store.Delete(id))
+}
+
+func (kv *silenceKV) List(pattern string, offset, limit int) ([]Silence, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+	objects, err := kv.store.List(storage.DefaultIDIndex, pattern, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	silences := make([]Silence, len(objects))
+	for i, o := range objects {
+		s, ok := o.(*Silence)
+		if !ok {
+			return nil, storage.ImpossibleTypeErr(s, o)
+		}
+		silences[i] = *s
+	}
+	return silences, nil
+}
+
+func (kv *silenceKV) Rebuild() error {
+	return kv.store.Rebuild()
+}
+
+var (
+	ErrInhibitionExists   = errors.New("inhibition already exists")
+	ErrNoInhibitionExists = errors.New("no inhibition exists")
+)
+
+// Data access object for Inhibition data.
+type InhibitionDAO interface {
+	// Retrieve an inhibition
+	Get(id string) (Inhibition, error)
+
+	// Create an inhibition.
+	// ErrInhibitionExists is returned if an inhibition already exists with the same ID.
+	Create(i Inhibition) error
+
+	// Replace an existing inhibition.
+	// ErrNoInhibitionExists is returned if the inhibition does not exist.
+	Replace(i Inhibition) error
+
+	// Delete an inhibition.
+	// It is not an error to delete an non-existent inhibition.
+	Delete(id string) error
+
+	// List inhibitions matching a pattern.
+	// The pattern is shell/glob matching see https://golang.org/pkg/path/#Match
+	// Offset and limit are pagination bounds. Offset is inclusive starting at index 0.
+	// More results may exist while the number of returned items is equal to limit.
+	List(pattern string, offset, limit int) ([]Inhibition, error)
+
+	Rebuild() error
+}
+
+const (
+	inhibitionVersion1 = 1
+)
+
+// Inhibition prevents the alerts matching the target from being triggered
+// while an alert matching the source is not OK.
+type Inhibition struct {
+	ID     string          `json:"id"`
+	Source InhibitionMatch `json:"source"`
+	Target InhibitionMatch `json:"target"`
+	// Equal is the tags that must have the same values in the source and target alerts.
+	Equal []string `json:"equal"`
+}
+
+// InhibitionMatch selects alerts by category and tags.
+type InhibitionMatch struct {
+	// Category of the alerts, empty matches every category.
+	Category string `json:"category"`
+	// Matchers on the tags of the alerts, all of which must match.
+	Matchers []Matcher `json:"matchers"`
+}
+
+func (i Inhibition) Validate() error {
+	if !validHandlerID.MatchString(i.ID) {
+		return fmt.Errorf("inhibition ID must contain only letters, numbers, '-', '.' and '_'. %q", i.ID)
+	}
+	if i.Source.Category == "" && len(i.Source.Matchers) == 0 {
+		return errors.New("inhibition source must have a category or matchers")
+	}
+	if i.Target.Category == "" && len(i.Target.Matchers) == 0 {
+		return errors.New("inhibition target must have a category or matchers")
+	}
+	if err := validateMatchers(i.Source.Matchers); err != nil {
+		return errors.Wrap(err, "invalid inhibition source")
+	}
+	if err := validateMatchers(i.Target.Matchers); err != nil {
+		return errors.Wrap(err, "invalid inhibition target")
+	}
+	for _, tag := range i.Equal {
+		if tag == "" {
+			return errors.New("inhibition equal tags must not be empty")
+		}
+	}
+	return nil
+}
+
+func (i Inhibition) ObjectID() string {
+	return i.ID
+}
+
+func (i Inhibition) MarshalBinary() ([]byte, error) {
+	if err := i.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid inhibition")
+	}
+	return storage.VersionJSONEncode(inhibitionVersion1, i)
+}
+
+func (i *Inhibition) UnmarshalBinary(data []byte) error {
+	return storage.VersionJSONDecode(data, func(version int, dec *json.Decoder) error {
+		switch version {
+		case inhibitionVersion1:
+			return dec.Decode(i)
+		default:
+			return fmt.Errorf("unknown inhibition version %d: cannot decode", version)
+		}
+	})
+}
+
+// Key/Value store based implementation of the InhibitionDAO
+type inhibitionKV struct {
+	store *storage.IndexedStore
+}
+
+const (
+	inhibitionPrefix = "inhibitions"
+)
+
+func newInhibitionKV(store storage.Interface) (*inhibitionKV, error) {
+	c := storage.DefaultIndexedStoreConfig(inhibitionPrefix, func() storage.BinaryObject {
+		return new(Inhibition)
+	})
+	istore, err := storage.NewIndexedStore(store, c)
+	if err != nil {
+		return nil, err
+	}
+	return &inhibitionKV{
+		store: istore,
+	}, nil
+}
+
+func (kv *inhibitionKV) error(err error) error {
+	if err == storage.ErrObjectExists {
+		return ErrInhibitionExists
+	} else if err == storage.ErrNoObjectExists {
+		return ErrNoInhibitionExists
+	}
+	return err
+}
+
+func (kv *inhibitionKV) Get(id string) (Inhibition, error) {
+	o, err := kv.store.Get(id)
+	if err != nil {
+		return Inhibition{}, kv.error(err)
+	}
+	i, ok := o.(*Inhibition)
+	if !ok {
+		return Inhibition{}, storage.ImpossibleTypeErr(i, o)
+	}
+	return *i, nil
+}
+
+func (kv *inhibitionKV) Create(i Inhibition) error {
+	return kv.error(kv.store.Create(&i))
+}
+
+func (kv *inhibitionKV) Replace(i Inhibition) error {
+	return kv.error(kv.store.Replace(&i))
+}
+
+func (kv *inhibitionKV) Delete(id string) error {
+	return kv.error(kv.store.Delete(id))
+}
+
+func (kv *inhibitionKV) List(pattern string, offset, limit int) ([]Inhibition, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+	objects, err := kv.store.List(storage.DefaultIDIndex, pattern, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	inhibitions := make([]Inhibition, len(objects))
+	for idx, o := range objects {
+		i, ok := o.(*Inhibition)
+		if !ok {
+			return nil, storage.ImpossibleTypeErr(i, o)
+		}
+		inhibitions[idx] = *i
+	}
+	return inhibitions, nil
+}
+
+func (kv *inhibitionKV) Rebuild() error {
+	return kv.store.Rebuild()
+}
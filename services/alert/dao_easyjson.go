@@ -154,6 +154,42 @@ func easyjson7be57abeDecodeGithubComInfluxdataKapacitorServicesAlert1(in *jlexer
 			if data := in.UnsafeBytes(); in.Ok() {
 				in.AddError((out.Level).UnmarshalText(data))
 			}
+		case "acked-by":
+			out.AckedBy = string(in.String())
+		case "acked-at":
+			if in.IsNull() {
+				in.Skip()
+				out.AckedAt = nil
+			} else {
+				if out.AckedAt == nil {
+					out.AckedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.AckedAt).UnmarshalJSON(data))
+				}
+			}
+		case "category":
+			out.Category = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Tags = make(map[string]string)
+				} else {
+					out.Tags = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v3 string
+					v3 = string(in.String())
+					(out.Tags)[key] = v3
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -214,6 +250,40 @@ func easyjson7be57abeEncodeGithubComInfluxdataKapacitorServicesAlert1(out *jwrit
 		}
 		out.RawText((in.Level).MarshalText())
 	}
+	if in.AckedBy != "" {
+		const prefix string = ",\"acked-by\":"
+		out.RawString(prefix)
+		out.String(string(in.AckedBy))
+	}
+	if in.AckedAt != nil {
+		const prefix string = ",\"acked-at\":"
+		out.RawString(prefix)
+		out.Raw((*in.AckedAt).MarshalJSON())
+	}
+	if in.Category != "" {
+		const prefix string = ",\"category\":"
+		out.RawString(prefix)
+		out.String(string(in.Category))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v4First := true
+			for v4Name, v4Value := range in.Tags {
+				if v4First {
+					v4First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v4Name))
+				out.RawByte(':')
+				out.String(string(v4Value))
+			}
+			out.RawByte('}')
+		}
+	}
 	out.RawByte('}')
 }
 
@@ -7,11 +7,13 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	text "text/template"
 	"time"
 
+	"github.com/influxdata/influxdb/toml"
 	"github.com/influxdata/kapacitor/alert"
 	"github.com/influxdata/kapacitor/bufpool"
 	"github.com/influxdata/kapacitor/command"
@@ -332,6 +334,655 @@ func (h *publishHandler) Handle(event alert.Event) {
 	}
 }
 
+// EscalationStepConfig is a step of an escalation.
+// The handler of the step is called once the event has lasted for the delay,
+// as long as it is at least at the level of the step and has not been acknowledged.
+type EscalationStepConfig struct {
+	// Delay after the start of the event before the step is reached.
+	Delay toml.Duration `mapstructure:"delay"`
+	// Minimum level of the event for the step to be reached.
+	Level alert.Level `mapstructure:"level"`
+	// Kind and options of the handler of the step.
+	Kind    string                 `mapstructure:"kind"`
+	Options map[string]interface{} `mapstructure:"options"`
+}
+
+type EscalationHandlerConfig struct {
+	// Ordered steps of the escalation.
+	Steps []EscalationStepConfig `mapstructure:"steps"`
+	// Interval at which the pending steps are checked.
+	Interval toml.Duration `mapstructure:"interval"`
+	// Whether acknowledging an event stops its escalation.
+	StopOnAck bool `mapstructure:"stop-on-ack"`
+
+	topic  string
+	topics Topics
+}
+
+func newDefaultEscalationHandlerConfig(topic string, topics Topics) EscalationHandlerConfig {
+	return EscalationHandlerConfig{
+		Interval:  toml.Duration(10 * time.Second),
+		StopOnAck: true,
+		topic:     topic,
+		topics:    topics,
+	}
+}
+
+func (c EscalationHandlerConfig) Validate() error {
+	if len(c.Steps) == 0 {
+		return errors.New("escalation must have at least one step")
+	}
+	if c.Interval <= 0 {
+		return errors.New("escalation interval must be positive")
+	}
+	for i, s := range c.Steps {
+		if s.Kind == "" {
+			return fmt.Errorf("escalation step %d must have a kind", i)
+		}
+		if s.Delay < 0 {
+			return fmt.Errorf("delay of escalation step %d must not be negative", i)
+		}
+		if i > 0 && s.Delay < c.Steps[i-1].Delay {
+			return fmt.Errorf("escalation steps must be ordered by delay, step %d is before step %d", i, i-1)
+		}
+	}
+	return nil
+}
+
+type escalationStep struct {
+	delay time.Duration
+	level alert.Level
+	h     alert.Handler
+}
+
+// escalationNotification is an event to pass to the handler of a step.
+type escalationNotification struct {
+	h     alert.Handler
+	event alert.Event
+}
+
+// escalation is the progress of a single event through the steps.
+type escalation struct {
+	event alert.Event
+	// reached records which steps have been reached.
+	reached []bool
+}
+
+// escalationHandler calls the handlers of its steps as the events last.
+// The progress is derived from the event states of the topic,
+// so that the escalations are resumed after a restart.
+// The handlers of the steps are called in order by the run goroutine,
+// so that a slow step does not block the handling of the events.
+type escalationHandler struct {
+	topic     string
+	topics    Topics
+	steps     []escalationStep
+	interval  time.Duration
+	stopOnAck bool
+
+	diag HandlerDiagnostic
+
+	mu          sync.Mutex
+	escalations map[string]*escalation
+	restored    bool
+	// pending is the notifications of the steps that have not been delivered yet.
+	pending []escalationNotification
+
+	// deliverMu serializes the deliveries, so that the steps get the events in order.
+	deliverMu sync.Mutex
+	notify    chan struct{}
+
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewEscalationHandler creates an escalation handler calling the given handlers of the configured steps.
+func NewEscalationHandler(c EscalationHandlerConfig, handlers []alert.Handler, d HandlerDiagnostic) (alert.Handler, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if len(handlers) != len(c.Steps) {
+		return nil, fmt.Errorf("expected %d escalation step handlers, got %d", len(c.Steps), len(handlers))
+	}
+	steps := make([]escalationStep, len(c.Steps))
+	for i, s := range c.Steps {
+		steps[i] = escalationStep{
+			delay: time.Duration(s.Delay),
+			level: s.Level,
+			h:     handlers[i],
+		}
+	}
+	h := &escalationHandler{
+		topic:       c.topic,
+		topics:      c.topics,
+		steps:       steps,
+		interval:    time.Duration(c.Interval),
+		stopOnAck:   c.StopOnAck,
+		diag:        d,
+		escalations: make(map[string]*escalation),
+		notify:      make(chan struct{}, 1),
+		closing:     make(chan struct{}),
+	}
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.run()
+	}()
+	return h, nil
+}
+
+func (h *escalationHandler) run() {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.closing:
+			return
+		case now := <-ticker.C:
+			h.mu.Lock()
+			h.escalate(now)
+			h.mu.Unlock()
+			h.deliver()
+		case <-h.notify:
+			h.deliver()
+		}
+	}
+}
+
+// deliver passes the pending notifications to the handlers of the steps.
+func (h *escalationHandler) deliver() {
+	h.deliverMu.Lock()
+	defer h.deliverMu.Unlock()
+	h.mu.Lock()
+	pending := h.pending
+	h.pending = nil
+	h.mu.Unlock()
+	for _, n := range pending {
+		n.h.Handle(n.event)
+	}
+}
+
+// Handle passes the event to the steps that have been reached,
+// and starts the escalation of new events.
+func (h *escalationHandler) Handle(event alert.Event) {
+	h.mu.Lock()
+	h.handle(event)
+	h.mu.Unlock()
+	select {
+	case h.notify <- struct{}{}:
+	default:
+		// A delivery is already pending.
+	}
+}
+
+// handle queues the notifications of the event.
+// Caller must have the lock.
+func (h *escalationHandler) handle(event alert.Event) {
+	h.restore()
+
+	e, ok := h.escalations[event.State.ID]
+	if event.State.Level == alert.OK {
+		if ok {
+			// Notify the reached steps of the recovery
+			h.handleReached(e, event)
+			delete(h.escalations, event.State.ID)
+		}
+		return
+	}
+	if !ok || !escalationStart(e.event.State).Equal(escalationStart(event.State)) {
+		// The event started a new escalation
+		e = &escalation{
+			reached: make([]bool, len(h.steps)),
+		}
+		h.escalations[event.State.ID] = e
+	}
+	e.event = event
+	h.handleReached(e, event)
+	h.escalate(time.Now())
+}
+
+// Caller must have the lock.
+func (h *escalationHandler) handleReached(e *escalation, event alert.Event) {
+	for i, s := range h.steps {
+		if e.reached[i] {
+			h.pending = append(h.pending, escalationNotification{h: s.h, event: event})
+		}
+	}
+}
+
+// restore recreates the escalations of the events of the topic the first time it is called.
+// The steps that were due before the last update of an event are considered reached,
+// since the handler was already escalating the event at that time.
+// Caller must have the lock.
+func (h *escalationHandler) restore() {
+	if h.restored {
+		return
+	}
+	h.restored = true
+	states, err := h.topics.EventStates(h.topic, alert.Info)
+	if err != nil {
+		// The topic does not exist yet, there is nothing to restore.
+		return
+	}
+	for id, state := range states {
+		e := &escalation{
+			event: alert.Event{
+				Topic: h.topic,
+				State: state,
+			},
+			reached: make([]bool, len(h.steps)),
+		}
+		for i, s := range h.steps {
+			e.reached[i] = s.delay <= state.Duration && state.Level >= s.level
+		}
+		h.escalations[id] = e
+	}
+}
+
+// escalate queues the notifications of the steps that are due at now.
+// Silenced events are not escalated, their steps are reached once the silence ends.
+// Caller must have the lock.
+func (h *escalationHandler) escalate(now time.Time) {
+	h.restore()
+	for id, e := range h.escalations {
+		state, ok, err := h.topics.EventState(h.topic, id)
+		if err != nil {
+			h.diag.Error("failed to get event state", err, keyvalue.KV("event", id))
+			continue
+		}
+		if !ok {
+			delete(h.escalations, id)
+			continue
+		}
+		if state.Level == alert.OK || (h.stopOnAck && state.Acked()) {
+			// The recovery is handled once the event is passed to the handler.
+			continue
+		}
+		event := e.event
+		event.State = state
+		if h.topics.IsSilenced(event) {
+			continue
+		}
+		start := escalationStart(state)
+		for i, s := range h.steps {
+			if e.reached[i] || state.Level < s.level || now.Before(start.Add(s.delay)) {
+				continue
+			}
+			e.reached[i] = true
+			h.pending = append(h.pending, escalationNotification{h: s.h, event: event})
+		}
+	}
+}
+
+// escalationStart returns the time at which the event left the OK level.
+func escalationStart(state alert.EventState) time.Time {
+	return state.Time.Add(-state.Duration)
+}
+
+func (h *escalationHandler) Close() {
+	close(h.closing)
+	h.wg.Wait()
+	h.deliver()
+	for _, s := range h.steps {
+		if c, ok := s.h.(closer); ok {
+			c.Close()
+		}
+	}
+}
+
+type GroupHandlerConfig struct {
+	// Tags by which the events are grouped.
+	// All events are in the same group if empty.
+	GroupBy []string `mapstructure:"group-by"`
+	// Duration to wait for more events before the first notification of a new group.
+	GroupWait toml.Duration `mapstructure:"group-wait"`
+	// Duration to wait before notifying changes to a group that was already notified.
+	GroupInterval toml.Duration `mapstructure:"group-interval"`
+	// Duration to wait before notifying a group again when nothing changed.
+	RepeatInterval toml.Duration `mapstructure:"repeat-interval"`
+	// Template of the message of the notifications.
+	Message string `mapstructure:"message"`
+	// Kind and options of the handler of the notifications.
+	Kind    string                 `mapstructure:"kind"`
+	Options map[string]interface{} `mapstructure:"options"`
+
+	id string
+}
+
+type groupMessageData struct {
+	// Key is the values of the group tags, formatted as tag=value pairs.
+	Key string
+	// Tags is the values of the group tags.
+	Tags     map[string]string
+	Firing   []alert.EventState
+	Resolved []alert.EventState
+}
+
+func newDefaultGroupHandlerConfig(id string) GroupHandlerConfig {
+	return GroupHandlerConfig{
+		GroupWait:      toml.Duration(30 * time.Second),
+		GroupInterval:  toml.Duration(5 * time.Minute),
+		RepeatInterval: toml.Duration(4 * time.Hour),
+		Message:        "{{ len .Firing }} firing and {{ len .Resolved }} resolved alerts{{ with .Key }} for {{ . }}{{ end }}.",
+		id:             id,
+	}
+}
+
+func (c GroupHandlerConfig) Validate() error {
+	if c.Kind == "" {
+		return errors.New("group must have a handler kind")
+	}
+	if c.GroupWait < 0 {
+		return errors.New("group-wait must not be negative")
+	}
+	if c.GroupInterval <= 0 {
+		return errors.New("group-interval must be positive")
+	}
+	if c.RepeatInterval <= 0 {
+		return errors.New("repeat-interval must be positive")
+	}
+	for _, t := range c.GroupBy {
+		if t == "" {
+			return errors.New("group-by tags must not be empty")
+		}
+	}
+	return nil
+}
+
+// eventGroup is the pending events of a group.
+type eventGroup struct {
+	key  string
+	tags map[string]string
+	// events is the latest event of each firing event, and the resolved events that were not notified yet.
+	events map[string]alert.Event
+	// changed is whether events started, changed level or resolved since the last notification.
+	changed bool
+	// last is the time of the last notification, zero until the group is notified.
+	last time.Time
+	// next is the time of the next notification.
+	next time.Time
+}
+
+// groupHandler groups the events by tags, and passes a single notification
+// listing the firing and resolved events of each group to its handler.
+type groupHandler struct {
+	id             string
+	groupBy        []string
+	groupWait      time.Duration
+	groupInterval  time.Duration
+	repeatInterval time.Duration
+	h              alert.Handler
+
+	messageTmpl *text.Template
+
+	groups map[string]*eventGroup
+
+	diag   HandlerDiagnostic
+	events chan alert.Event
+	// notify passes the notifications to the handler,
+	// so that a slow handler does not block the grouping of new events.
+	notify  chan alert.Event
+	closing chan struct{}
+
+	wg sync.WaitGroup
+}
+
+// NewGroupHandler creates a group handler passing the notifications to the given handler.
+func NewGroupHandler(c GroupHandlerConfig, h alert.Handler, d HandlerDiagnostic) (alert.Handler, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	// Parse and validate message template
+	tmpl, err := text.New("message").Parse(c.Message)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	md := groupMessageData{}
+	err = tmpl.Execute(&buf, md)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate message template with group message data")
+	}
+
+	g := &groupHandler{
+		id:             c.id,
+		groupBy:        c.GroupBy,
+		groupWait:      time.Duration(c.GroupWait),
+		groupInterval:  time.Duration(c.GroupInterval),
+		repeatInterval: time.Duration(c.RepeatInterval),
+		h:              h,
+		messageTmpl:    tmpl,
+		groups:         make(map[string]*eventGroup),
+		diag:           d,
+		events:         make(chan alert.Event),
+		notify:         make(chan alert.Event),
+		closing:        make(chan struct{}),
+	}
+	g.wg.Add(2)
+	go func() {
+		defer g.wg.Done()
+		g.run()
+	}()
+	go func() {
+		defer g.wg.Done()
+		g.runNotify()
+	}()
+	return g, nil
+}
+
+func (h *groupHandler) run() {
+	var timer *time.Timer
+	var timerC <-chan time.Time
+	reset := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timerC = nil, nil
+		}
+		if next, ok := h.nextNotification(); ok {
+			timer = time.NewTimer(time.Until(next))
+			timerC = timer.C
+		}
+	}
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	// pending notifications are passed on as the handler is ready for them
+	var pending []alert.Event
+	for {
+		var notify chan<- alert.Event
+		var next alert.Event
+		if len(pending) > 0 {
+			notify = h.notify
+			next = pending[0]
+		}
+		select {
+		case <-h.closing:
+			return
+		case e := <-h.events:
+			h.add(e, time.Now())
+			reset()
+		case now := <-timerC:
+			pending = append(pending, h.notifications(now)...)
+			reset()
+		case notify <- next:
+			pending = pending[1:]
+		}
+	}
+}
+
+// runNotify passes the notifications to the handler.
+func (h *groupHandler) runNotify() {
+	for {
+		select {
+		case <-h.closing:
+			return
+		case n := <-h.notify:
+			h.h.Handle(n)
+		}
+	}
+}
+
+func (h *groupHandler) Handle(event alert.Event) {
+	select {
+	case h.events <- event:
+	case <-h.closing:
+	}
+}
+
+// add adds the event to its group.
+func (h *groupHandler) add(event alert.Event, now time.Time) {
+	key, tags := h.groupKey(event)
+	g, ok := h.groups[key]
+	if !ok {
+		if event.State.Level == alert.OK {
+			// The event was not firing, there is nothing to resolve.
+			return
+		}
+		g = &eventGroup{
+			key:    key,
+			tags:   tags,
+			events: make(map[string]alert.Event),
+			next:   now.Add(h.groupWait),
+		}
+		h.groups[key] = g
+	}
+	prev, ok := g.events[event.State.ID]
+	if !ok && event.State.Level == alert.OK {
+		return
+	}
+	g.events[event.State.ID] = event
+	if ok && prev.State.Level == event.State.Level {
+		return
+	}
+	g.changed = true
+	if !g.last.IsZero() {
+		h.schedule(g)
+	}
+}
+
+// schedule sets the time of the next notification of a group that was already notified.
+// Changed groups are notified after the group interval, unchanged groups after the repeat interval.
+func (h *groupHandler) schedule(g *eventGroup) {
+	if g.changed {
+		g.next = g.last.Add(h.groupInterval)
+	} else {
+		g.next = g.last.Add(h.repeatInterval)
+	}
+}
+
+func (h *groupHandler) groupKey(event alert.Event) (string, map[string]string) {
+	tags := make(map[string]string, len(h.groupBy))
+	pairs := make([]string, len(h.groupBy))
+	for i, t := range h.groupBy {
+		v := event.Data.Tags[t]
+		tags[t] = v
+		pairs[i] = t + "=" + v
+	}
+	return strings.Join(pairs, ","), tags
+}
+
+// nextNotification returns the time of the earliest pending notification.
+func (h *groupHandler) nextNotification() (next time.Time, ok bool) {
+	for _, g := range h.groups {
+		if !ok || g.next.Before(next) {
+			next = g.next
+			ok = true
+		}
+	}
+	return
+}
+
+// notifications returns the notifications of the groups that are due at now.
+func (h *groupHandler) notifications(now time.Time) []alert.Event {
+	var notifications []alert.Event
+	for key, g := range h.groups {
+		if now.Before(g.next) {
+			continue
+		}
+		notifications = append(notifications, h.notification(g))
+		for id, e := range g.events {
+			if e.State.Level == alert.OK {
+				delete(g.events, id)
+			}
+		}
+		if len(g.events) == 0 {
+			delete(h.groups, key)
+			continue
+		}
+		g.changed = false
+		g.last = now
+		h.schedule(g)
+	}
+	return notifications
+}
+
+// notification creates the event notifying the current state of the group.
+func (h *groupHandler) notification(g *eventGroup) alert.Event {
+	ids := make([]string, 0, len(g.events))
+	for id := range g.events {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	md := groupMessageData{
+		Key:  g.key,
+		Tags: g.tags,
+	}
+	n := alert.Event{
+		State: alert.EventState{
+			ID: h.id,
+		},
+		Data: alert.EventData{
+			Tags: g.tags,
+		},
+		NoExternal: true,
+	}
+	if g.key != "" {
+		n.State.ID += ":" + g.key
+	}
+	details := make([]string, len(ids))
+	for i, id := range ids {
+		e := g.events[id]
+		if e.State.Level == alert.OK {
+			md.Resolved = append(md.Resolved, e.State)
+			details[i] = fmt.Sprintf("RESOLVED %s: %s", id, e.State.Message)
+		} else {
+			md.Firing = append(md.Firing, e.State)
+			details[i] = fmt.Sprintf("FIRING %s %s: %s", e.State.Level, id, e.State.Message)
+			if e.State.Level > n.State.Level {
+				n.State.Level = e.State.Level
+			}
+			if e.State.Duration > n.State.Duration {
+				n.State.Duration = e.State.Duration
+			}
+		}
+		if e.State.Time.After(n.State.Time) {
+			n.State.Time = e.State.Time
+		}
+		n.Topic = e.Topic
+		n.Data.Name = e.Data.Name
+		n.Data.TaskName = e.Data.TaskName
+		n.Data.Result.Series = append(n.Data.Result.Series, e.Data.Result.Series...)
+		n.NoExternal = n.NoExternal && e.NoExternal
+	}
+	var messageBuf bytes.Buffer
+	// Ignore error since we have validated the template already
+	_ = h.messageTmpl.Execute(&messageBuf, md)
+	n.State.Message = messageBuf.String()
+	n.State.Details = strings.Join(details, "\n")
+	return n
+}
+
+func (h *groupHandler) Close() {
+	close(h.closing)
+	h.wg.Wait()
+	if c, ok := h.h.(closer); ok {
+		c.Close()
+	}
+}
+
 // ExternalHandler wraps an existing handler that calls out to external services.
 // The events are checked for the NoExternal flag before being passed to the external handler.
 type externalHandler struct {
@@ -1,9 +1,12 @@
 package alert
 
 import (
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 
+	"github.com/influxdata/influxdb/toml"
 	"github.com/influxdata/kapacitor/alert"
 )
 
@@ -67,3 +70,476 @@ func TestMatchHandlerAlertDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestSilenceMatches(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	event := alert.Event{
+		Topic: "main:cpu:alert2",
+		State: alert.EventState{
+			ID:    "cpu:host=serverA",
+			Level: alert.Critical,
+		},
+		Data: alert.EventData{
+			Tags: map[string]string{
+				"host": "serverA",
+				"dc":   "us-east-1",
+			},
+		},
+	}
+	cases := []struct {
+		name        string
+		silence     Silence
+		shouldMatch bool
+	}{
+		{
+			name: "topic and tag",
+			silence: Silence{
+				Topic:     "main:*",
+				Matchers:  []Matcher{{Name: "host", Value: "serverA"}},
+				ExpiresAt: now.Add(time.Hour),
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "regex",
+			silence: Silence{
+				Topic:     "*",
+				Matchers:  []Matcher{{Name: "dc", Value: "us-.*", Regex: true}},
+				ExpiresAt: now.Add(time.Hour),
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "regex is anchored",
+			silence: Silence{
+				Topic:     "*",
+				Matchers:  []Matcher{{Name: "dc", Value: "east", Regex: true}},
+				ExpiresAt: now.Add(time.Hour),
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "other topic",
+			silence: Silence{
+				Topic:     "other:*",
+				ExpiresAt: now.Add(time.Hour),
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "missing tag",
+			silence: Silence{
+				Topic:     "*",
+				Matchers:  []Matcher{{Name: "service", Value: "web"}},
+				ExpiresAt: now.Add(time.Hour),
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "expired",
+			silence: Silence{
+				Topic:     "*",
+				ExpiresAt: now,
+			},
+			shouldMatch: false,
+		},
+	}
+	for _, tc := range cases {
+		tc.silence.ID = "s"
+		tc.silence.Author = "ops"
+		s, err := newSilence(tc.silence)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if got := s.matches(event, now); got != tc.shouldMatch {
+			t.Errorf("%s: unexpected match, got %v exp %v", tc.name, got, tc.shouldMatch)
+		}
+	}
+}
+
+func TestInhibition(t *testing.T) {
+	in, err := newInhibition(Inhibition{
+		ID:     "dc-down",
+		Source: InhibitionMatch{Category: "dc", Matchers: []Matcher{{Name: "alert", Value: "down"}}},
+		Target: InhibitionMatch{Matchers: []Matcher{{Name: "host", Value: "server.*", Regex: true}}},
+		Equal:  []string{"dc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := func(dc string, level alert.Level) alert.Event {
+		return alert.Event{
+			Topic: "main:dc",
+			State: alert.EventState{ID: "dc:" + dc, Level: level},
+			Data: alert.EventData{
+				Category: "dc",
+				Tags:     map[string]string{"alert": "down", "dc": dc},
+			},
+		}
+	}
+	target := map[string]string{"host": "serverA", "dc": "us-east-1"}
+
+	if in.inhibits("", target) {
+		t.Fatal("expected no inhibition without an active source")
+	}
+	in.update(source("us-west-1", alert.Critical))
+	if in.inhibits("", target) {
+		t.Fatal("expected no inhibition with a source in another dc")
+	}
+	in.update(source("us-east-1", alert.Warning))
+	if !in.inhibits("", target) {
+		t.Fatal("expected inhibition with a source in the same dc")
+	}
+	if in.inhibits("", map[string]string{"host": "db", "dc": "us-east-1"}) {
+		t.Fatal("expected no inhibition of an alert not matching the target")
+	}
+	in.update(source("us-east-1", alert.Critical))
+	in.update(source("us-east-1", alert.OK))
+	if in.inhibits("", target) {
+		t.Fatal("expected no inhibition once the source recovered")
+	}
+	other := source("us-east-1", alert.Critical)
+	other.Data.Category = "network"
+	in.update(other)
+	if in.inhibits("", target) {
+		t.Fatal("expected no inhibition by an alert of another category")
+	}
+}
+
+func TestInhibition_MatchingBothSides(t *testing.T) {
+	in, err := newInhibition(Inhibition{
+		ID:     "host-down",
+		Source: InhibitionMatch{Matchers: []Matcher{{Name: "host", Value: "server.*", Regex: true}}},
+		Target: InhibitionMatch{Matchers: []Matcher{{Name: "host", Value: "server.*", Regex: true}}},
+		Equal:  []string{"dc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := func(host string, level alert.Level) alert.Event {
+		return alert.Event{
+			Topic: "main:host",
+			State: alert.EventState{ID: host, Level: level},
+			Data: alert.EventData{
+				Tags: map[string]string{"host": host, "dc": "us-east-1"},
+			},
+		}
+	}
+
+	a := event("serverA", alert.Critical)
+	in.update(a)
+	if in.inhibits(a.Data.Category, a.Data.Tags) {
+		t.Fatal("expected an alert not to inhibit itself")
+	}
+	b := event("serverB", alert.Critical)
+	in.update(b)
+	if in.inhibits(b.Data.Category, b.Data.Tags) {
+		t.Fatal("expected no inhibition of an alert matching both the source and the target")
+	}
+	in.update(event("serverA", alert.OK))
+	in.update(event("serverB", alert.OK))
+	if len(in.active) != 0 {
+		t.Fatal("expected no active source once the sources recovered")
+	}
+}
+
+type escalationTestTopics struct {
+	Topics
+	states   map[string]alert.EventState
+	silenced map[string]bool
+}
+
+func (t *escalationTestTopics) IsSilenced(event alert.Event) bool {
+	return t.silenced[event.State.ID]
+}
+
+func (t *escalationTestTopics) EventState(topic, event string) (alert.EventState, bool, error) {
+	state, ok := t.states[event]
+	return state, ok, nil
+}
+
+func (t *escalationTestTopics) EventStates(topic string, minLevel alert.Level) (map[string]alert.EventState, error) {
+	states := make(map[string]alert.EventState)
+	for id, state := range t.states {
+		if state.Level >= minLevel {
+			states[id] = state
+		}
+	}
+	return states, nil
+}
+
+type recordingHandler struct {
+	events []alert.Event
+}
+
+func (h *recordingHandler) Handle(event alert.Event) {
+	h.events = append(h.events, event)
+}
+
+func TestEscalationHandler(t *testing.T) {
+	start := time.Now().Add(-30 * time.Minute)
+	topics := &escalationTestTopics{
+		states: make(map[string]alert.EventState),
+	}
+	c := newDefaultEscalationHandlerConfig("topic", topics)
+	c.Interval = toml.Duration(time.Hour)
+	c.Steps = []EscalationStepConfig{
+		{Kind: "slack"},
+		{Kind: "pagerduty2", Delay: toml.Duration(15 * time.Minute), Level: alert.Critical},
+		{Kind: "smtp", Delay: toml.Duration(time.Hour)},
+	}
+	slack, pagerduty, smtp := new(recordingHandler), new(recordingHandler), new(recordingHandler)
+	ah, err := NewEscalationHandler(c, []alert.Handler{slack, pagerduty, smtp}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := ah.(*escalationHandler)
+	defer h.Close()
+
+	collect := func(state alert.EventState) {
+		topics.states[state.ID] = state
+		h.Handle(alert.Event{Topic: "topic", State: state})
+	}
+	counts := func(step string, exp ...int) {
+		t.Helper()
+		h.deliver()
+		got := []int{len(slack.events), len(pagerduty.events), len(smtp.events)}
+		for i := range exp {
+			if got[i] != exp[i] {
+				t.Fatalf("%s: unexpected handled event counts, got %v exp %v", step, got, exp)
+			}
+		}
+	}
+
+	collect(alert.EventState{ID: "a", Level: alert.Warning, Time: start})
+	counts("warning", 1, 0, 0)
+
+	h.mu.Lock()
+	h.escalate(start.Add(20 * time.Minute))
+	h.mu.Unlock()
+	counts("warning after delay", 1, 0, 0)
+
+	collect(alert.EventState{ID: "a", Level: alert.Critical, Time: start.Add(20 * time.Minute), Duration: 20 * time.Minute})
+	counts("critical after delay", 2, 1, 0)
+
+	// Acknowledging the event stops the escalation
+	state := topics.states["a"]
+	state.AckedBy = "ops"
+	state.AckedAt = start.Add(30 * time.Minute)
+	topics.states["a"] = state
+	h.mu.Lock()
+	h.escalate(start.Add(2 * time.Hour))
+	h.mu.Unlock()
+	counts("acked", 2, 1, 0)
+
+	// The reached steps are notified of the recovery
+	collect(alert.EventState{ID: "a", Level: alert.OK, Time: start.Add(3 * time.Hour), Duration: 0})
+	counts("recovered", 3, 2, 0)
+	if got := pagerduty.events[1].State.Level; got != alert.OK {
+		t.Errorf("unexpected level of recovery, got %v exp %v", got, alert.OK)
+	}
+}
+
+func TestEscalationHandler_Restore(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	topics := &escalationTestTopics{
+		states: map[string]alert.EventState{
+			"a": {ID: "a", Level: alert.Critical, Time: start.Add(20 * time.Minute), Duration: 20 * time.Minute},
+		},
+	}
+	c := newDefaultEscalationHandlerConfig("topic", topics)
+	c.Interval = toml.Duration(time.Hour)
+	c.Steps = []EscalationStepConfig{
+		{Kind: "slack"},
+		{Kind: "smtp", Delay: toml.Duration(30 * time.Minute)},
+	}
+	slack, smtp := new(recordingHandler), new(recordingHandler)
+	ah, err := NewEscalationHandler(c, []alert.Handler{slack, smtp}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := ah.(*escalationHandler)
+	defer h.Close()
+
+	h.mu.Lock()
+	h.escalate(start.Add(40 * time.Minute))
+	h.mu.Unlock()
+	h.deliver()
+	if len(slack.events) != 0 {
+		t.Errorf("expected the step reached before the restart not to be handled again, got %d events", len(slack.events))
+	}
+	if len(smtp.events) != 1 {
+		t.Errorf("expected the pending step to be handled after the restart, got %d events", len(smtp.events))
+	}
+}
+
+func TestEscalationHandler_Silenced(t *testing.T) {
+	start := time.Now()
+	topics := &escalationTestTopics{
+		states:   make(map[string]alert.EventState),
+		silenced: make(map[string]bool),
+	}
+	c := newDefaultEscalationHandlerConfig("topic", topics)
+	c.Interval = toml.Duration(time.Hour)
+	c.Steps = []EscalationStepConfig{
+		{Kind: "slack"},
+		{Kind: "smtp", Delay: toml.Duration(30 * time.Minute)},
+	}
+	slack, smtp := new(recordingHandler), new(recordingHandler)
+	ah, err := NewEscalationHandler(c, []alert.Handler{slack, smtp}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := ah.(*escalationHandler)
+	defer h.Close()
+
+	state := alert.EventState{ID: "a", Level: alert.Critical, Time: start}
+	topics.states["a"] = state
+	h.Handle(alert.Event{Topic: "topic", State: state})
+	h.deliver()
+	if len(slack.events) != 1 {
+		t.Fatalf("expected the first step to be handled, got %d events", len(slack.events))
+	}
+
+	// A silenced event does not escalate
+	topics.silenced["a"] = true
+	h.mu.Lock()
+	h.escalate(start.Add(40 * time.Minute))
+	h.mu.Unlock()
+	h.deliver()
+	if len(smtp.events) != 0 {
+		t.Errorf("expected the silenced event not to escalate, got %d events", len(smtp.events))
+	}
+
+	// The escalation continues once the silence ends
+	delete(topics.silenced, "a")
+	h.mu.Lock()
+	h.escalate(start.Add(50 * time.Minute))
+	h.mu.Unlock()
+	h.deliver()
+	if len(smtp.events) != 1 {
+		t.Errorf("expected the event to escalate after the silence, got %d events", len(smtp.events))
+	}
+}
+
+type blockingHandler struct {
+	release chan struct{}
+	events  []alert.Event
+}
+
+func (h *blockingHandler) Handle(event alert.Event) {
+	<-h.release
+	h.events = append(h.events, event)
+}
+
+func TestEscalationHandler_SlowStep(t *testing.T) {
+	topics := &escalationTestTopics{
+		states: make(map[string]alert.EventState),
+	}
+	c := newDefaultEscalationHandlerConfig("topic", topics)
+	c.Interval = toml.Duration(time.Hour)
+	c.Steps = []EscalationStepConfig{
+		{Kind: "slack"},
+	}
+	slack := &blockingHandler{release: make(chan struct{})}
+	ah, err := NewEscalationHandler(c, []alert.Handler{slack}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := ah.(*escalationHandler)
+
+	// The events are handled while the step is blocked
+	start := time.Now()
+	levels := []alert.Level{alert.Warning, alert.Critical, alert.OK}
+	for _, l := range levels {
+		state := alert.EventState{ID: "a", Level: l, Time: start}
+		topics.states["a"] = state
+		h.Handle(alert.Event{Topic: "topic", State: state})
+	}
+
+	close(slack.release)
+	h.Close()
+	if len(slack.events) != len(levels) {
+		t.Fatalf("unexpected number of handled events, got %d exp %d", len(slack.events), len(levels))
+	}
+	for i, l := range levels {
+		if got := slack.events[i].State.Level; got != l {
+			t.Errorf("unexpected level of event %d, got %v exp %v", i, got, l)
+		}
+	}
+}
+
+func TestGroupHandler(t *testing.T) {
+	c := newDefaultGroupHandlerConfig("grouped")
+	c.GroupBy = []string{"dc"}
+	c.GroupWait = toml.Duration(30 * time.Second)
+	c.GroupInterval = toml.Duration(5 * time.Minute)
+	c.RepeatInterval = toml.Duration(time.Hour)
+	c.Kind = "slack"
+	rh := new(recordingHandler)
+	gh, err := NewGroupHandler(c, rh, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := gh.(*groupHandler)
+	defer h.Close()
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	event := func(id string, level alert.Level, dc string) alert.Event {
+		return alert.Event{
+			Topic: "topic",
+			State: alert.EventState{
+				ID:      id,
+				Message: id + " is " + level.String(),
+				Level:   level,
+				Time:    now,
+			},
+			Data: alert.EventData{
+				Tags: map[string]string{"dc": dc},
+			},
+		}
+	}
+	notify := func(at time.Duration, exp ...string) {
+		t.Helper()
+		ns := h.notifications(now.Add(at))
+		got := make([]string, len(ns))
+		for i, n := range ns {
+			got[i] = n.State.ID + " " + n.State.Level.String() + " " + n.State.Message
+		}
+		sort.Strings(got)
+		if len(exp) == 0 {
+			exp = []string{}
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected notifications at %v:\ngot %q\nexp %q", at, got, exp)
+		}
+	}
+
+	h.add(event("a", alert.Critical, "east"), now)
+	h.add(event("b", alert.Warning, "east"), now.Add(10*time.Second))
+	h.add(event("c", alert.Warning, "west"), now.Add(10*time.Second))
+	notify(20 * time.Second)
+	notify(30*time.Second, "grouped:dc=east CRITICAL 2 firing and 0 resolved alerts for dc=east.")
+	notify(40*time.Second, "grouped:dc=west WARNING 1 firing and 0 resolved alerts for dc=west.")
+
+	// Events that did not change are not notified before the repeat interval
+	h.add(event("a", alert.Critical, "east"), now.Add(time.Minute))
+	notify(10 * time.Minute)
+
+	// Changes are notified after the group interval
+	h.add(event("b", alert.OK, "east"), now.Add(20*time.Minute))
+	notify(30*time.Second+20*time.Minute, "grouped:dc=east CRITICAL 1 firing and 1 resolved alerts for dc=east.")
+
+	// The groups are notified again after the repeat interval
+	notify(time.Hour+40*time.Second, "grouped:dc=west WARNING 1 firing and 0 resolved alerts for dc=west.")
+
+	// The group is removed once all its events are resolved
+	h.add(event("c", alert.OK, "west"), now.Add(2*time.Hour))
+	notify(2*time.Hour,
+		"grouped:dc=east CRITICAL 1 firing and 0 resolved alerts for dc=east.",
+		"grouped:dc=west OK 0 firing and 1 resolved alerts for dc=west.",
+	)
+	if _, ok := h.groups["dc=west"]; ok {
+		t.Error("expected resolved group to be removed")
+	}
+}
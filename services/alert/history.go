@@ -0,0 +1,269 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/kapacitor/alert"
+	"github.com/influxdata/kapacitor/services/storage"
+)
+
+//--------------------------------------------------------------------
+// The following structures are stored in a database via JSON encoding.
+// Changes to the structures could break existing data.
+
+const (
+	historyEntryVersion1 = 1
+)
+
+// HistoryEntry is a change of the level of an event.
+type HistoryEntry struct {
+	Topic         string            `json:"topic"`
+	ID            string            `json:"id"`
+	Time          time.Time         `json:"time"`
+	Level         alert.Level       `json:"level"`
+	PreviousLevel alert.Level       `json:"previous-level"`
+	Message       string            `json:"message"`
+	Details       string            `json:"details"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+func (e HistoryEntry) MarshalBinary() ([]byte, error) {
+	return storage.VersionJSONEncode(historyEntryVersion1, e)
+}
+
+func (e *HistoryEntry) UnmarshalBinary(data []byte) error {
+	return storage.VersionJSONDecode(data, func(version int, dec *json.Decoder) error {
+		switch version {
+		case historyEntryVersion1:
+			return dec.Decode(e)
+		default:
+			return fmt.Errorf("unknown history entry version %d: cannot decode", version)
+		}
+	})
+}
+
+// HistoryQuery selects the entries of the alert history.
+type HistoryQuery struct {
+	// Glob pattern matching the topics, all topics if empty.
+	Topic string
+	// Glob pattern matching the event IDs, all events if empty.
+	Event string
+	// Tags the events must have.
+	Tags map[string]string
+	// Start of the time range, inclusive. Unbounded if zero.
+	Start time.Time
+	// Stop of the time range, exclusive. Unbounded if zero.
+	Stop time.Time
+	// Maximum number of entries, the most recent entries are returned. No limit if zero.
+	Limit int
+}
+
+func (q HistoryQuery) matches(e HistoryEntry) bool {
+	if !alert.PatternMatch(q.Event, e.ID) {
+		return false
+	}
+	for k, v := range q.Tags {
+		if e.Tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// historyKeyFormat is a fixed width time format, so that the keys of the entries are sorted by time.
+const historyKeyFormat = "20060102T150405.000000000Z"
+
+func historyKey(e HistoryEntry) string {
+	return e.Time.UTC().Format(historyKeyFormat) + "_" + e.ID
+}
+
+func historyKeyTime(key string) (time.Time, error) {
+	if len(key) < len(historyKeyFormat) {
+		return time.Time{}, fmt.Errorf("invalid history key %q", key)
+	}
+	return time.Parse(historyKeyFormat, key[:len(historyKeyFormat)])
+}
+
+// historyPruneSlack is the fraction of the retention limits by which the history may exceed them.
+// Entries are pruned in batches to avoid listing the history of a topic on each append.
+const historyPruneSlack = 10
+
+// historyStore persists the history of each topic in its own bucket.
+type historyStore struct {
+	store      storage.Interface
+	retention  time.Duration
+	maxEntries int
+
+	mu     sync.Mutex
+	topics map[string]historySize
+}
+
+// historySize tracks the size of the history of a topic.
+type historySize struct {
+	count  int
+	oldest time.Time
+}
+
+func newHistoryStore(store storage.Interface, retention time.Duration, maxEntries int) *historyStore {
+	return &historyStore{
+		store:      store,
+		retention:  retention,
+		maxEntries: maxEntries,
+		topics:     make(map[string]historySize),
+	}
+}
+
+// Append adds an entry to the history of its topic and prunes the entries beyond the retention limits.
+func (h *historyStore) Append(e HistoryEntry, now time.Time) error {
+	data, err := e.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var size historySize
+	err = h.store.Update(func(tx storage.Tx) error {
+		tx = tx.Bucket([]byte(e.Topic))
+		if tx == nil {
+			return nil
+		}
+		var ok bool
+		size, ok = h.topics[e.Topic]
+		if !ok {
+			kvs, err := tx.List("")
+			if err != nil {
+				return err
+			}
+			size.count = len(kvs)
+			if len(kvs) > 0 {
+				size.oldest, _ = historyKeyTime(kvs[0].Key)
+			}
+		}
+		if err := tx.Put(historyKey(e), data); err != nil {
+			return err
+		}
+		size.count++
+		if size.oldest.IsZero() || e.Time.Before(size.oldest) {
+			size.oldest = e.Time
+		}
+		return h.prune(tx, &size, now)
+	})
+	if err != nil {
+		return err
+	}
+	h.topics[e.Topic] = size
+	return nil
+}
+
+// prune deletes the oldest entries of the topic once the history exceeds the retention limits by the slack.
+func (h *historyStore) prune(tx storage.Tx, size *historySize, now time.Time) error {
+	var cutoff time.Time
+	if h.retention > 0 {
+		cutoff = now.Add(-h.retention)
+	}
+	overflow := h.maxEntries > 0 && size.count > h.maxEntries+h.maxEntries/historyPruneSlack
+	expired := !cutoff.IsZero() && size.oldest.Before(cutoff.Add(-h.retention/historyPruneSlack))
+	if !overflow && !expired {
+		return nil
+	}
+	kvs, err := tx.List("")
+	if err != nil {
+		return err
+	}
+	size.count = len(kvs)
+	size.oldest = time.Time{}
+	for _, kv := range kvs {
+		t, err := historyKeyTime(kv.Key)
+		if err == nil && (h.maxEntries == 0 || size.count <= h.maxEntries) && (cutoff.IsZero() || !t.Before(cutoff)) {
+			size.oldest = t
+			break
+		}
+		if err := tx.Delete(kv.Key); err != nil {
+			return err
+		}
+		size.count--
+	}
+	return nil
+}
+
+// Query returns the entries matching the query, sorted by time.
+func (h *historyStore) Query(q HistoryQuery) ([]HistoryEntry, error) {
+	var entries []HistoryEntry
+	err := h.store.View(func(tx storage.ReadOnlyTx) error {
+		buckets, err := tx.List("")
+		if err != nil {
+			return err
+		}
+		for _, b := range buckets {
+			if b == nil || !alert.PatternMatch(q.Topic, b.Key) {
+				continue
+			}
+			topicEntries, err := q.queryTopic(tx.Bucket([]byte(b.Key)))
+			if err != nil {
+				return err
+			}
+			entries = append(entries, topicEntries...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Stable(sortedHistory(entries))
+	if q.Limit > 0 && len(entries) > q.Limit {
+		entries = entries[len(entries)-q.Limit:]
+	}
+	return entries, nil
+}
+
+// queryTopic returns the entries of the history of a topic matching the query, sorted by time.
+// The entries are read backwards from the stop of the query, so that only the most recent entries are read.
+func (q HistoryQuery) queryTopic(tx storage.ReadOnlyTx) ([]HistoryEntry, error) {
+	c := tx.Cursor()
+	if c == nil {
+		return nil, nil
+	}
+	var k, v []byte
+	if q.Stop.IsZero() {
+		k, v = c.Last()
+	} else if k, _ = c.Seek([]byte(q.Stop.UTC().Format(historyKeyFormat))); k == nil {
+		k, v = c.Last()
+	} else {
+		k, v = c.Prev()
+	}
+	var entries []HistoryEntry
+	for ; k != nil; k, v = c.Prev() {
+		if q.Limit > 0 && len(entries) == q.Limit {
+			break
+		}
+		t, err := historyKeyTime(string(k))
+		if err != nil || v == nil {
+			continue
+		}
+		if !q.Start.IsZero() && t.Before(q.Start) {
+			break
+		}
+		var e HistoryEntry
+		if err := e.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		if q.matches(e) {
+			entries = append(entries, e)
+		}
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+type sortedHistory []HistoryEntry
+
+func (s sortedHistory) Len() int               { return len(s) }
+func (s sortedHistory) Less(i int, j int) bool { return s[i].Time.Before(s[j].Time) }
+func (s sortedHistory) Swap(i int, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,155 @@
+package alert
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/kapacitor/alert"
+	"github.com/influxdata/kapacitor/services/storage"
+	bolt "go.etcd.io/bbolt"
+)
+
+func newTestHistoryStore(t *testing.T, retention time.Duration, maxEntries int) *historyStore {
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "history.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		os.Remove(db.Path())
+	})
+	return newHistoryStore(storage.NewBolt(db, []byte(AlertHistoryNameSpace)), retention, maxEntries)
+}
+
+func TestHistoryStore_Query(t *testing.T) {
+	h := newTestHistoryStore(t, 0, 0)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	entries := []HistoryEntry{
+		{Topic: "main:cpu", ID: "cpu:serverA", Time: now, Level: alert.Warning, PreviousLevel: alert.OK, Tags: map[string]string{"host": "serverA"}},
+		{Topic: "main:cpu", ID: "cpu:serverB", Time: now.Add(time.Minute), Level: alert.Critical, PreviousLevel: alert.OK, Tags: map[string]string{"host": "serverB"}},
+		{Topic: "main:mem", ID: "mem:serverA", Time: now.Add(2 * time.Minute), Level: alert.Critical, PreviousLevel: alert.OK, Tags: map[string]string{"host": "serverA"}},
+		{Topic: "main:cpu", ID: "cpu:serverA", Time: now.Add(3 * time.Minute), Level: alert.OK, PreviousLevel: alert.Warning, Tags: map[string]string{"host": "serverA"}},
+	}
+	for _, e := range entries {
+		if err := h.Append(e, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		name string
+		q    HistoryQuery
+		exp  []HistoryEntry
+	}{
+		{
+			name: "all",
+			exp:  entries,
+		},
+		{
+			name: "topic",
+			q:    HistoryQuery{Topic: "*:cpu"},
+			exp:  []HistoryEntry{entries[0], entries[1], entries[3]},
+		},
+		{
+			name: "event",
+			q:    HistoryQuery{Event: "cpu:serverA"},
+			exp:  []HistoryEntry{entries[0], entries[3]},
+		},
+		{
+			name: "tag",
+			q:    HistoryQuery{Tags: map[string]string{"host": "serverA"}},
+			exp:  []HistoryEntry{entries[0], entries[2], entries[3]},
+		},
+		{
+			name: "time range",
+			q:    HistoryQuery{Start: now.Add(time.Minute), Stop: now.Add(3 * time.Minute)},
+			exp:  []HistoryEntry{entries[1], entries[2]},
+		},
+		{
+			name: "limit",
+			q:    HistoryQuery{Limit: 2},
+			exp:  []HistoryEntry{entries[2], entries[3]},
+		},
+		{
+			name: "limit within time range",
+			q:    HistoryQuery{Stop: now.Add(3 * time.Minute), Limit: 2},
+			exp:  []HistoryEntry{entries[1], entries[2]},
+		},
+		{
+			name: "limit of matching entries",
+			q:    HistoryQuery{Event: "cpu:serverA", Limit: 1},
+			exp:  []HistoryEntry{entries[3]},
+		},
+		{
+			name: "stop after last entry",
+			q:    HistoryQuery{Start: now.Add(2 * time.Minute), Stop: now.Add(time.Hour)},
+			exp:  []HistoryEntry{entries[2], entries[3]},
+		},
+	}
+	for _, tc := range testCases {
+		got, err := h.Query(tc.q)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if len(got) != len(tc.exp) {
+			t.Fatalf("%s: unexpected number of entries, got %d exp %d", tc.name, len(got), len(tc.exp))
+		}
+		for i := range got {
+			if !got[i].Time.Equal(tc.exp[i].Time) || got[i].ID != tc.exp[i].ID || got[i].Level != tc.exp[i].Level {
+				t.Errorf("%s: unexpected entry %d, got %v exp %v", tc.name, i, got[i], tc.exp[i])
+			}
+		}
+	}
+}
+
+func TestHistoryStore_Retention(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	times := func(entries []HistoryEntry) []time.Time {
+		ts := make([]time.Time, len(entries))
+		for i, e := range entries {
+			ts[i] = e.Time
+		}
+		return ts
+	}
+
+	// Max entries
+	h := newTestHistoryStore(t, 0, 10)
+	var exp []time.Time
+	for i := 0; i < 12; i++ {
+		e := HistoryEntry{Topic: "topic", ID: "event", Time: now.Add(time.Duration(i) * time.Second), Level: alert.Critical}
+		if err := h.Append(e, now); err != nil {
+			t.Fatal(err)
+		}
+		exp = append(exp, e.Time)
+	}
+	got, err := h.Query(HistoryQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := exp[2:]; !reflect.DeepEqual(times(got), exp) {
+		t.Errorf("unexpected entries after pruning by count:\ngot %v\nexp %v", times(got), exp)
+	}
+
+	// Retention
+	h = newTestHistoryStore(t, time.Hour, 0)
+	for _, d := range []time.Duration{0, 30 * time.Minute, 50 * time.Minute} {
+		e := HistoryEntry{Topic: "topic", ID: "event", Time: now.Add(d), Level: alert.Critical}
+		if err := h.Append(e, now.Add(d)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	e := HistoryEntry{Topic: "topic", ID: "event", Time: now.Add(2 * time.Hour), Level: alert.OK}
+	if err := h.Append(e, now.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	got, err = h.Query(HistoryQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []time.Time{now.Add(2 * time.Hour)}; !reflect.DeepEqual(times(got), exp) {
+		t.Errorf("unexpected entries after pruning by age:\ngot %v\nexp %v", times(got), exp)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"path"
 	"reflect"
 	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/influxdata/kapacitor/alert"
 	"github.com/influxdata/kapacitor/command"
@@ -37,6 +39,7 @@ import (
 	"github.com/influxdata/kapacitor/services/telegram"
 	"github.com/influxdata/kapacitor/services/victorops"
 	"github.com/influxdata/kapacitor/services/zenoss"
+	"github.com/influxdata/kapacitor/uuid"
 	"github.com/mailru/easyjson/jlexer"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
@@ -69,10 +72,20 @@ type Service struct {
 	disabled map[string]struct{}
 	// Handler store API
 	specsDAO HandlerSpecDAO
+	// Silence store API
+	silencesDAO SilenceDAO
+	// Inhibition store API
+	inhibitionsDAO InhibitionDAO
 	// V2 topic store
 	topicsStore   storage.Interface
 	PersistTopics bool
 
+	// Alert history store
+	history           *historyStore
+	RecordHistory     bool
+	HistoryRetention  time.Duration
+	HistoryMaxEntries int
+
 	APIServer *apiServer
 
 	handlers map[string]map[string]handler
@@ -81,6 +94,13 @@ type Service struct {
 
 	inhibitorLookup *alert.InhibitorLookup
 
+	// silencesMu protects the silences separately,
+	// so that handlers may check them while the service lock is held.
+	silencesMu sync.RWMutex
+	silences   map[string]*silence
+
+	inhibitions map[string]*inhibition
+
 	topics         *alert.Topics
 	EventCollector EventCollector
 
@@ -172,12 +192,18 @@ func NewService(d Diagnostic, disabled map[string]struct{}, topicBufLen int) *Se
 		topics:          alert.NewTopics(topicBufLen),
 		diag:            d,
 		inhibitorLookup: alert.NewInhibitorLookup(),
+		silences:        make(map[string]*silence),
+		inhibitions:     make(map[string]*inhibition),
 	}
 	s.APIServer = &apiServer{
-		Registrar: s,
-		Topics:    s,
-		Persister: s,
-		diag:      d,
+		Registrar:    s,
+		Topics:       s,
+		Persister:    s,
+		Acknowledger: s,
+		Silencer:     s,
+		History:      s,
+		Inhibitor:    s,
+		diag:         d,
 	}
 	s.EventCollector = s
 	return s
@@ -186,11 +212,17 @@ func NewService(d Diagnostic, disabled map[string]struct{}, topicBufLen int) *Se
 const (
 	// Public name of the handler specs store.
 	handlerSpecsAPIName = "handler-specs"
+	// Public name of the silences store.
+	silencesAPIName = "silences"
+	// Public name of the inhibitions store.
+	inhibitionsAPIName = "inhibitions"
 	// The storage namespace V1 topic store and task data.
 	// In V2, still stores handlers
 	AlertNameSpace = "alert_store"
 	// TopicStatesNameSpace - The storage namespace for the V2 topic store and nothing else
 	TopicStatesNameSpace = "topic_states_store"
+	// AlertHistoryNameSpace - The storage namespace for the alert history
+	AlertHistoryNameSpace = "alert_history_store"
 )
 
 func (s *Service) Open() error {
@@ -204,8 +236,21 @@ func (s *Service) Open() error {
 	}
 	s.specsDAO = specsDAO
 	s.StorageService.Register(handlerSpecsAPIName, s.specsDAO)
+	silencesDAO, err := newSilenceKV(store)
+	if err != nil {
+		return err
+	}
+	s.silencesDAO = silencesDAO
+	s.StorageService.Register(silencesAPIName, s.silencesDAO)
+	inhibitionsDAO, err := newInhibitionKV(store)
+	if err != nil {
+		return err
+	}
+	s.inhibitionsDAO = inhibitionsDAO
+	s.StorageService.Register(inhibitionsAPIName, s.inhibitionsDAO)
 	s.topicsStore = s.StorageService.Store(TopicStatesNameSpace)
 	// NOTE: since the topics store doesn't use the indexing store, we don't need to register the api
+	s.history = newHistoryStore(s.StorageService.Store(AlertHistoryNameSpace), s.HistoryRetention, s.HistoryMaxEntries)
 
 	// Migrate v1.2 handlers
 	if err := s.migrateHandlerSpecs(store); err != nil {
@@ -217,6 +262,16 @@ func (s *Service) Open() error {
 		return err
 	}
 
+	// Load saved silences
+	if err := s.loadSavedSilences(); err != nil {
+		return err
+	}
+
+	// Load saved inhibitions
+	if err := s.loadSavedInhibitions(); err != nil {
+		return err
+	}
+
 	if err := s.MigrateTopicStoreV1V2(); err != nil {
 		return err
 	}
@@ -226,6 +281,15 @@ func (s *Service) Open() error {
 		return err
 	}
 
+	// Rebuild the sources of the inhibitions from the topic state
+	inhibitions := make([]*inhibition, 0, len(s.inhibitions))
+	for _, in := range s.inhibitions {
+		inhibitions = append(inhibitions, in)
+	}
+	if err := s.restoreInhibitionSources(inhibitions...); err != nil {
+		return err
+	}
+
 	s.APIServer.HTTPDService = s.HTTPDService
 	if err := s.APIServer.Open(); err != nil {
 		return err
@@ -387,25 +451,77 @@ func (s *Service) loadSavedHandlerSpecs() error {
 	return nil
 }
 
-func convertEventStateToAlert(id string, state *EventState) *alert.EventState {
-	return &alert.EventState{
-		ID:       id,
-		Message:  state.Message,
-		Details:  state.Details,
-		Time:     state.Time,
-		Duration: state.Duration,
-		Level:    state.Level,
+func (s *Service) loadSavedSilences() error {
+	offset := 0
+	limit := 100
+	for {
+		silences, err := s.silencesDAO.List("*", offset, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, spec := range silences {
+			sil, err := newSilence(spec)
+			if err != nil {
+				s.diag.Error("failed to load silence on startup", err)
+				continue
+			}
+			s.silences[spec.ID] = sil
+		}
+
+		offset += limit
+		if len(silences) != limit {
+			break
+		}
 	}
+	s.pruneSilences(time.Now())
+	return nil
+}
+
+func (s *Service) loadSavedInhibitions() error {
+	offset := 0
+	limit := 100
+	for {
+		inhibitions, err := s.inhibitionsDAO.List("*", offset, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, spec := range inhibitions {
+			in, err := newInhibition(spec)
+			if err != nil {
+				s.diag.Error("failed to load inhibition on startup", err)
+				continue
+			}
+			s.inhibitions[spec.ID] = in
+		}
+
+		offset += limit
+		if len(inhibitions) != limit {
+			break
+		}
+	}
+	return nil
+}
+
+func convertEventStateToAlert(id string, state *EventState) *alert.EventState {
+	return state.AlertEventState(id)
 }
 
 func convertEventStateFromAlert(state alert.EventState) *EventState {
-	return &EventState{
+	es := &EventState{
 		Message:  state.Message,
 		Details:  state.Details,
 		Time:     state.Time,
 		Duration: state.Duration,
 		Level:    state.Level,
+		AckedBy:  state.AckedBy,
+	}
+	if state.Acked() {
+		ackedAt := state.AckedAt
+		es.AckedAt = &ackedAt
 	}
+	return es
 }
 
 func (s *Service) loadSavedTopicStates() error {
@@ -483,10 +599,24 @@ func (s *Service) Collect(event alert.Event) error {
 		}
 	}
 
-	err := s.topics.Collect(event)
+	if !event.Silenced {
+		event.Silenced = s.isSilenced(event, time.Now())
+	}
+
+	prev, hasPrev, err := s.topics.Collect(event)
 	if err != nil {
 		return err
 	}
+	previousLevel := alert.OK
+	if hasPrev {
+		previousLevel = prev.Level
+	}
+	if event.State.Level != previousLevel {
+		if err := s.recordHistory(event, previousLevel); err != nil {
+			// The state of the event is still persisted.
+			s.diag.Error("failed to record alert history", err, keyvalue.KV("topic", event.Topic), keyvalue.KV("event", event.State.ID))
+		}
+	}
 	// Events with alert.OK status should always only be resets from other statuses.
 	if event.State.Level == alert.OK && s.PersistTopics {
 		if err := s.clearHistory(&event); err != nil {
@@ -514,7 +644,20 @@ func (s *Service) persistEventState(event alert.Event) error {
 		if tx == nil {
 			return nil
 		}
-		data, err := convertEventStateFromAlert(event.State).MarshalJSON()
+		es := convertEventStateFromAlert(event.State)
+		es.Category = event.Data.Category
+		es.Tags = event.Data.Tags
+		if es.Category == "" && es.Tags == nil {
+			// Updates of the state alone keep the data of the event.
+			if kv, err := tx.Get(event.State.ID); err == nil {
+				prev := EventState{}
+				if err := prev.UnmarshalJSON(kv.Value); err == nil {
+					es.Category = prev.Category
+					es.Tags = prev.Tags
+				}
+			}
+		}
+		data, err := es.MarshalJSON()
 		if err != nil {
 			return fmt.Errorf("cannot marshal event %q in topic %q: %w", event.State.ID, event.Topic, err)
 		}
@@ -522,6 +665,31 @@ func (s *Service) persistEventState(event alert.Event) error {
 	})
 }
 
+// recordHistory appends the level change of the event to the alert history.
+func (s *Service) recordHistory(event alert.Event, previousLevel alert.Level) error {
+	if !s.RecordHistory || s.history == nil {
+		return nil
+	}
+	return s.history.Append(HistoryEntry{
+		Topic:         event.Topic,
+		ID:            event.State.ID,
+		Time:          event.State.Time,
+		Level:         event.State.Level,
+		PreviousLevel: previousLevel,
+		Message:       event.State.Message,
+		Details:       event.State.Details,
+		Tags:          event.Data.Tags,
+	}, time.Now())
+}
+
+// AlertHistory returns the level changes of the events matching the query, sorted by time.
+func (s *Service) AlertHistory(q HistoryQuery) ([]HistoryEntry, error) {
+	if s.history == nil {
+		return nil, nil
+	}
+	return s.history.Query(q)
+}
+
 func (s *Service) clearHistory(event *alert.Event) error {
 	// clear on-disk EventStates, but leave the in-memory history
 	return s.topicsStore.Update(func(tx storage.Tx) error {
@@ -623,6 +791,409 @@ func (s *Service) UpdateEvent(topic string, event alert.EventState) error {
 	})
 }
 
+// AckEvent acknowledges the current state of an event,
+// so that it is not handled again until its level changes.
+func (s *Service) AckEvent(topic, event, author string) (alert.EventState, bool, error) {
+	state, ok := s.topics.AckEvent(topic, event, author, time.Now().UTC())
+	if !ok {
+		return alert.EventState{}, false, nil
+	}
+	if err := s.persistEventState(alert.Event{Topic: topic, State: state}); err != nil {
+		return alert.EventState{}, false, err
+	}
+	return state, true, nil
+}
+
+// IsSilenced reports whether any silence matches the event.
+func (s *Service) IsSilenced(event alert.Event) bool {
+	return s.isSilenced(event, time.Now())
+}
+
+// isSilenced reports whether any silence matches the event at time now.
+func (s *Service) isSilenced(event alert.Event, now time.Time) bool {
+	s.silencesMu.RLock()
+	defer s.silencesMu.RUnlock()
+	for _, sil := range s.silences {
+		if sil.matches(event, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateSilence persists a new silence and starts silencing the matching events.
+// An ID is generated if the silence does not have one.
+func (s *Service) CreateSilence(spec Silence) (Silence, error) {
+	if spec.ID == "" {
+		spec.ID = uuid.New().String()
+	}
+	if spec.CreatedAt.IsZero() {
+		spec.CreatedAt = time.Now().UTC()
+	}
+	sil, err := newSilence(spec)
+	if err != nil {
+		return Silence{}, err
+	}
+
+	s.silencesMu.Lock()
+	defer s.silencesMu.Unlock()
+	s.pruneSilences(time.Now())
+	if err := s.silencesDAO.Create(spec); err != nil {
+		return Silence{}, err
+	}
+	s.silences[spec.ID] = sil
+	return spec, nil
+}
+
+// Silence returns a silence.
+// Expired silences no longer exist.
+func (s *Service) Silence(id string) (Silence, bool, error) {
+	s.silencesMu.Lock()
+	defer s.silencesMu.Unlock()
+	s.pruneSilences(time.Now())
+	sil, ok := s.silences[id]
+	if !ok {
+		return Silence{}, false, nil
+	}
+	return sil.Silence, true, nil
+}
+
+// Silences returns the silences whose ID matches the pattern.
+// Expired silences no longer exist.
+func (s *Service) Silences(pattern string) ([]Silence, error) {
+	s.silencesMu.Lock()
+	defer s.silencesMu.Unlock()
+	s.pruneSilences(time.Now())
+	silences := make([]Silence, 0, len(s.silences))
+	for id, sil := range s.silences {
+		if alert.PatternMatch(pattern, id) {
+			silences = append(silences, sil.Silence)
+		}
+	}
+	return silences, nil
+}
+
+// DeleteSilence deletes a silence, the matching events are no longer silenced.
+func (s *Service) DeleteSilence(id string) error {
+	s.silencesMu.Lock()
+	defer s.silencesMu.Unlock()
+	if err := s.silencesDAO.Delete(id); err != nil {
+		return err
+	}
+	delete(s.silences, id)
+	return nil
+}
+
+// pruneSilences deletes the silences that expired before now from memory and storage.
+// The silences lock must be held.
+func (s *Service) pruneSilences(now time.Time) {
+	for id, sil := range s.silences {
+		if now.Before(sil.ExpiresAt) {
+			continue
+		}
+		if err := s.silencesDAO.Delete(id); err != nil {
+			s.diag.Error("failed to delete expired silence", err, keyvalue.KV("silence", id))
+			continue
+		}
+		delete(s.silences, id)
+	}
+}
+
+// UpdateInhibitions tracks the event as a source of the inhibitions.
+func (s *Service) UpdateInhibitions(event alert.Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, in := range s.inhibitions {
+		in.update(event)
+	}
+}
+
+// restoreInhibitionSources tracks the events of the persisted topic state as sources of the inhibitions,
+// since the sources are only kept in memory.
+func (s *Service) restoreInhibitionSources(inhibitions ...*inhibition) error {
+	if len(inhibitions) == 0 || !s.PersistTopics {
+		return nil
+	}
+	return WalkTopicBuckets(s.topicsStore, func(tx storage.ReadOnlyTx, topic string) error {
+		kvs, err := tx.Bucket([]byte(topic)).List("")
+		if err != nil {
+			return err
+		}
+		es := &EventState{}
+		for _, kv := range kvs {
+			es.Reset()
+			if err := es.UnmarshalJSON(kv.Value); err != nil {
+				return err
+			}
+			event := alert.Event{
+				Topic: topic,
+				State: alert.EventState{
+					ID:    kv.Key,
+					Level: es.Level,
+				},
+				Data: alert.EventData{
+					Category: es.Category,
+					Tags:     es.Tags,
+				},
+			}
+			for _, in := range inhibitions {
+				in.update(event)
+			}
+		}
+		return nil
+	})
+}
+
+// CreateInhibition persists a new inhibition.
+// The inhibition is active while a collected alert matching its source is firing.
+func (s *Service) CreateInhibition(spec Inhibition) error {
+	in, err := newInhibition(spec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.inhibitionsDAO.Create(spec); err != nil {
+		return err
+	}
+	if err := s.restoreInhibitionSources(in); err != nil {
+		s.diag.Error("failed to restore the sources of the inhibition", err, keyvalue.KV("inhibition", spec.ID))
+	}
+	s.inhibitions[spec.ID] = in
+	return nil
+}
+
+// ReplaceInhibition replaces an existing inhibition.
+// The active sources are kept if the source and equal tags did not change.
+func (s *Service) ReplaceInhibition(spec Inhibition) error {
+	in, err := newInhibition(spec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.inhibitionsDAO.Replace(spec); err != nil {
+		return err
+	}
+	if old, ok := s.inhibitions[spec.ID]; ok &&
+		reflect.DeepEqual(old.Source, spec.Source) && reflect.DeepEqual(old.Equal, spec.Equal) {
+		old.mu.RLock()
+		for id, key := range old.sources {
+			in.sources[id] = key
+			in.active[key]++
+		}
+		old.mu.RUnlock()
+	} else if err := s.restoreInhibitionSources(in); err != nil {
+		s.diag.Error("failed to restore the sources of the inhibition", err, keyvalue.KV("inhibition", spec.ID))
+	}
+	s.inhibitions[spec.ID] = in
+	return nil
+}
+
+// Inhibition returns an inhibition.
+func (s *Service) Inhibition(id string) (Inhibition, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	in, ok := s.inhibitions[id]
+	if !ok {
+		return Inhibition{}, false, nil
+	}
+	return in.Inhibition, true, nil
+}
+
+// Inhibitions returns the inhibitions whose ID matches the pattern.
+func (s *Service) Inhibitions(pattern string) ([]Inhibition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	inhibitions := make([]Inhibition, 0, len(s.inhibitions))
+	for id, in := range s.inhibitions {
+		if alert.PatternMatch(pattern, id) {
+			inhibitions = append(inhibitions, in.Inhibition)
+		}
+	}
+	return inhibitions, nil
+}
+
+// DeleteInhibition deletes an inhibition, the matching alerts are no longer inhibited.
+func (s *Service) DeleteInhibition(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.inhibitionsDAO.Delete(id); err != nil {
+		return err
+	}
+	delete(s.inhibitions, id)
+	return nil
+}
+
+// tagMatchers are compiled tag matchers.
+type tagMatchers struct {
+	matchers []Matcher
+	// regexes of the matchers, nil for the matchers of exact values.
+	regexes []*regexp.Regexp
+}
+
+func newTagMatchers(matchers []Matcher) (tagMatchers, error) {
+	tm := tagMatchers{
+		matchers: matchers,
+		regexes:  make([]*regexp.Regexp, len(matchers)),
+	}
+	for i, m := range matchers {
+		if m.Regex {
+			// Regular expressions must match the entire value.
+			r, err := regexp.Compile("^(?:" + m.Value + ")$")
+			if err != nil {
+				return tagMatchers{}, err
+			}
+			tm.regexes[i] = r
+		}
+	}
+	return tm, nil
+}
+
+// match reports whether all the matchers match the tags.
+func (tm tagMatchers) match(tags map[string]string) bool {
+	for i, m := range tm.matchers {
+		v, ok := tags[m.Name]
+		if !ok {
+			return false
+		}
+		if r := tm.regexes[i]; r != nil {
+			if !r.MatchString(v) {
+				return false
+			}
+		} else if v != m.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// silence is a Silence with its compiled matchers.
+type silence struct {
+	Silence
+	tags tagMatchers
+}
+
+func newSilence(spec Silence) (*silence, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	tags, err := newTagMatchers(spec.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	return &silence{
+		Silence: spec,
+		tags:    tags,
+	}, nil
+}
+
+// matches reports whether the silence is active at time now and matches the event.
+func (s *silence) matches(event alert.Event, now time.Time) bool {
+	if !now.Before(s.ExpiresAt) || !alert.PatternMatch(s.Topic, event.Topic) {
+		return false
+	}
+	return s.tags.match(event.Data.Tags)
+}
+
+// inhibition is an Inhibition with its compiled matchers and its active sources.
+type inhibition struct {
+	Inhibition
+	source tagMatchers
+	target tagMatchers
+
+	mu sync.RWMutex
+	// sources is the values of the equal tags of each active source, keyed by category and event ID,
+	// so that the copies of an event in several topics are a single source.
+	sources map[string]string
+	// active is the number of active sources for each set of values of the equal tags.
+	active map[string]int
+}
+
+func newInhibition(spec Inhibition) (*inhibition, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	source, err := newTagMatchers(spec.Source.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	target, err := newTagMatchers(spec.Target.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	return &inhibition{
+		Inhibition: spec,
+		source:     source,
+		target:     target,
+		sources:    make(map[string]string),
+		active:     make(map[string]int),
+	}, nil
+}
+
+// equalKey returns the values of the equal tags.
+func (i *inhibition) equalKey(tags map[string]string) string {
+	values := make([]string, len(i.Equal))
+	for idx, t := range i.Equal {
+		values[idx] = tags[t]
+	}
+	return strings.Join(values, "\x00")
+}
+
+// matchesSource reports whether an alert with the category and tags matches the source of the inhibition.
+func (i *inhibition) matchesSource(category string, tags map[string]string) bool {
+	return (i.Source.Category == "" || i.Source.Category == category) && i.source.match(tags)
+}
+
+// update tracks whether the event is an active source of the inhibition.
+func (i *inhibition) update(event alert.Event) {
+	active := event.State.Level != alert.OK && i.matchesSource(event.Data.Category, event.Data.Tags)
+	id := event.Data.Category + "/" + event.State.ID
+
+	i.mu.RLock()
+	prev, wasActive := i.sources[id]
+	i.mu.RUnlock()
+	if !active && !wasActive {
+		// Most events are not sources, avoid the write lock.
+		return
+	}
+	key := i.equalKey(event.Data.Tags)
+	if active && wasActive && prev == key {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if prev, ok := i.sources[id]; ok {
+		delete(i.sources, id)
+		if i.active[prev]--; i.active[prev] <= 0 {
+			delete(i.active, prev)
+		}
+	}
+	if active {
+		i.sources[id] = key
+		i.active[key]++
+	}
+}
+
+// inhibits reports whether an alert with the category and tags is inhibited.
+// An alert matching both the source and the target is not inhibited,
+// so that an alert never inhibits itself.
+func (i *inhibition) inhibits(category string, tags map[string]string) bool {
+	if i.Target.Category != "" && i.Target.Category != category {
+		return false
+	}
+	if !i.target.match(tags) || i.matchesSource(category, tags) {
+		return false
+	}
+	key := i.equalKey(tags)
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.active[key] > 0
+}
+
 func (s *Service) RegisterAnonHandler(topic string, h alert.Handler) {
 	s.topics.RegisterHandler(topic, h)
 }
@@ -735,6 +1306,9 @@ func (s *Service) UpdateHandlerSpec(oldSpec, newSpec HandlerSpec) error {
 	s.setTopicHandler(newSpec.Topic, newSpec.ID, newH)
 
 	s.topics.ReplaceHandler(topic, oldH.Handler, newH.Handler)
+	if ha, ok := oldH.Handler.(closer); ok {
+		ha.Close()
+	}
 	return nil
 }
 
@@ -903,6 +1477,48 @@ func (s *Service) createHandlerFromSpec(spec HandlerSpec) (handler, error) {
 			return handler{}, err
 		}
 		h = newExternalHandler(h)
+	case "escalation":
+		c := newDefaultEscalationHandlerConfig(spec.Topic, s)
+		err = decodeOptions(spec.Options, &c)
+		if err != nil {
+			return handler{}, err
+		}
+		steps := make([]alert.Handler, 0, len(c.Steps))
+		// closeSteps closes the handlers of the steps built so far when the escalation cannot be created.
+		closeSteps := func() {
+			for _, sh := range steps {
+				if ha, ok := sh.(closer); ok {
+					ha.Close()
+				}
+			}
+		}
+		for i, step := range c.Steps {
+			if step.Kind == "escalation" {
+				closeSteps()
+				return handler{}, fmt.Errorf("escalation step %d cannot be an escalation", i)
+			}
+			sh, err := s.createHandlerFromSpec(HandlerSpec{
+				ID:      fmt.Sprintf("%s-step-%d", spec.ID, i),
+				Topic:   spec.Topic,
+				Kind:    step.Kind,
+				Options: step.Options,
+			})
+			if err != nil {
+				closeSteps()
+				return handler{}, errors.Wrapf(err, "invalid escalation step %d", i)
+			}
+			if sh.Handler == nil {
+				closeSteps()
+				return handler{}, fmt.Errorf("escalation step %d uses disabled handler %q", i, step.Kind)
+			}
+			steps = append(steps, sh.Handler)
+		}
+		handlerDiag := s.diag.WithHandlerContext(ctx...)
+		h, err = NewEscalationHandler(c, steps, handlerDiag)
+		if err != nil {
+			closeSteps()
+			return handler{}, err
+		}
 	case "exec":
 		c := ExecHandlerConfig{
 			Commander: s.Commander,
@@ -914,6 +1530,32 @@ func (s *Service) createHandlerFromSpec(spec HandlerSpec) (handler, error) {
 		handlerDiag := s.diag.WithHandlerContext(ctx...)
 		h = NewExecHandler(c, handlerDiag)
 		h = newExternalHandler(h)
+	case "group":
+		c := newDefaultGroupHandlerConfig(spec.ID)
+		err = decodeOptions(spec.Options, &c)
+		if err != nil {
+			return handler{}, err
+		}
+		if c.Kind == "group" || c.Kind == "escalation" {
+			return handler{}, fmt.Errorf("group cannot pass its notifications to a %s handler", c.Kind)
+		}
+		gh, err := s.createHandlerFromSpec(HandlerSpec{
+			ID:      spec.ID + "-grouped",
+			Topic:   spec.Topic,
+			Kind:    c.Kind,
+			Options: c.Options,
+		})
+		if err != nil {
+			return handler{}, errors.Wrap(err, "invalid group handler")
+		}
+		if gh.Handler == nil {
+			return handler{}, fmt.Errorf("group uses disabled handler %q", c.Kind)
+		}
+		handlerDiag := s.diag.WithHandlerContext(ctx...)
+		h, err = NewGroupHandler(c, gh.Handler, handlerDiag)
+		if err != nil {
+			return handler{}, err
+		}
 	case "hipchat":
 		c := hipchat.HandlerConfig{}
 		err = decodeOptions(spec.Options, &c)
@@ -1132,7 +1774,17 @@ func (s *Service) createHandlerFromSpec(spec HandlerSpec) (handler, error) {
 }
 
 func (s *Service) IsInhibited(name string, tags models.Tags) bool {
-	return s.inhibitorLookup.IsInhibited(name, tags)
+	if s.inhibitorLookup.IsInhibited(name, tags) {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, in := range s.inhibitions {
+		if in.inhibits(name, tags) {
+			return true
+		}
+	}
+	return false
 }
 func (s *Service) AddInhibitor(in *alert.Inhibitor) {
 	s.inhibitorLookup.AddInhibitor(in)
@@ -31,6 +31,47 @@ type Topics interface {
 	// EventStates returns the current state of events for the specified topic.
 	// Only events greater or equal to minLevel will be returned
 	EventStates(topic string, minLevel alert.Level) (map[string]alert.EventState, error)
+
+	// IsSilenced reports whether an active silence matches the event.
+	IsSilenced(event alert.Event) bool
+}
+
+// EventAcknowledger is responsible for acknowledging events.
+type EventAcknowledger interface {
+	// AckEvent acknowledges the current state of the event, and returns the new state and if the event exists.
+	AckEvent(topic, event, author string) (alert.EventState, bool, error)
+}
+
+// Silencer is responsible for managing and persisting silences.
+type Silencer interface {
+	// CreateSilence saves the silence and starts silencing the matching events.
+	CreateSilence(s Silence) (Silence, error)
+	// Silence returns a silence.
+	Silence(id string) (Silence, bool, error)
+	// Silences returns the silences whose ID matches the pattern.
+	Silences(pattern string) ([]Silence, error)
+	// DeleteSilence deletes the silence.
+	DeleteSilence(id string) error
+}
+
+// Inhibitor is responsible for managing and persisting inhibitions.
+type Inhibitor interface {
+	// CreateInhibition saves the inhibition and starts tracking its sources.
+	CreateInhibition(i Inhibition) error
+	// ReplaceInhibition replaces an existing inhibition.
+	ReplaceInhibition(i Inhibition) error
+	// Inhibition returns an inhibition.
+	Inhibition(id string) (Inhibition, bool, error)
+	// Inhibitions returns the inhibitions whose ID matches the pattern.
+	Inhibitions(pattern string) ([]Inhibition, error)
+	// DeleteInhibition deletes the inhibition.
+	DeleteInhibition(id string) error
+}
+
+// History is responsible for querying the alert history.
+type History interface {
+	// AlertHistory returns the level changes of the events matching the query, sorted by time.
+	AlertHistory(q HistoryQuery) ([]HistoryEntry, error)
 }
 
 // AnonHandlerRegistrar is responsible for directly registering handlers for anonymous topics.
@@ -71,6 +112,12 @@ type handler struct {
 	Handler alert.Handler
 }
 
+// InhibitionUpdater tracks the sources of the inhibitions.
+type InhibitionUpdater interface {
+	// UpdateInhibitions tracks the event as a source of the inhibitions.
+	UpdateInhibitions(event alert.Event)
+}
+
 // InhibitorLookup provides lookup access to inhibitors
 type InhibitorLookup interface {
 	IsInhibited(name string, tags models.Tags) bool
@@ -0,0 +1,82 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/pkg/errors"
+)
+
+// HolidayLayout is the layout of the dates of holidays.
+const HolidayLayout = "2006-01-02"
+
+// Calendar is a set of recurring time windows in a time zone.
+// A window starts each time its cron expression matches and lasts for its duration.
+type Calendar struct {
+	name     string
+	location *time.Location
+	windows  []window
+	holidays map[string]bool
+}
+
+type window struct {
+	expr     *cronexpr.Expression
+	duration time.Duration
+}
+
+// New creates a calendar from its spec.
+func New(spec Spec) (*Calendar, error) {
+	location, err := time.LoadLocation(spec.Timezone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid timezone %q", spec.Timezone)
+	}
+	c := &Calendar{
+		name:     spec.Name,
+		location: location,
+		windows:  make([]window, len(spec.Windows)),
+		holidays: make(map[string]bool, len(spec.Holidays)),
+	}
+	for i, w := range spec.Windows {
+		if w.Duration <= 0 {
+			return nil, fmt.Errorf("duration of window %q must be positive", w.Cron)
+		}
+		expr, err := cronexpr.Parse(w.Cron)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cron expression %q", w.Cron)
+		}
+		c.windows[i] = window{
+			expr:     expr,
+			duration: w.Duration,
+		}
+	}
+	for _, h := range spec.Holidays {
+		if _, err := time.ParseInLocation(HolidayLayout, h, location); err != nil {
+			return nil, errors.Wrapf(err, "invalid holiday %q", h)
+		}
+		c.holidays[h] = true
+	}
+	return c, nil
+}
+
+// Name returns the name of the calendar.
+func (c *Calendar) Name() string {
+	return c.name
+}
+
+// Active reports whether t falls in a window of the calendar.
+// A calendar is never active on its holidays.
+func (c *Calendar) Active(t time.Time) bool {
+	t = t.In(c.location)
+	if c.holidays[t.Format(HolidayLayout)] {
+		return false
+	}
+	for _, w := range c.windows {
+		// The window contains t if it starts in (t-duration, t].
+		start := w.expr.Next(t.Add(-w.duration))
+		if !start.IsZero() && !start.After(t) {
+			return true
+		}
+	}
+	return false
+}
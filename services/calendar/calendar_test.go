@@ -0,0 +1,108 @@
+package calendar_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/kapacitor/services/calendar"
+)
+
+func TestCalendar_Active(t *testing.T) {
+	c, err := calendar.New(calendar.Spec{
+		Name:     "maintenance",
+		Timezone: "Europe/Paris",
+		Windows: []calendar.WindowSpec{
+			// Saturday night, across midnight
+			{Cron: "0 23 * * 6", Duration: 3 * time.Hour},
+			// First day of the month
+			{Cron: "30 12 1 * *", Duration: 30 * time.Minute},
+		},
+		Holidays: []string{"2024-03-31"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		t   time.Time
+		exp bool
+	}{
+		{t: time.Date(2024, 1, 6, 22, 59, 59, 0, paris), exp: false},
+		{t: time.Date(2024, 1, 6, 23, 0, 0, 0, paris), exp: true},
+		{t: time.Date(2024, 1, 7, 1, 59, 59, 0, paris), exp: true},
+		{t: time.Date(2024, 1, 7, 2, 0, 0, 0, paris), exp: false},
+		// The time zone of the time does not matter
+		{t: time.Date(2024, 1, 6, 22, 30, 0, 0, time.UTC), exp: true},
+		{t: time.Date(2024, 2, 1, 12, 45, 0, 0, paris), exp: true},
+		{t: time.Date(2024, 2, 1, 13, 0, 0, 0, paris), exp: false},
+		// Holiday
+		{t: time.Date(2024, 3, 31, 0, 30, 0, 0, paris), exp: false},
+	}
+	for _, tc := range testCases {
+		if got := c.Active(tc.t); got != tc.exp {
+			t.Errorf("unexpected active at %v: got %v exp %v", tc.t, got, tc.exp)
+		}
+	}
+}
+
+func TestSpec_Validate(t *testing.T) {
+	testCases := []struct {
+		spec  calendar.Spec
+		valid bool
+	}{
+		{
+			spec: calendar.Spec{
+				Name:     "ok",
+				Timezone: "UTC",
+				Windows:  []calendar.WindowSpec{{Cron: "0 9 * * 1-5", Duration: time.Hour}},
+			},
+			valid: true,
+		},
+		{
+			spec: calendar.Spec{
+				Name:     "bad name",
+				Timezone: "UTC",
+				Windows:  []calendar.WindowSpec{{Cron: "0 9 * * 1-5", Duration: time.Hour}},
+			},
+		},
+		{
+			spec: calendar.Spec{
+				Name:     "no-windows",
+				Timezone: "UTC",
+			},
+		},
+		{
+			spec: calendar.Spec{
+				Name:     "bad-cron",
+				Timezone: "UTC",
+				Windows:  []calendar.WindowSpec{{Cron: "0 9 * *", Duration: time.Hour}},
+			},
+		},
+		{
+			spec: calendar.Spec{
+				Name:     "bad-timezone",
+				Timezone: "Nowhere/City",
+				Windows:  []calendar.WindowSpec{{Cron: "0 9 * * 1-5", Duration: time.Hour}},
+			},
+		},
+		{
+			spec: calendar.Spec{
+				Name:     "bad-holiday",
+				Timezone: "UTC",
+				Windows:  []calendar.WindowSpec{{Cron: "0 9 * * 1-5", Duration: time.Hour}},
+				Holidays: []string{"12/25/2024"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.spec.Validate()
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.spec.Name, err)
+		} else if !tc.valid && err == nil {
+			t.Errorf("%s: expected error", tc.spec.Name)
+		}
+	}
+}
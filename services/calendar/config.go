@@ -0,0 +1,74 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb/toml"
+)
+
+// Config is the configuration for a single [[calendar]] section of the kapacitor
+// configuration file.
+type Config struct {
+	// The name of the calendar, referenced by tasks.
+	Name string `toml:"name"`
+	// The time zone of the windows and holidays.
+	// Default: UTC
+	Timezone string `toml:"timezone"`
+	// The recurring windows of the calendar.
+	Windows []WindowConfig `toml:"window"`
+	// The dates on which the calendar is not active, formatted as YYYY-MM-DD.
+	Holidays []string `toml:"holidays"`
+}
+
+// WindowConfig is the configuration for a single [[calendar.window]] section.
+type WindowConfig struct {
+	// The cron expression matching the start of the window.
+	Cron string `toml:"cron"`
+	// The duration of the window.
+	Duration toml.Duration `toml:"duration"`
+}
+
+// Spec returns the spec of the configured calendar.
+func (c Config) Spec() Spec {
+	s := Spec{
+		Name:     c.Name,
+		Timezone: c.Timezone,
+		Windows:  make([]WindowSpec, len(c.Windows)),
+		Holidays: c.Holidays,
+	}
+	if s.Timezone == "" {
+		s.Timezone = "UTC"
+	}
+	for i, w := range c.Windows {
+		s.Windows[i] = WindowSpec{
+			Cron:     w.Cron,
+			Duration: time.Duration(w.Duration),
+		}
+	}
+	return s
+}
+
+func (c Config) Validate() error {
+	return c.Spec().Validate()
+}
+
+// Configs is the configuration for all [[calendar]] sections of the kapacitor
+// configuration file.
+type Configs []Config
+
+// Validate calls config.Validate for each element in Configs
+// and checks that the names are unique.
+func (cs Configs) Validate() error {
+	names := make(map[string]bool, len(cs))
+	for _, c := range cs {
+		if names[c.Name] {
+			return fmt.Errorf("duplicate name %q for calendar configs", c.Name)
+		}
+		names[c.Name] = true
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package calendar
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/influxdata/kapacitor/services/storage"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrCalendarExists   = errors.New("calendar already exists")
+	ErrNoCalendarExists = errors.New("no calendar exists")
+)
+
+// Data access object for calendar specs.
+type SpecDAO interface {
+	// Retrieve a calendar
+	Get(name string) (Spec, error)
+
+	// Create a calendar.
+	// ErrCalendarExists is returned if a calendar already exists with the same name.
+	Create(s Spec) error
+
+	// Replace an existing calendar.
+	// ErrNoCalendarExists is returned if the calendar does not exist.
+	Replace(s Spec) error
+
+	// Delete a calendar.
+	// It is not an error to delete an non-existent calendar.
+	Delete(name string) error
+
+	// List calendars matching a pattern.
+	// The pattern is shell/glob matching see https://golang.org/pkg/path/#Match
+	// Offset and limit are pagination bounds. Offset is inclusive starting at index 0.
+	// More results may exist while the number of returned items is equal to limit.
+	List(pattern string, offset, limit int) ([]Spec, error)
+
+	Rebuild() error
+}
+
+//--------------------------------------------------------------------
+// The following structures are stored in a database via JSON encoding.
+// Changes to the structures could break existing data.
+
+const (
+	specVersion1 = 1
+)
+
+// Spec provides all the necessary information to create a calendar.
+type Spec struct {
+	Name     string       `json:"name"`
+	Timezone string       `json:"timezone"`
+	Windows  []WindowSpec `json:"windows"`
+	Holidays []string     `json:"holidays"`
+}
+
+// WindowSpec is a window that starts each time the cron expression matches and lasts for the duration.
+type WindowSpec struct {
+	Cron     string        `json:"cron"`
+	Duration time.Duration `json:"duration"`
+}
+
+var validName = regexp.MustCompile(`^[-\._\p{L}0-9]+$`)
+
+func (s Spec) Validate() error {
+	if !validName.MatchString(s.Name) {
+		return fmt.Errorf("calendar name must contain only letters, numbers, '-', '.' and '_'. %q", s.Name)
+	}
+	if len(s.Windows) == 0 {
+		return fmt.Errorf("calendar %q must have at least one window", s.Name)
+	}
+	if _, err := New(s); err != nil {
+		return errors.Wrapf(err, "invalid calendar %q", s.Name)
+	}
+	return nil
+}
+
+func (s Spec) ObjectID() string {
+	return s.Name
+}
+
+func (s Spec) MarshalBinary() ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid spec")
+	}
+	return storage.VersionJSONEncode(specVersion1, s)
+}
+
+func (s *Spec) UnmarshalBinary(data []byte) error {
+	return storage.VersionJSONDecode(data, func(version int, dec *json.Decoder) error {
+		switch version {
+		case specVersion1:
+			return dec.Decode(s)
+		default:
+			return fmt.Errorf("unknown spec version %d: cannot decode", version)
+		}
+	})
+}
+
+// Key/Value store based implementation of the SpecDAO
+type specKV struct {
+	store *storage.IndexedStore
+}
+
+const (
+	calendarPrefix = "calendars"
+)
+
+func newSpecKV(store storage.Interface) (*specKV, error) {
+	c := storage.DefaultIndexedStoreConfig(calendarPrefix, func() storage.BinaryObject {
+		return new(Spec)
+	})
+	istore, err := storage.NewIndexedStore(store, c)
+	if err != nil {
+		return nil, err
+	}
+	return &specKV{
+		store: istore,
+	}, nil
+}
+
+func (kv *specKV) error(err error) error {
+	if err == storage.ErrObjectExists {
+		return ErrCalendarExists
+	} else if err == storage.ErrNoObjectExists {
+		return ErrNoCalendarExists
+	}
+	return err
+}
+
+func (kv *specKV) Get(name string) (Spec, error) {
+	o, err := kv.store.Get(name)
+	if err != nil {
+		return Spec{}, kv.error(err)
+	}
+	s, ok := o.(*Spec)
+	if !ok {
+		return Spec{}, storage.ImpossibleTypeErr(s, o)
+	}
+	return *s, nil
+}
+
+func (kv *specKV) Create(s Spec) error {
+	return kv.error(kv.store.Create(&s))
+}
+
+func (kv *specKV) Replace(s Spec) error {
+	return kv.error(kv.store.Replace(&s))
+}
+
+func (kv *specKV) Delete(name string) error {
+	return kv.error(kv.store.Delete(name))
+}
+
+func (kv *specKV) List(pattern string, offset, limit int) ([]Spec, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+	objects, err := kv.store.List(storage.DefaultIDIndex, pattern, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	specs := make([]Spec, len(objects))
+	for i, o := range objects {
+		s, ok := o.(*Spec)
+		if !ok {
+			return nil, storage.ImpossibleTypeErr(s, o)
+		}
+		specs[i] = *s
+	}
+	return specs, nil
+}
+
+func (kv *specKV) Rebuild() error {
+	return kv.store.Rebuild()
+}
@@ -0,0 +1,420 @@
+package calendar
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	client "github.com/influxdata/kapacitor/client/v1"
+	"github.com/influxdata/kapacitor/services/httpd"
+	"github.com/influxdata/kapacitor/services/storage"
+	"github.com/pkg/errors"
+)
+
+const (
+	calendarsPath             = "/calendars"
+	calendarsPathAnchored     = "/calendars/"
+	calendarsBasePathAnchored = httpd.BasePath + calendarsPathAnchored
+
+	calendarsAPIName = "calendars"
+
+	// Public name of the calendar store
+	CalendarNameSpace = "calendar_store"
+)
+
+type Diagnostic interface {
+	Error(msg string, err error)
+}
+
+// Service manages the named calendars.
+// Calendars are either defined in the configuration file or stored via the HTTP API.
+// Configured calendars take precedence and cannot be modified via the API.
+type Service struct {
+	diag    Diagnostic
+	configs Configs
+	routes  []httpd.Route
+
+	mu         sync.RWMutex
+	configured map[string]*Calendar
+	stored     map[string]*Calendar
+
+	specs SpecDAO
+
+	StorageService interface {
+		Store(namespace string) storage.Interface
+		Register(name string, store storage.StoreActioner)
+	}
+	HTTPDService interface {
+		AddRoutes([]httpd.Route) error
+		DelRoutes([]httpd.Route)
+	}
+}
+
+func NewService(c Configs, d Diagnostic) *Service {
+	return &Service{
+		diag:       d,
+		configs:    c,
+		configured: make(map[string]*Calendar, len(c)),
+		stored:     make(map[string]*Calendar),
+	}
+}
+
+func (s *Service) Open() error {
+	for _, c := range s.configs {
+		cal, err := New(c.Spec())
+		if err != nil {
+			return errors.Wrapf(err, "invalid calendar %q", c.Name)
+		}
+		s.configured[c.Name] = cal
+	}
+
+	store := s.StorageService.Store(CalendarNameSpace)
+	specs, err := newSpecKV(store)
+	if err != nil {
+		return err
+	}
+	s.specs = specs
+	s.StorageService.Register(calendarsAPIName, s.specs)
+
+	if err := s.loadSavedCalendars(); err != nil {
+		return err
+	}
+
+	// Define API routes
+	s.routes = []httpd.Route{
+		{
+			Method:      "GET",
+			Pattern:     calendarsPathAnchored,
+			HandlerFunc: s.handleCalendar,
+		},
+		{
+			Method:      "PUT",
+			Pattern:     calendarsPathAnchored,
+			HandlerFunc: s.handleReplaceCalendar,
+		},
+		{
+			Method:      "DELETE",
+			Pattern:     calendarsPathAnchored,
+			HandlerFunc: s.handleDeleteCalendar,
+		},
+		{
+			// Satisfy CORS checks.
+			Method:      "OPTIONS",
+			Pattern:     calendarsPathAnchored,
+			HandlerFunc: httpd.ServeOptions,
+		},
+		{
+			Method:      "GET",
+			Pattern:     calendarsPath,
+			HandlerFunc: s.handleListCalendars,
+		},
+		{
+			Method:      "POST",
+			Pattern:     calendarsPath,
+			HandlerFunc: s.handleCreateCalendar,
+		},
+	}
+
+	return errors.Wrap(s.HTTPDService.AddRoutes(s.routes), "failed to add API routes")
+}
+
+func (s *Service) Close() error {
+	if s.HTTPDService != nil {
+		s.HTTPDService.DelRoutes(s.routes)
+	}
+	return nil
+}
+
+func (s *Service) loadSavedCalendars() error {
+	offset := 0
+	limit := 100
+	for {
+		specs, err := s.specs.List("*", offset, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, spec := range specs {
+			cal, err := New(spec)
+			if err != nil {
+				s.diag.Error("failed to load calendar on startup", err)
+				continue
+			}
+			s.stored[spec.Name] = cal
+		}
+
+		offset += limit
+		if len(specs) != limit {
+			break
+		}
+	}
+	return nil
+}
+
+// Calendar returns the named calendar.
+func (s *Service) Calendar(name string) (*Calendar, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if cal, ok := s.configured[name]; ok {
+		return cal, nil
+	}
+	if cal, ok := s.stored[name]; ok {
+		return cal, nil
+	}
+	return nil, fmt.Errorf("unknown calendar %q", name)
+}
+
+// Active reports whether the named calendar is active at time t.
+func (s *Service) Active(name string, t time.Time) (bool, error) {
+	cal, err := s.Calendar(name)
+	if err != nil {
+		return false, err
+	}
+	return cal.Active(t), nil
+}
+
+func (s *Service) isConfigured(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.configured[name]
+	return ok
+}
+
+func (s *Service) saveCalendar(spec Spec, replace bool) error {
+	cal, err := New(spec)
+	if err != nil {
+		return err
+	}
+	if replace {
+		err = s.specs.Replace(spec)
+	} else {
+		err = s.specs.Create(spec)
+	}
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.stored[spec.Name] = cal
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Service) deleteCalendar(name string) error {
+	if err := s.specs.Delete(name); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.stored, name)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Service) nameFromPath(p string) (string, error) {
+	if len(p) <= len(calendarsBasePathAnchored) {
+		return "", errors.New("must specify calendar name on path")
+	}
+	return p[len(calendarsBasePathAnchored):], nil
+}
+
+func (s *Service) calendarLink(name string) client.Link {
+	return client.Link{Relation: client.Self, Href: path.Join(httpd.BasePath, calendarsPath, name)}
+}
+
+func (s *Service) convertToClientCalendar(spec Spec, configured bool) client.Calendar {
+	windows := make([]client.CalendarWindow, len(spec.Windows))
+	for i, w := range spec.Windows {
+		windows[i] = client.CalendarWindow{
+			Cron:     w.Cron,
+			Duration: client.Duration(w.Duration),
+		}
+	}
+	return client.Calendar{
+		Link:       s.calendarLink(spec.Name),
+		Name:       spec.Name,
+		Timezone:   spec.Timezone,
+		Windows:    windows,
+		Holidays:   spec.Holidays,
+		Configured: configured,
+	}
+}
+
+func convertFromClientCalendar(opt client.CalendarOptions) Spec {
+	spec := Spec{
+		Name:     opt.Name,
+		Timezone: opt.Timezone,
+		Windows:  make([]WindowSpec, len(opt.Windows)),
+		Holidays: opt.Holidays,
+	}
+	if spec.Timezone == "" {
+		spec.Timezone = "UTC"
+	}
+	for i, w := range opt.Windows {
+		spec.Windows[i] = WindowSpec{
+			Cron:     w.Cron,
+			Duration: time.Duration(w.Duration),
+		}
+	}
+	return spec
+}
+
+func (s *Service) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	name, err := s.nameFromPath(r.URL.Path)
+	if err != nil {
+		httpd.HttpError(w, err.Error(), true, http.StatusBadRequest)
+		return
+	}
+	for _, c := range s.configs {
+		if c.Name == name {
+			w.Write(httpd.MarshalJSON(s.convertToClientCalendar(c.Spec(), true), true))
+			return
+		}
+	}
+	spec, err := s.specs.Get(name)
+	if err != nil {
+		if err == ErrNoCalendarExists {
+			httpd.HttpError(w, err.Error(), true, http.StatusNotFound)
+			return
+		}
+		httpd.HttpError(w, err.Error(), true, http.StatusInternalServerError)
+		return
+	}
+	w.Write(httpd.MarshalJSON(s.convertToClientCalendar(spec, false), true))
+}
+
+func (s *Service) handleCreateCalendar(w http.ResponseWriter, r *http.Request) {
+	opt := client.CalendarOptions{}
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(&opt); err != nil {
+		httpd.HttpError(w, "invalid JSON", true, http.StatusBadRequest)
+		return
+	}
+	s.putCalendar(w, convertFromClientCalendar(opt), false)
+}
+
+func (s *Service) handleReplaceCalendar(w http.ResponseWriter, r *http.Request) {
+	name, err := s.nameFromPath(r.URL.Path)
+	if err != nil {
+		httpd.HttpError(w, err.Error(), true, http.StatusBadRequest)
+		return
+	}
+	opt := client.CalendarOptions{}
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(&opt); err != nil {
+		httpd.HttpError(w, "invalid JSON", true, http.StatusBadRequest)
+		return
+	}
+	if opt.Name == "" {
+		opt.Name = name
+	}
+	if opt.Name != name {
+		httpd.HttpError(w, "cannot rename a calendar", true, http.StatusBadRequest)
+		return
+	}
+	s.putCalendar(w, convertFromClientCalendar(opt), true)
+}
+
+func (s *Service) putCalendar(w http.ResponseWriter, spec Spec, replace bool) {
+	if err := spec.Validate(); err != nil {
+		httpd.HttpError(w, err.Error(), true, http.StatusBadRequest)
+		return
+	}
+	if s.isConfigured(spec.Name) {
+		httpd.HttpError(w, fmt.Sprintf("calendar %q is defined in the configuration and cannot be modified", spec.Name), true, http.StatusBadRequest)
+		return
+	}
+	if err := s.saveCalendar(spec, replace); err != nil {
+		switch err {
+		case ErrCalendarExists:
+			httpd.HttpError(w, err.Error(), true, http.StatusConflict)
+		case ErrNoCalendarExists:
+			httpd.HttpError(w, err.Error(), true, http.StatusNotFound)
+		default:
+			httpd.HttpError(w, fmt.Sprintf("failed to save calendar: %s", err.Error()), true, http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Write(httpd.MarshalJSON(s.convertToClientCalendar(spec, false), true))
+}
+
+func (s *Service) handleDeleteCalendar(w http.ResponseWriter, r *http.Request) {
+	name, err := s.nameFromPath(r.URL.Path)
+	if err != nil {
+		httpd.HttpError(w, err.Error(), true, http.StatusBadRequest)
+		return
+	}
+	if s.isConfigured(name) {
+		httpd.HttpError(w, fmt.Sprintf("calendar %q is defined in the configuration and cannot be deleted", name), true, http.StatusBadRequest)
+		return
+	}
+	if err := s.deleteCalendar(name); err != nil {
+		httpd.HttpError(w, fmt.Sprintf("failed to delete calendar: %s", err.Error()), true, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Service) handleListCalendars(w http.ResponseWriter, r *http.Request) {
+	pattern := r.URL.Query().Get("pattern")
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	var err error
+	offset := int64(0)
+	offsetStr := r.URL.Query().Get("offset")
+	if offsetStr != "" {
+		offset, err = strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil {
+			httpd.HttpError(w, fmt.Sprintf("invalid offset parameter %q must be an integer: %s", offsetStr, err), true, http.StatusBadRequest)
+			return
+		}
+	}
+
+	limit := int64(100)
+	limitStr := r.URL.Query().Get("limit")
+	if limitStr != "" {
+		limit, err = strconv.ParseInt(limitStr, 10, 64)
+		if err != nil {
+			httpd.HttpError(w, fmt.Sprintf("invalid limit parameter %q must be an integer: %s", limitStr, err), true, http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Configured calendars are listed first
+	calendars := make([]client.Calendar, 0)
+	for _, c := range s.configs {
+		if match, _ := path.Match(pattern, c.Name); match {
+			calendars = append(calendars, s.convertToClientCalendar(c.Spec(), true))
+		}
+	}
+	sort.Slice(calendars, func(i, j int) bool {
+		return calendars[i].Name < calendars[j].Name
+	})
+	specs, err := s.specs.List(pattern, 0, int(offset+limit))
+	if err != nil {
+		httpd.HttpError(w, fmt.Sprintf("invalid pattern %q: %s", pattern, err), true, http.StatusBadRequest)
+		return
+	}
+	for _, spec := range specs {
+		calendars = append(calendars, s.convertToClientCalendar(spec, false))
+	}
+	if int(offset) < len(calendars) {
+		calendars = calendars[offset:]
+	} else {
+		calendars = calendars[:0]
+	}
+	if int(limit) < len(calendars) {
+		calendars = calendars[:limit]
+	}
+
+	type response struct {
+		Calendars []client.Calendar `json:"calendars"`
+	}
+
+	w.Write(httpd.MarshalJSON(response{calendars}, true))
+}
@@ -1411,6 +1411,15 @@ func (h *SideloadHandler) WithContext(ctx ...keyvalue.T) sideload.Diagnostic {
 	}
 }
 
+// Calendar handler
+type CalendarHandler struct {
+	l Logger
+}
+
+func (h *CalendarHandler) Error(msg string, err error) {
+	h.l.Error(msg, Error(err))
+}
+
 // Teams handler
 type TeamsHandler struct {
 	l Logger
@@ -139,6 +139,12 @@ func (s *Service) NewSideloadHandler() *SideloadHandler {
 	}
 }
 
+func (s *Service) NewCalendarHandler() *CalendarHandler {
+	return &CalendarHandler{
+		l: s.Logger.With(String("service", "calendar")),
+	}
+}
+
 func (s *Service) NewVictorOpsHandler() *VictorOpsHandler {
 	return &VictorOpsHandler{
 		l: s.Logger.With(String("service", "victorops")),
@@ -8,6 +8,7 @@ import (
 const taskDir = "tasks"
 const templateDir = "templates"
 const handlerDir = "handlers"
+const inhibitionDir = "inhibitions"
 
 type Config struct {
 	Enabled bool   `toml:"enabled"`
@@ -48,3 +49,7 @@ func (c Config) templatesDir() string {
 func (c Config) handlersDir() string {
 	return filepath.Join(c.Dir, handlerDir)
 }
+
+func (c Config) inhibitionsDir() string {
+	return filepath.Join(c.Dir, inhibitionDir)
+}
@@ -11,9 +11,10 @@ import (
 const version = 1
 
 const (
-	tasksStr     = "tasks"
-	templatesStr = "templates"
-	handlersStr  = "handlers"
+	tasksStr       = "tasks"
+	templatesStr   = "templates"
+	handlersStr    = "handlers"
+	inhibitionsStr = "inhibitions"
 )
 
 // Data access object for resources loaded from
@@ -48,6 +49,12 @@ func newTopicHandlerItem(topic, id string) Item {
 	}
 }
 
+func newInhibitionItem(id string) Item {
+	return Item{
+		ID: path.Join(inhibitionsStr, id),
+	}
+}
+
 func (i Item) MarshalBinary() ([]byte, error) {
 	return storage.VersionJSONEncode(version, i)
 }
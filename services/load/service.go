@@ -49,9 +49,10 @@ type Service struct {
 
 	items ItemsDAO
 
-	tasks     map[string]bool
-	templates map[string]bool
-	handlers  map[string]bool
+	tasks       map[string]bool
+	templates   map[string]bool
+	handlers    map[string]bool
+	inhibitions map[string]bool
 
 	StorageService interface {
 		Store(namespace string) storage.Interface
@@ -76,12 +77,13 @@ func NewService(c Config, h http.Handler, d Diagnostic) (*Service, error) {
 	}
 
 	s := &Service{
-		config:    c,
-		diag:      d,
-		cli:       cli,
-		tasks:     map[string]bool{},
-		templates: map[string]bool{},
-		handlers:  map[string]bool{},
+		config:      c,
+		diag:        d,
+		cli:         cli,
+		tasks:       map[string]bool{},
+		templates:   map[string]bool{},
+		handlers:    map[string]bool{},
+		inhibitions: map[string]bool{},
 	}
 
 	s.statsKey, s.statMap = vars.NewStatistic("load", nil)
@@ -203,6 +205,37 @@ func (s *Service) handlerFiles() ([]string, error) {
 	return handlers, nil
 }
 
+// inhibitionFiles gets a slice of all files with the .json, .yml, and
+// .yaml file extentions in the configured inhibition directory.
+func (s *Service) inhibitionFiles() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inhibitions := []string{}
+
+	inhibitionsDir := s.config.inhibitionsDir()
+
+	files, err := os.ReadDir(inhibitionsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		filename := file.Name()
+		switch ext := filepath.Ext(filename); ext {
+		case ".yml", ".json", ".yaml":
+			inhibitions = append(inhibitions, filepath.Join(inhibitionsDir, filename))
+		default:
+			continue
+		}
+	}
+
+	return inhibitions, nil
+}
+
 func (s *Service) Load() error {
 	if !s.config.Enabled {
 		return nil
@@ -212,6 +245,7 @@ func (s *Service) Load() error {
 	s.tasks = map[string]bool{}
 	s.templates = map[string]bool{}
 	s.handlers = map[string]bool{}
+	s.inhibitions = map[string]bool{}
 	s.mu.Unlock()
 
 	if err := s.load(); err != nil {
@@ -253,6 +287,12 @@ func (s *Service) load() error {
 		return err
 	}
 
+	s.diag.Debug("loading inhibitions")
+	err = s.loadInhibitions()
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
 	return nil
 }
 
@@ -541,6 +581,65 @@ func (s *Service) loadHandler(f string) error {
 	return nil
 }
 
+func (s *Service) loadInhibitions() error {
+	files, err := s.inhibitionFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		s.diag.Loading("inhibition", f)
+		if err := s.loadInhibition(f); err != nil {
+			return fmt.Errorf("failed to load file %s: %s", f, err.Error())
+		}
+	}
+	return nil
+}
+
+func (s *Service) loadInhibition(f string) error {
+	data, err := os.ReadFile(f)
+	if err != nil {
+		return fmt.Errorf("failed to read file %v: %v", f, err)
+	}
+
+	var o client.InhibitionOptions
+	switch ext := path.Ext(f); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &o); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal yaml inhibition file %q", f)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &o); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal json inhibition file %q", f)
+		}
+	default:
+		return errors.New("bad file extension. Must be YAML or JSON")
+	}
+	if o.ID == "" {
+		o.ID = strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+	}
+
+	l := s.cli.InhibitionLink(o.ID)
+	inhibition, _ := s.cli.Inhibition(l)
+	if inhibition.ID == "" {
+		if _, err := s.cli.CreateInhibition(o); err != nil {
+			return err
+		}
+	} else {
+		if _, err := s.cli.ReplaceInhibition(l, o); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inhibitions[o.ID] = true
+	if err := s.items.Set(newInhibitionItem(o.ID)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *Service) removeMissing() error {
 
 	if err := s.removeTasks(); err != nil {
@@ -555,6 +654,10 @@ func (s *Service) removeMissing() error {
 		return err
 	}
 
+	if err := s.removeInhibitions(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -615,6 +718,23 @@ func (s *Service) removeHandlers() error {
 	return nil
 }
 
+func (s *Service) removeInhibitions() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loadedInhibitions, err := s.loadedInhibitions()
+	if err != nil {
+		return err
+	}
+	for _, id := range diff(s.inhibitions, loadedInhibitions) {
+		l := s.cli.InhibitionLink(id)
+		if err := s.cli.DeleteInhibition(l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func diff(m map[string]bool, xs []string) []string {
 	diffs := []string{}
 
@@ -666,3 +786,16 @@ func (s *Service) loadedHandlers() ([]string, error) {
 
 	return handlers, nil
 }
+
+func (s *Service) loadedInhibitions() ([]string, error) {
+	items, err := s.items.List(inhibitionsStr)
+	if err != nil {
+		return nil, err
+	}
+	inhibitions := []string{}
+	for _, item := range items {
+		inhibitions = append(inhibitions, strings.TrimPrefix(item.ID, inhibitionsStr+"/"))
+	}
+
+	return inhibitions, nil
+}
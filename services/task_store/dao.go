@@ -179,6 +179,7 @@ type DBRP struct {
 
 type Snapshot struct {
 	NodeSnapshots map[string][]byte
+	PipelineHash  string
 }
 
 // Key/Value store based implementation of the TaskDAO
@@ -384,6 +384,7 @@ func (ts *Service) Load(id string) (*kapacitor.Task, error) {
 func (ts *Service) SaveSnapshot(id string, snapshot *kapacitor.TaskSnapshot) error {
 	s := &Snapshot{
 		NodeSnapshots: snapshot.NodeSnapshots,
+		PipelineHash:  snapshot.PipelineHash,
 	}
 	return ts.snapshots.Put(id, s)
 }
@@ -404,6 +405,7 @@ func (ts *Service) LoadSnapshot(id string) (*kapacitor.TaskSnapshot, error) {
 	}
 	s := &kapacitor.TaskSnapshot{
 		NodeSnapshots: snapshot.NodeSnapshots,
+		PipelineHash:  snapshot.PipelineHash,
 	}
 	return s, nil
 }
@@ -89,7 +89,12 @@ func taskTypeFromProgram(n *ast.ProgramNode) client.TaskType {
 	t := tts[0]
 	for _, tt := range tts[1:] {
 		if t != tt {
-			return client.InvalidTask
+			// Stream tasks can define lookup tables using batch.
+			if !usesLookupJoin(n) {
+				return client.InvalidTask
+			}
+			t = "stream"
+			break
 		}
 	}
 
@@ -102,3 +107,13 @@ func taskTypeFromProgram(n *ast.ProgramNode) client.TaskType {
 
 	return client.InvalidTask
 }
+
+// usesLookupJoin reports whether the program calls lookupJoin.
+func usesLookupJoin(n *ast.ProgramNode) bool {
+	for _, f := range ast.FindFunctionCalls(n) {
+		if f == "lookupJoin" {
+			return true
+		}
+	}
+	return false
+}
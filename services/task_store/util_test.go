@@ -119,6 +119,16 @@ func TestTaskTypeFromProgram(t *testing.T) {
 			`,
 			taskType: client.InvalidTask,
 		},
+		{
+			name: "stream with lookup table",
+			tickscript: `dbrp "telegraf"."autogen"
+			dbrp "cmdb"."autogen"
+			
+			var x = batch|query('SELECT * FROM "cmdb"."autogen"."hosts"').groupBy('host')
+			stream|from().measurement('m')|lookupJoin(x).on('host')
+			`,
+			taskType: client.StreamTask,
+		},
 		{
 			name: "missing batch or stream",
 			tickscript: `dbrp "telegraf"."autogen"
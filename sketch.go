@@ -0,0 +1,190 @@
+package kapacitor
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/keyvalue"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/sketch"
+)
+
+// SketchNode aggregates a field into a quantile sketch and emits fields computed from the sketch.
+type SketchNode struct {
+	node
+	field            string
+	relativeAccuracy float64
+	maxBins          int
+	sketchAs         string
+
+	// fields computes the emitted fields from the sketch.
+	fields func(s *sketch.DDSketch) models.Fields
+}
+
+// Create a new SketchNode that computes quantiles.
+func newQuantilesNode(et *ExecutingTask, n *pipeline.QuantilesNode, d NodeDiagnostic) (*SketchNode, error) {
+	sn := &SketchNode{
+		node:             node{Node: n, et: et, diag: d},
+		field:            n.Field,
+		relativeAccuracy: n.RelativeAccuracy,
+		maxBins:          int(n.MaxBins),
+		sketchAs:         n.SketchAs,
+		fields: func(s *sketch.DDSketch) models.Fields {
+			fields := make(models.Fields, len(n.Quantiles))
+			for _, q := range n.Quantiles {
+				fields[quantileFieldName(q)] = s.Quantile(q)
+			}
+			return fields
+		},
+	}
+	sn.node.runF = sn.runSketch
+	return sn, nil
+}
+
+// Create a new SketchNode that computes a histogram.
+func newHistogramNode(et *ExecutingTask, n *pipeline.HistogramNode, d NodeDiagnostic) (*SketchNode, error) {
+	sn := &SketchNode{
+		node:             node{Node: n, et: et, diag: d},
+		field:            n.Field,
+		relativeAccuracy: n.RelativeAccuracy,
+		maxBins:          int(n.MaxBins),
+		sketchAs:         n.SketchAs,
+		fields: func(s *sketch.DDSketch) models.Fields {
+			fields := make(models.Fields, len(n.Bounds)+1)
+			for _, b := range n.Bounds {
+				fields["le_"+strconv.FormatFloat(b, 'f', -1, 64)] = int64(math.Round(s.CountBelow(b)))
+			}
+			fields["count"] = int64(s.Count())
+			return fields
+		},
+	}
+	sn.node.runF = sn.runSketch
+	return sn, nil
+}
+
+// quantileFieldName returns the name of the field of quantile q, e.g. p99.9 for 0.999.
+func quantileFieldName(q float64) string {
+	return "p" + strconv.FormatFloat(math.Round(q*1e6)/1e4, 'f', -1, 64)
+}
+
+func (n *SketchNode) runSketch([]byte) error {
+	consumer := edge.NewGroupedConsumer(
+		n.ins[0],
+		n,
+	)
+	n.statMap.Set(statCardinalityGauge, consumer.CardinalityVar())
+	return consumer.Consume()
+}
+
+func (n *SketchNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.Receiver, error) {
+	return edge.NewReceiverFromForwardReceiverWithStats(
+		n.outs,
+		edge.NewTimedForwardReceiver(n.timer, &sketchGroup{n: n, group: group}),
+	), nil
+}
+
+type sketchGroup struct {
+	n     *SketchNode
+	group edge.GroupInfo
+
+	name   string
+	tags   models.Tags
+	time   time.Time
+	sketch *sketch.DDSketch
+}
+
+func (g *sketchGroup) reset(name string, tags models.Tags, t time.Time) {
+	g.name = name
+	g.tags = tags
+	g.time = t
+	// The parameters have been validated by the pipeline
+	g.sketch, _ = sketch.NewDDSketch(g.n.relativeAccuracy, g.n.maxBins)
+}
+
+// add adds the value of the field, either a number or an encoded sketch.
+func (g *sketchGroup) add(fields models.Fields) {
+	switch v := fields[g.n.field].(type) {
+	case string:
+		s, err := sketch.Decode(v)
+		if err != nil {
+			g.n.diag.Error("failed to decode sketch", err, keyvalue.KV("field", g.n.field))
+			return
+		}
+		if err := g.sketch.Merge(s); err != nil {
+			g.n.diag.Error("failed to merge sketch", err, keyvalue.KV("field", g.n.field))
+		}
+	default:
+		f, ok := numToFloat(v)
+		if !ok {
+			g.n.diag.Error("cannot add value to sketch",
+				fmt.Errorf("field is missing or the wrong type %T", v),
+				keyvalue.KV("field", g.n.field),
+			)
+			return
+		}
+		g.sketch.Add(f)
+	}
+}
+
+// emit returns a point with the fields computed from the sketch.
+func (g *sketchGroup) emit() edge.Message {
+	if g.sketch == nil || g.sketch.Count() == 0 {
+		return nil
+	}
+	fields := g.n.fields(g.sketch)
+	if g.n.sketchAs != "" {
+		str, err := g.sketch.Encode()
+		if err != nil {
+			g.n.diag.Error("failed to encode sketch", err)
+		} else {
+			fields[g.n.sketchAs] = str
+		}
+	}
+	return edge.NewPointMessage(
+		g.name, "", "",
+		g.group.Dimensions,
+		fields,
+		g.tags,
+		g.time,
+	)
+}
+
+func (g *sketchGroup) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	g.reset(begin.Name(), begin.Tags(), begin.Time())
+	return nil, nil
+}
+
+func (g *sketchGroup) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	g.add(bp.Fields())
+	return nil, nil
+}
+
+func (g *sketchGroup) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	m := g.emit()
+	g.sketch = nil
+	return m, nil
+}
+
+func (g *sketchGroup) Point(p edge.PointMessage) (edge.Message, error) {
+	if g.sketch != nil && p.Time().Equal(g.time) {
+		g.add(p.Fields())
+		return nil, nil
+	}
+	// Time has elapsed, emit the current sketch
+	m := g.emit()
+	g.reset(p.Name(), p.GroupInfo().Tags, p.Time())
+	g.add(p.Fields())
+	return m, nil
+}
+
+func (g *sketchGroup) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	return b, nil
+}
+func (g *sketchGroup) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	return d, nil
+}
+func (g *sketchGroup) Done() {}
@@ -0,0 +1,330 @@
+// Package sketch provides a mergeable quantile sketch with bounded memory.
+//
+// The sketch is a DDSketch, it guarantees that quantiles are within a
+// relative accuracy of the true value as long as the number of bins is not exceeded.
+// When the number of bins is exceeded the bins of the smallest values are collapsed.
+package sketch
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// encodingVersion is the first byte of an encoded sketch.
+const encodingVersion byte = 1
+
+// minIndexableValue is the smallest value that is not counted as zero.
+const minIndexableValue = 1e-9
+
+// DDSketch is a quantile sketch with relative accuracy guarantees.
+type DDSketch struct {
+	relativeAccuracy float64
+	maxBins          int
+
+	gamma    float64
+	logGamma float64
+
+	positive map[int]float64
+	negative map[int]float64
+	zero     float64
+
+	count float64
+	sum   float64
+	min   float64
+	max   float64
+}
+
+// NewDDSketch creates a sketch with the given relative accuracy, between 0 and 1, and maximum number of bins.
+func NewDDSketch(relativeAccuracy float64, maxBins int) (*DDSketch, error) {
+	if relativeAccuracy <= 0 || relativeAccuracy >= 1 {
+		return nil, fmt.Errorf("relative accuracy must be between 0 and 1, got %v", relativeAccuracy)
+	}
+	if maxBins <= 0 {
+		return nil, fmt.Errorf("max bins must be positive, got %d", maxBins)
+	}
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	return &DDSketch{
+		relativeAccuracy: relativeAccuracy,
+		maxBins:          maxBins,
+		gamma:            gamma,
+		logGamma:         math.Log(gamma),
+		positive:         make(map[int]float64),
+		negative:         make(map[int]float64),
+		min:              math.Inf(1),
+		max:              math.Inf(-1),
+	}, nil
+}
+
+// Add adds a value to the sketch.
+func (s *DDSketch) Add(v float64) {
+	s.addCount(v, 1)
+}
+
+func (s *DDSketch) addCount(v, count float64) {
+	switch {
+	case v > minIndexableValue:
+		s.positive[s.index(v)] += count
+		s.collapse(s.positive)
+	case v < -minIndexableValue:
+		s.negative[s.index(-v)] += count
+		s.collapse(s.negative)
+	default:
+		s.zero += count
+	}
+	s.count += count
+	s.sum += v * count
+	s.min = math.Min(s.min, v)
+	s.max = math.Max(s.max, v)
+}
+
+func (s *DDSketch) index(v float64) int {
+	return int(math.Ceil(math.Log(v) / s.logGamma))
+}
+
+func (s *DDSketch) value(index int) float64 {
+	return 2 * math.Pow(s.gamma, float64(index)) / (s.gamma + 1)
+}
+
+// collapse merges the lowest bins of the store until it is within the maximum number of bins.
+func (s *DDSketch) collapse(bins map[int]float64) {
+	if len(bins) <= s.maxBins {
+		return
+	}
+	indexes := sortedIndexes(bins)
+	excess := len(indexes) - s.maxBins
+	target := indexes[excess]
+	for _, i := range indexes[:excess] {
+		bins[target] += bins[i]
+		delete(bins, i)
+	}
+}
+
+// Count returns the number of values added to the sketch.
+func (s *DDSketch) Count() float64 {
+	return s.count
+}
+
+// Sum returns the sum of the values added to the sketch.
+func (s *DDSketch) Sum() float64 {
+	return s.sum
+}
+
+// Min returns the smallest value added to the sketch.
+func (s *DDSketch) Min() float64 {
+	return s.min
+}
+
+// Max returns the largest value added to the sketch.
+func (s *DDSketch) Max() float64 {
+	return s.max
+}
+
+// Quantile returns the approximate value at quantile q, between 0 and 1.
+// It returns NaN if the sketch is empty.
+func (s *DDSketch) Quantile(q float64) float64 {
+	if s.count == 0 || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	switch q {
+	case 0:
+		return s.min
+	case 1:
+		return s.max
+	}
+	rank := q * (s.count - 1)
+	var n float64
+	// Negative values from the most negative
+	neg := sortedIndexes(s.negative)
+	for i := len(neg) - 1; i >= 0; i-- {
+		n += s.negative[neg[i]]
+		if n > rank {
+			return s.clamp(-s.value(neg[i]))
+		}
+	}
+	n += s.zero
+	if n > rank {
+		return 0
+	}
+	for _, i := range sortedIndexes(s.positive) {
+		n += s.positive[i]
+		if n > rank {
+			return s.clamp(s.value(i))
+		}
+	}
+	return s.max
+}
+
+// clamp limits v to the range of the added values.
+func (s *DDSketch) clamp(v float64) float64 {
+	return math.Max(s.min, math.Min(s.max, v))
+}
+
+// CountBelow returns the approximate number of values less than or equal to v.
+func (s *DDSketch) CountBelow(v float64) float64 {
+	if v >= s.max {
+		return s.count
+	}
+	if v < s.min {
+		return 0
+	}
+	var n float64
+	for i, c := range s.negative {
+		if -s.value(i) <= v {
+			n += c
+		}
+	}
+	if v >= 0 {
+		n += s.zero
+	}
+	for i, c := range s.positive {
+		if s.value(i) <= v {
+			n += c
+		}
+	}
+	return n
+}
+
+// Merge adds all values of o to the sketch.
+// Both sketches must have the same relative accuracy.
+func (s *DDSketch) Merge(o *DDSketch) error {
+	if s.gamma != o.gamma {
+		return fmt.Errorf("cannot merge sketches with different relative accuracy %v and %v", s.relativeAccuracy, o.relativeAccuracy)
+	}
+	if o.count == 0 {
+		return nil
+	}
+	for i, c := range o.positive {
+		s.positive[i] += c
+	}
+	s.collapse(s.positive)
+	for i, c := range o.negative {
+		s.negative[i] += c
+	}
+	s.collapse(s.negative)
+	s.zero += o.zero
+	s.count += o.count
+	s.sum += o.sum
+	s.min = math.Min(s.min, o.min)
+	s.max = math.Max(s.max, o.max)
+	return nil
+}
+
+// MarshalBinary encodes the sketch.
+func (s *DDSketch) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(encodingVersion)
+	for _, v := range []interface{}{
+		s.relativeAccuracy,
+		int64(s.maxBins),
+		s.zero,
+		s.count,
+		s.sum,
+		s.min,
+		s.max,
+	} {
+		if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+			return nil, err
+		}
+	}
+	for _, bins := range []map[int]float64{s.positive, s.negative} {
+		if err := binary.Write(&buf, binary.LittleEndian, int64(len(bins))); err != nil {
+			return nil, err
+		}
+		for _, i := range sortedIndexes(bins) {
+			if err := binary.Write(&buf, binary.LittleEndian, int64(i)); err != nil {
+				return nil, err
+			}
+			if err := binary.Write(&buf, binary.LittleEndian, bins[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a sketch encoded with MarshalBinary.
+func (s *DDSketch) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	version, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if version != encodingVersion {
+		return fmt.Errorf("unsupported sketch encoding version %d", version)
+	}
+	var relativeAccuracy float64
+	var maxBins int64
+	if err := binary.Read(r, binary.LittleEndian, &relativeAccuracy); err != nil {
+		return err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &maxBins); err != nil {
+		return err
+	}
+	d, err := NewDDSketch(relativeAccuracy, int(maxBins))
+	if err != nil {
+		return err
+	}
+	for _, v := range []*float64{&d.zero, &d.count, &d.sum, &d.min, &d.max} {
+		if err := binary.Read(r, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	for _, bins := range []map[int]float64{d.positive, d.negative} {
+		var l int64
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return err
+		}
+		if l < 0 || l > int64(r.Len()) {
+			return errors.New("invalid sketch bin count")
+		}
+		for j := int64(0); j < l; j++ {
+			var i int64
+			var c float64
+			if err := binary.Read(r, binary.LittleEndian, &i); err != nil {
+				return err
+			}
+			if err := binary.Read(r, binary.LittleEndian, &c); err != nil {
+				return err
+			}
+			bins[int(i)] = c
+		}
+	}
+	*s = *d
+	return nil
+}
+
+// Encode returns the sketch as a string that can be stored in a field.
+func (s *DDSketch) Encode() (string, error) {
+	data, err := s.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Decode decodes a sketch from a string returned by Encode.
+func Decode(str string) (*DDSketch, error) {
+	data, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, err
+	}
+	s := new(DDSketch)
+	if err := s.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func sortedIndexes(bins map[int]float64) []int {
+	indexes := make([]int, 0, len(bins))
+	for i := range bins {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
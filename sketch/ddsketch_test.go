@@ -0,0 +1,114 @@
+package sketch_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/influxdata/kapacitor/sketch"
+)
+
+func newSketch(t *testing.T) *sketch.DDSketch {
+	t.Helper()
+	s, err := sketch.NewDDSketch(0.01, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestDDSketch_Quantile(t *testing.T) {
+	s := newSketch(t)
+	for i := 1; i <= 1000; i++ {
+		s.Add(float64(i))
+	}
+	testCases := []struct {
+		q   float64
+		exp float64
+	}{
+		{q: 0, exp: 1},
+		{q: 0.5, exp: 500},
+		{q: 0.9, exp: 900},
+		{q: 0.99, exp: 990},
+		{q: 1, exp: 1000},
+	}
+	for _, tc := range testCases {
+		got := s.Quantile(tc.q)
+		if math.Abs(got-tc.exp) > 0.01*tc.exp+1 {
+			t.Errorf("unexpected quantile %v got %v exp %v", tc.q, got, tc.exp)
+		}
+	}
+	if got, exp := s.Count(), 1000.0; got != exp {
+		t.Errorf("unexpected count got %v exp %v", got, exp)
+	}
+}
+
+func TestDDSketch_Negative(t *testing.T) {
+	s := newSketch(t)
+	for i := -50; i <= 50; i++ {
+		s.Add(float64(i))
+	}
+	if got := s.Quantile(0.5); got != 0 {
+		t.Errorf("unexpected median got %v exp 0", got)
+	}
+	if got, exp := s.Quantile(0.1), -40.0; math.Abs(got-exp) > 0.5 {
+		t.Errorf("unexpected quantile got %v exp %v", got, exp)
+	}
+	if got, exp := s.CountBelow(0), 51.0; got != exp {
+		t.Errorf("unexpected count below got %v exp %v", got, exp)
+	}
+}
+
+func TestDDSketch_MaxBins(t *testing.T) {
+	s, err := sketch.NewDDSketch(0.01, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 10000; i++ {
+		s.Add(float64(i))
+	}
+	// The highest quantiles keep their accuracy
+	if got, exp := s.Quantile(0.99), 9900.0; math.Abs(got-exp) > 0.01*exp+1 {
+		t.Errorf("unexpected quantile got %v exp %v", got, exp)
+	}
+}
+
+func TestDDSketch_MergeEncode(t *testing.T) {
+	a := newSketch(t)
+	b := newSketch(t)
+	for i := 1; i <= 500; i++ {
+		a.Add(float64(i))
+		b.Add(float64(i + 500))
+	}
+	str, err := b.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := sketch.Decode(str)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Merge(decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := a.Count(), 1000.0; got != exp {
+		t.Errorf("unexpected count got %v exp %v", got, exp)
+	}
+	if got, exp := a.Max(), 1000.0; got != exp {
+		t.Errorf("unexpected max got %v exp %v", got, exp)
+	}
+	if got, exp := a.Quantile(0.5), 500.0; math.Abs(got-exp) > 0.01*exp+1 {
+		t.Errorf("unexpected median got %v exp %v", got, exp)
+	}
+
+	other, err := sketch.NewDDSketch(0.05, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.Add(1)
+	if err := a.Merge(other); err == nil {
+		t.Error("expected error merging sketches with different accuracy")
+	}
+	if _, err := sketch.Decode("invalid"); err == nil {
+		t.Error("expected error decoding invalid sketch")
+	}
+}
@@ -0,0 +1,86 @@
+package kapacitor
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+func TestQuantileFieldName(t *testing.T) {
+	testCases := map[float64]string{
+		0.5:   "p50",
+		0.9:   "p90",
+		0.99:  "p99",
+		0.999: "p99.9",
+		1:     "p100",
+	}
+	for q, exp := range testCases {
+		if got := quantileFieldName(q); got != exp {
+			t.Errorf("unexpected field name for %v got %s exp %s", q, got, exp)
+		}
+	}
+}
+
+func TestSketchNode_MergeBatches(t *testing.T) {
+	batch := &pipeline.BatchNode{}
+	pipeline.CreatePipelineSources(batch)
+	query := batch.Query(`SELECT "latency" FROM "db"."rp"."requests"`)
+	q := query.Quantiles("latency").Q(0.5)
+	q.SketchAs = "sketch"
+	h := query.Histogram("latency").Buckets(250, 500)
+
+	qn, err := newQuantilesNode(nil, q, &nodeDiagnostic{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hn, err := newHistogramNode(nil, h, &nodeDiagnostic{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	run := func(g *sketchGroup, values []interface{}) models.Fields {
+		t.Helper()
+		if _, err := g.BeginBatch(edge.NewBeginBatchMessage("requests", nil, false, now, len(values))); err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range values {
+			if _, err := g.BatchPoint(edge.NewBatchPointMessage(models.Fields{"latency": v}, nil, now)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		m, err := g.EndBatch(edge.NewEndBatchMessage())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m.(edge.PointMessage).Fields()
+	}
+
+	// Produce two sketches of raw values
+	var sketches []interface{}
+	for _, offset := range []int{0, 500} {
+		var values []interface{}
+		for i := 1; i <= 500; i++ {
+			values = append(values, float64(i+offset))
+		}
+		fields := run(&sketchGroup{n: qn}, values)
+		sketches = append(sketches, fields["sketch"])
+	}
+
+	// Merge the sketches
+	fields := run(&sketchGroup{n: qn}, sketches)
+	if got, exp := fields["p50"].(float64), 500.0; math.Abs(got-exp) > 0.01*exp+1 {
+		t.Errorf("unexpected median got %v exp %v", got, exp)
+	}
+	fields = run(&sketchGroup{n: hn}, sketches)
+	if got, exp := fields["count"], int64(1000); got != exp {
+		t.Errorf("unexpected count got %v exp %v", got, exp)
+	}
+	if got, exp := fields["le_500"].(int64), int64(500); got < exp-5 || got > exp+5 {
+		t.Errorf("unexpected le_500 got %v exp %v", got, exp)
+	}
+}
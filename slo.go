@@ -0,0 +1,308 @@
+package kapacitor
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+	"github.com/influxdata/kapacitor/tick/stateful"
+)
+
+type SloNode struct {
+	node
+	s *pipeline.SloNode
+
+	good          stateful.Expression
+	goodScopePool stateful.ScopePool
+
+	total          stateful.Expression
+	totalScopePool stateful.ScopePool
+
+	// windows ordered from the shortest to the longest
+	windows []sloWindow
+	// budget is the allowed fraction of bad events.
+	budget float64
+
+	// mu protects the groups so they can be snapshotted.
+	mu       sync.Mutex
+	groups   map[models.GroupID]*sloGroup
+	restored map[models.GroupID][]sloEvent
+}
+
+// sloWindow is a window duration and the names of its fields.
+type sloWindow struct {
+	duration       time.Duration
+	goodField      string
+	totalField     string
+	burnRateField  string
+	remainingField string
+}
+
+// Create a new SloNode, which computes the burn rate and remaining error budget of each group.
+func newSloNode(et *ExecutingTask, n *pipeline.SloNode, d NodeDiagnostic) (*SloNode, error) {
+	if n.Good == nil {
+		return nil, errors.New("nil good expression passed to SloNode")
+	}
+	sn := &SloNode{
+		node:   node{Node: n, et: et, diag: d},
+		s:      n,
+		budget: 1 - n.Objective/100,
+		groups: make(map[models.GroupID]*sloGroup),
+	}
+	var err error
+	sn.good, err = stateful.NewExpression(n.Good.Expression)
+	if err != nil {
+		return nil, err
+	}
+	sn.goodScopePool = stateful.NewScopePool(ast.FindReferenceVariables(n.Good.Expression))
+	if n.Total != nil {
+		sn.total, err = stateful.NewExpression(n.Total.Expression)
+		if err != nil {
+			return nil, err
+		}
+		sn.totalScopePool = stateful.NewScopePool(ast.FindReferenceVariables(n.Total.Expression))
+	}
+	durations := make([]time.Duration, len(n.Durations))
+	copy(durations, n.Durations)
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	for _, d := range durations {
+		suffix := "_" + influxql.FormatDuration(d)
+		sn.windows = append(sn.windows, sloWindow{
+			duration:       d,
+			goodField:      "good" + suffix,
+			totalField:     "total" + suffix,
+			burnRateField:  "burn_rate" + suffix,
+			remainingField: "error_budget_remaining" + suffix,
+		})
+	}
+	sn.node.runF = sn.runSlo
+	return sn, nil
+}
+
+func (n *SloNode) runSlo(snapshot []byte) error {
+	if snapshot != nil {
+		if err := n.restore(snapshot); err != nil {
+			n.diag.Error("failed to restore slo state from snapshot", err)
+		}
+	}
+	consumer := edge.NewGroupedConsumer(
+		n.ins[0],
+		n,
+	)
+	n.statMap.Set(statCardinalityGauge, consumer.CardinalityVar())
+	return consumer.Consume()
+}
+
+func (n *SloNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.Receiver, error) {
+	g := n.newGroup()
+	n.mu.Lock()
+	if events, ok := n.restored[group.ID]; ok {
+		for _, e := range events {
+			g.add(e)
+		}
+		delete(n.restored, group.ID)
+	}
+	n.groups[group.ID] = g
+	n.mu.Unlock()
+	return edge.NewReceiverFromForwardReceiverWithStats(
+		n.outs,
+		edge.NewTimedForwardReceiver(n.timer, edge.NewLockedForwardReceiver(&n.mu, g)),
+	), nil
+}
+
+func (n *SloNode) newGroup() *sloGroup {
+	g := &sloGroup{
+		n:      n,
+		good:   n.good.CopyReset(),
+		counts: make([]sloCount, len(n.windows)),
+	}
+	if n.total != nil {
+		g.total = n.total.CopyReset()
+	}
+	return g
+}
+
+func (n *SloNode) snapshot() ([]byte, error) {
+	n.mu.Lock()
+	groups := make(map[models.GroupID][]sloEvent, len(n.groups))
+	for id, g := range n.groups {
+		events := g.events[g.oldest():]
+		groups[id] = append(make([]sloEvent, 0, len(events)), events...)
+	}
+	n.mu.Unlock()
+	return encodeNodeSnapshot(groups)
+}
+
+func (n *SloNode) restore(data []byte) error {
+	groups := make(map[models.GroupID][]sloEvent)
+	if err := decodeNodeSnapshot(data, &groups); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.restored = groups
+	n.mu.Unlock()
+	return nil
+}
+
+// sloEvent is a single total event, which is persisted in snapshots.
+type sloEvent struct {
+	Time time.Time
+	Good bool
+}
+
+// sloCount is the rolling count of the events within a window.
+type sloCount struct {
+	// first is the index of the oldest event within the window.
+	first int
+	good  int64
+	total int64
+}
+
+type sloGroup struct {
+	n     *SloNode
+	good  stateful.Expression
+	total stateful.Expression
+
+	// events within the longest window ordered by time
+	events []sloEvent
+	// now is the time of the newest point
+	now time.Time
+	// counts of each window of the node
+	counts []sloCount
+}
+
+func (g *sloGroup) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	return begin, nil
+}
+
+func (g *sloGroup) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	bp = bp.ShallowCopy()
+	if err := g.track(bp); err != nil {
+		g.n.diag.Error("error while evaluating expression", err)
+		return nil, nil
+	}
+	return bp, nil
+}
+
+func (g *sloGroup) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	return end, nil
+}
+
+func (g *sloGroup) Point(p edge.PointMessage) (edge.Message, error) {
+	p = p.ShallowCopy()
+	if err := g.track(p); err != nil {
+		g.n.diag.Error("error while evaluating expression", err)
+		return nil, nil
+	}
+	return p, nil
+}
+
+// track counts the point as an event and sets the fields of each window on the point.
+func (g *sloGroup) track(p edge.FieldsTagsTimeSetter) error {
+	isTotal := true
+	if g.total != nil {
+		var err error
+		isTotal, err = EvalPredicate(g.total, g.n.totalScopePool, p)
+		if err != nil {
+			return err
+		}
+	}
+	if isTotal {
+		good, err := EvalPredicate(g.good, g.n.goodScopePool, p)
+		if err != nil {
+			return err
+		}
+		g.add(sloEvent{Time: p.Time(), Good: good})
+	} else {
+		g.expire(p.Time())
+	}
+
+	fields := p.Fields().Copy()
+	for i, w := range g.n.windows {
+		c := g.counts[i]
+		burnRate := 0.0
+		if c.total > 0 {
+			burnRate = float64(c.total-c.good) / float64(c.total) / g.n.budget
+		}
+		fields[w.goodField] = c.good
+		fields[w.totalField] = c.total
+		fields[w.burnRateField] = burnRate
+		// The remaining budget is relative to the window, not to a longer objective period.
+		fields[w.remainingField] = 1 - burnRate
+	}
+	p.SetFields(fields)
+	return nil
+}
+
+// add expires the events older than the windows and adds the event to the counts of the windows it falls within.
+// Late events are inserted in time order, events older than the longest window are dropped.
+func (g *sloGroup) add(e sloEvent) {
+	g.expire(e.Time)
+	if len(g.n.windows) == 0 || !e.Time.After(g.now.Add(-g.n.windows[len(g.n.windows)-1].duration)) {
+		return
+	}
+	idx := sort.Search(len(g.events), func(i int) bool { return g.events[i].Time.After(e.Time) })
+	g.events = append(g.events, sloEvent{})
+	copy(g.events[idx+1:], g.events[idx:])
+	g.events[idx] = e
+	for i, w := range g.n.windows {
+		c := &g.counts[i]
+		if !e.Time.After(g.now.Add(-w.duration)) {
+			// The event is before the window, which starts one event later
+			c.first++
+			continue
+		}
+		c.total++
+		if e.Good {
+			c.good++
+		}
+	}
+}
+
+// expire removes the events that are no longer within the windows at time t.
+func (g *sloGroup) expire(t time.Time) {
+	if t.After(g.now) {
+		g.now = t
+	}
+	for i, w := range g.n.windows {
+		c := &g.counts[i]
+		oldest := g.now.Add(-w.duration)
+		for ; c.first < len(g.events) && !g.events[c.first].Time.After(oldest); c.first++ {
+			c.total--
+			if g.events[c.first].Good {
+				c.good--
+			}
+		}
+	}
+	// The longest window holds the oldest events,
+	// drop the events it no longer needs once they make up half of the events.
+	if drop := g.oldest(); drop > 0 && drop >= len(g.events)/2 {
+		g.events = append(g.events[:0], g.events[drop:]...)
+		for i := range g.counts {
+			g.counts[i].first -= drop
+		}
+	}
+}
+
+// oldest returns the index of the oldest event within the longest window.
+func (g *sloGroup) oldest() int {
+	if len(g.counts) == 0 {
+		return len(g.events)
+	}
+	return g.counts[len(g.counts)-1].first
+}
+
+func (g *sloGroup) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	return b, nil
+}
+func (g *sloGroup) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	delete(g.n.groups, d.GroupID())
+	return d, nil
+}
+func (g *sloGroup) Done() {}
@@ -0,0 +1,104 @@
+package kapacitor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+// nodeSnapshotVersion is encoded at the start of every built-in node snapshot.
+// It must be incremented whenever the snapshot state of a node changes in an incompatible way.
+const nodeSnapshotVersion = 1
+
+// encodeNodeSnapshot encodes the state of a node so it can be stored in a TaskSnapshot.
+func encodeNodeSnapshot(state interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(nodeSnapshotVersion); err != nil {
+		return nil, err
+	}
+	if err := enc.Encode(state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeNodeSnapshot decodes data created by encodeNodeSnapshot into state.
+func decodeNodeSnapshot(data []byte, state interface{}) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	var version int
+	if err := dec.Decode(&version); err != nil {
+		return err
+	}
+	if version != nodeSnapshotVersion {
+		return fmt.Errorf("unsupported node snapshot version %d, expected %d", version, nodeSnapshotVersion)
+	}
+	return dec.Decode(state)
+}
+
+// pipelineHash returns a hash of the pipeline definition.
+// Any change to the DAG or the properties of its nodes results in a different hash.
+func pipelineHash(p *pipeline.Pipeline) (string, error) {
+	data, err := p.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// pointSnapshot is the persisted form of a single point held in the state of a node.
+type pointSnapshot struct {
+	Database        string
+	RetentionPolicy string
+	Fields          models.Fields
+	Tags            models.Tags
+	Time            time.Time
+}
+
+func newPointSnapshot(p edge.PointMessage) pointSnapshot {
+	return pointSnapshot{
+		Database:        p.Database(),
+		RetentionPolicy: p.RetentionPolicy(),
+		Fields:          p.Fields(),
+		Tags:            p.Tags(),
+		Time:            p.Time(),
+	}
+}
+
+func newBatchPointSnapshot(p edge.FieldsTagsTimeGetter) pointSnapshot {
+	return pointSnapshot{
+		Fields: p.Fields(),
+		Tags:   p.Tags(),
+		Time:   p.Time(),
+	}
+}
+
+// pointMessage recreates the point for the given name and dimensions.
+func (s pointSnapshot) pointMessage(name string, dimensions models.Dimensions) edge.PointMessage {
+	return edge.NewPointMessage(
+		name,
+		s.Database,
+		s.RetentionPolicy,
+		dimensions,
+		s.Fields,
+		s.Tags,
+		s.Time,
+	)
+}
+
+// batchPointMessage recreates the point as a batch point.
+func (s pointSnapshot) batchPointMessage() edge.BatchPointMessage {
+	return edge.NewBatchPointMessage(
+		s.Fields,
+		s.Tags,
+		s.Time,
+	)
+}
@@ -2,9 +2,11 @@ package kapacitor
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/models"
 	"github.com/influxdata/kapacitor/pipeline"
 	"github.com/influxdata/kapacitor/tick/ast"
 	"github.com/influxdata/kapacitor/tick/stateful"
@@ -13,6 +15,14 @@ import (
 type stateTracker interface {
 	track(t time.Time, inState bool) interface{}
 	reset()
+	snapshot() stateTrackerSnapshot
+	restore(stateTrackerSnapshot)
+}
+
+// stateTrackerSnapshot is the persisted state of a single state tracker.
+type stateTrackerSnapshot struct {
+	StartTime time.Time
+	Count     int64
 }
 
 type stateTrackingGroup struct {
@@ -29,9 +39,19 @@ type StateTrackingNode struct {
 	scopePool stateful.ScopePool
 
 	newTracker func() stateTracker
+
+	// mu protects the trackers so they can be snapshotted.
+	mu       sync.Mutex
+	trackers map[models.GroupID]stateTracker
+	restored map[models.GroupID]stateTrackerSnapshot
 }
 
-func (n *StateTrackingNode) runStateTracking(_ []byte) error {
+func (n *StateTrackingNode) runStateTracking(snapshot []byte) error {
+	if snapshot != nil {
+		if err := n.restore(snapshot); err != nil {
+			n.diag.Error("failed to restore state tracking from snapshot", err)
+		}
+	}
 	consumer := edge.NewGroupedConsumer(
 		n.ins[0],
 		n,
@@ -41,9 +61,17 @@ func (n *StateTrackingNode) runStateTracking(_ []byte) error {
 }
 
 func (n *StateTrackingNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.Receiver, error) {
+	g := n.newGroup()
+	n.mu.Lock()
+	if s, ok := n.restored[group.ID]; ok {
+		g.tracker.restore(s)
+		delete(n.restored, group.ID)
+	}
+	n.trackers[group.ID] = g.tracker
+	n.mu.Unlock()
 	return edge.NewReceiverFromForwardReceiverWithStats(
 		n.outs,
-		edge.NewTimedForwardReceiver(n.timer, n.newGroup()),
+		edge.NewTimedForwardReceiver(n.timer, edge.NewLockedForwardReceiver(&n.mu, g)),
 	), nil
 }
 
@@ -59,6 +87,27 @@ func (n *StateTrackingNode) newGroup() *stateTrackingGroup {
 	return g
 }
 
+func (n *StateTrackingNode) snapshot() ([]byte, error) {
+	n.mu.Lock()
+	trackers := make(map[models.GroupID]stateTrackerSnapshot, len(n.trackers))
+	for id, t := range n.trackers {
+		trackers[id] = t.snapshot()
+	}
+	n.mu.Unlock()
+	return encodeNodeSnapshot(trackers)
+}
+
+func (n *StateTrackingNode) restore(data []byte) error {
+	trackers := make(map[models.GroupID]stateTrackerSnapshot)
+	if err := decodeNodeSnapshot(data, &trackers); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.restored = trackers
+	n.mu.Unlock()
+	return nil
+}
+
 func (g *stateTrackingGroup) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
 	g.tracker.reset()
 	return begin, nil
@@ -104,6 +153,7 @@ func (g *stateTrackingGroup) Barrier(b edge.BarrierMessage) (edge.Message, error
 	return b, nil
 }
 func (g *stateTrackingGroup) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	delete(g.n.trackers, d.GroupID())
 	return d, nil
 }
 func (g *stateTrackingGroup) Done() {}
@@ -130,6 +180,14 @@ func (sdt *stateDurationTracker) track(t time.Time, inState bool) interface{} {
 	return float64(t.Sub(sdt.startTime)) / float64(sdt.sd.Unit)
 }
 
+func (sdt *stateDurationTracker) snapshot() stateTrackerSnapshot {
+	return stateTrackerSnapshot{StartTime: sdt.startTime}
+}
+
+func (sdt *stateDurationTracker) restore(s stateTrackerSnapshot) {
+	sdt.startTime = s.StartTime
+}
+
 func newStateDurationNode(et *ExecutingTask, sd *pipeline.StateDurationNode, d NodeDiagnostic) (*StateTrackingNode, error) {
 	if sd.Lambda == nil {
 		return nil, fmt.Errorf("nil expression passed to StateDurationNode")
@@ -143,6 +201,7 @@ func newStateDurationNode(et *ExecutingTask, sd *pipeline.StateDurationNode, d N
 		node:       node{Node: sd, et: et, diag: d},
 		as:         sd.As,
 		newTracker: func() stateTracker { return &stateDurationTracker{sd: sd} },
+		trackers:   make(map[models.GroupID]stateTracker),
 		expr:       expr,
 		scopePool:  stateful.NewScopePool(ast.FindReferenceVariables(sd.Lambda.Expression)),
 	}
@@ -168,6 +227,14 @@ func (sct *stateCountTracker) track(t time.Time, inState bool) interface{} {
 	return sct.count
 }
 
+func (sct *stateCountTracker) snapshot() stateTrackerSnapshot {
+	return stateTrackerSnapshot{Count: sct.count}
+}
+
+func (sct *stateCountTracker) restore(s stateTrackerSnapshot) {
+	sct.count = s.Count
+}
+
 func newStateCountNode(et *ExecutingTask, sc *pipeline.StateCountNode, d NodeDiagnostic) (*StateTrackingNode, error) {
 	if sc.Lambda == nil {
 		return nil, fmt.Errorf("nil expression passed to StateCountNode")
@@ -181,6 +248,7 @@ func newStateCountNode(et *ExecutingTask, sc *pipeline.StateCountNode, d NodeDia
 		node:       node{Node: sc, et: et, diag: d},
 		as:         sc.As,
 		newTracker: func() stateTracker { return &stateCountTracker{} },
+		trackers:   make(map[models.GroupID]stateTracker),
 		expr:       expr,
 		scopePool:  stateful.NewScopePool(ast.FindReferenceVariables(sc.Lambda.Expression)),
 	}
@@ -48,6 +48,9 @@ type FromNode struct {
 	db            string
 	rp            string
 	name          string
+
+	watermark watermark
+	late      *lateOutput
 }
 
 // Create a new  FromNode which filters data from a source.
@@ -60,6 +63,14 @@ func newFromNode(et *ExecutingTask, n *pipeline.FromNode, d NodeDiagnostic) (*Fr
 		name: n.Measurement,
 	}
 	sn.node.runF = sn.runStream
+	if n.AllowedLateness != 0 {
+		late, err := newLateOutput(et, n.LateDatabase, n.LateRetentionPolicy, n.LateMeasurement, d)
+		if err != nil {
+			return nil, err
+		}
+		sn.watermark = newWatermark(et, n.AllowedLateness)
+		sn.late = late
+	}
 	sn.allDimensions, sn.tagNames = determineTagNames(n.Dimensions, nil)
 
 	if n.Lambda != nil {
@@ -76,6 +87,9 @@ func newFromNode(et *ExecutingTask, n *pipeline.FromNode, d NodeDiagnostic) (*Fr
 }
 
 func (n *FromNode) runStream([]byte) error {
+	if n.late != nil {
+		n.statMap.Set(statsLatePoints, n.late.latePoints)
+	}
 	consumer := edge.NewConsumerWithReceiver(
 		n.ins[0],
 		edge.NewReceiverFromForwardReceiverWithStats(
@@ -104,6 +118,13 @@ func (n *FromNode) Point(p edge.PointMessage) (edge.Message, error) {
 		if n.s.Round != 0 {
 			p.SetTime(p.Time().Round(n.s.Round))
 		}
+		if n.late != nil {
+			if p.Time().Before(n.watermark.time()) {
+				n.late.collect(p)
+				return nil, nil
+			}
+			n.watermark.observe(p.Time())
+		}
 		p.SetDimensions(models.Dimensions{
 			ByName:   n.s.GroupByMeasurementFlag,
 			TagNames: computeTagNames(p.Tags(), n.allDimensions, n.tagNames, nil),
@@ -0,0 +1,198 @@
+package kapacitor
+
+import (
+	"fmt"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/expvar"
+	"github.com/influxdata/kapacitor/keyvalue"
+	"github.com/influxdata/kapacitor/pipeline"
+	"github.com/influxdata/kapacitor/tick/ast"
+	"github.com/influxdata/kapacitor/tick/stateful"
+)
+
+const (
+	statsUnmatched = "unmatched"
+)
+
+type SwitchNode struct {
+	node
+	s *pipeline.SwitchNode
+
+	expressions []stateful.Expression
+	scopePools  []stateful.ScopePool
+
+	// the branch of each child
+	branches []string
+
+	unmatched *expvar.Int
+}
+
+// Create a new SwitchNode, which routes each point to exactly one named branch.
+func newSwitchNode(et *ExecutingTask, n *pipeline.SwitchNode, d NodeDiagnostic) (*SwitchNode, error) {
+	sn := &SwitchNode{
+		node:        node{Node: n, et: et, diag: d},
+		s:           n,
+		expressions: make([]stateful.Expression, len(n.Cases)),
+		scopePools:  make([]stateful.ScopePool, len(n.Cases)),
+		unmatched:   new(expvar.Int),
+	}
+	for i, c := range n.Cases {
+		expr, err := stateful.NewExpression(c.Lambda.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to compile expression of case %q: %v", c.Branch, err)
+		}
+		sn.expressions[i] = expr
+		sn.scopePools[i] = stateful.NewScopePool(ast.FindReferenceVariables(c.Lambda.Expression))
+	}
+	sn.node.runF = sn.runSwitch
+	return sn, nil
+}
+
+func (n *SwitchNode) runSwitch([]byte) error {
+	n.statMap.Set(statsUnmatched, n.unmatched)
+	n.branches = make([]string, len(n.children))
+	for i, c := range n.children {
+		if b, ok := c.(*BranchNode); ok {
+			n.branches[i] = b.b.Branch
+		}
+	}
+	consumer := edge.NewGroupedConsumer(
+		n.ins[0],
+		n,
+	)
+	n.statMap.Set(statCardinalityGauge, consumer.CardinalityVar())
+	return consumer.Consume()
+}
+
+func (n *SwitchNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.Receiver, error) {
+	g := &switchGroup{
+		n:           n,
+		expressions: make([]stateful.Expression, len(n.expressions)),
+	}
+	for i, expr := range n.expressions {
+		g.expressions[i] = expr.CopyReset()
+	}
+	return g, nil
+}
+
+type switchGroup struct {
+	n           *SwitchNode
+	expressions []stateful.Expression
+
+	// the batch being received, split by branch
+	begin  edge.BeginBatchMessage
+	points map[string][]edge.BatchPointMessage
+}
+
+// route returns the branch of a point.
+// The branch is empty if the point does not match any case and there is no default branch.
+func (g *switchGroup) route(p edge.FieldsTagsTimeGetter) string {
+	for i, c := range g.n.s.Cases {
+		match, err := EvalPredicate(g.expressions[i], g.n.scopePools[i], p)
+		if err != nil {
+			g.n.diag.Error("error while evaluating expression", err, keyvalue.KV("branch", c.Branch))
+			continue
+		}
+		if match {
+			return c.Branch
+		}
+	}
+	if g.n.s.DefaultBranchName == "" {
+		g.n.unmatched.Add(1)
+	}
+	return g.n.s.DefaultBranchName
+}
+
+// collect sends the message to the children of a branch.
+func (g *switchGroup) collect(branch string, m edge.Message) error {
+	g.n.timer.Pause()
+	defer g.n.timer.Resume()
+	for i, out := range g.n.outs {
+		if g.n.branches[i] != branch {
+			continue
+		}
+		if err := out.Collect(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *switchGroup) BeginBatch(begin edge.BeginBatchMessage) error {
+	g.begin = begin
+	g.points = make(map[string][]edge.BatchPointMessage)
+	return nil
+}
+
+func (g *switchGroup) BatchPoint(bp edge.BatchPointMessage) error {
+	g.n.timer.Start()
+	defer g.n.timer.Stop()
+	if branch := g.route(bp); branch != "" {
+		g.points[branch] = append(g.points[branch], bp)
+	}
+	return nil
+}
+
+func (g *switchGroup) EndBatch(end edge.EndBatchMessage) error {
+	g.n.timer.Start()
+	defer g.n.timer.Stop()
+	// Each branch receives a batch, even if it is empty.
+	sent := make(map[string]bool)
+	for _, branch := range g.n.branches {
+		if sent[branch] {
+			continue
+		}
+		sent[branch] = true
+		points := g.points[branch]
+		begin := g.begin.ShallowCopy()
+		begin.SetSizeHint(len(points))
+		if err := g.collect(branch, edge.NewBufferedBatchMessage(begin, points, end)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *switchGroup) Point(p edge.PointMessage) error {
+	g.n.timer.Start()
+	defer g.n.timer.Stop()
+	if branch := g.route(p); branch != "" {
+		return g.collect(branch, p)
+	}
+	return nil
+}
+
+func (g *switchGroup) Barrier(b edge.BarrierMessage) error {
+	return edge.Forward(g.n.outs, b)
+}
+
+func (g *switchGroup) DeleteGroup(d edge.DeleteGroupMessage) error {
+	return edge.Forward(g.n.outs, d)
+}
+
+func (g *switchGroup) Done() {}
+
+type BranchNode struct {
+	node
+	b *pipeline.BranchNode
+}
+
+// Create a new BranchNode, which passes through the points of a branch of a switch node.
+func newBranchNode(et *ExecutingTask, n *pipeline.BranchNode, d NodeDiagnostic) (*BranchNode, error) {
+	bn := &BranchNode{
+		node: node{Node: n, et: et, diag: d},
+		b:    n,
+	}
+	bn.node.runF = bn.runBranch
+	return bn, nil
+}
+
+func (n *BranchNode) runBranch([]byte) error {
+	for m, ok := n.ins[0].Emit(); ok; m, ok = n.ins[0].Emit() {
+		if err := edge.Forward(n.outs, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
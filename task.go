@@ -116,6 +116,15 @@ type ExecutingTask struct {
 	wg       sync.WaitGroup
 	diag     TaskDiagnostic
 
+	// batch source of the lookup tables of a stream task
+	tables *BatchNode
+
+	// hash of the pipeline used to version snapshots
+	pipelineHash string
+
+	// watermark of the from and window nodes with an allowed lateness
+	watermark *taskWatermark
+
 	// Mutex for throughput var
 	tmu        sync.RWMutex
 	throughput float64
@@ -125,16 +134,22 @@ type ExecutingTask struct {
 func NewExecutingTask(tm *TaskMaster, t *Task) (*ExecutingTask, error) {
 	d := tm.diag.WithTaskContext(t.ID)
 	et := &ExecutingTask{
-		tm:      tm,
-		Task:    t,
-		outputs: make(map[string]Output),
-		lookup:  make(map[pipeline.ID]Node),
-		diag:    d,
+		tm:        tm,
+		Task:      t,
+		outputs:   make(map[string]Output),
+		lookup:    make(map[pipeline.ID]Node),
+		diag:      d,
+		watermark: new(taskWatermark),
 	}
 	err := et.link()
 	if err != nil {
 		return nil, err
 	}
+	et.pipelineHash, err = pipelineHash(t.Pipeline)
+	if err != nil {
+		// Snapshots can still be validated by node name.
+		d.Error("failed to compute pipeline hash", err)
+	}
 	return et, nil
 }
 
@@ -189,6 +204,14 @@ func (et *ExecutingTask) link() error {
 
 	// The first node is always the source node
 	et.source = et.nodes[0]
+	if et.Task.Type == StreamTask {
+		// Any batch source of a stream task provides lookup tables
+		for _, n := range et.nodes[1:] {
+			if b, ok := n.(*BatchNode); ok {
+				et.tables = b
+			}
+		}
+	}
 	return nil
 }
 
@@ -198,17 +221,19 @@ func (et *ExecutingTask) start(ins []edge.StatsEdge, snapshot *TaskSnapshot) err
 	for _, in := range ins {
 		et.source.addParentEdge(in)
 	}
-	validSnapshot := false
-	if snapshot != nil {
-		err := et.walk(func(n Node) error {
-			_, ok := snapshot.NodeSnapshots[n.Name()]
-			if !ok {
-				return fmt.Errorf("task pipeline changed not using snapshot")
-			}
-			return nil
-		})
-		validSnapshot = err == nil
+	if et.tables != nil {
+		for i := 0; i < et.tables.Count(); i++ {
+			d := et.tm.diag.WithEdgeContext(et.Task.ID, "batch", fmt.Sprintf("batch%d", i))
+			et.tables.addParentEdge(newEdge(et.Task.ID, "batch", fmt.Sprintf("batch%d", i), pipeline.BatchEdge, defaultEdgeBufferSize, d))
+		}
 	}
+	if et.tables != nil {
+		if err := et.checkDBRPs(et.tables); err != nil {
+			et.tables.Abort()
+			return err
+		}
+	}
+	validSnapshot := et.snapshotValid(snapshot)
 
 	err := et.walk(func(n Node) error {
 		if validSnapshot {
@@ -221,6 +246,9 @@ func (et *ExecutingTask) start(ins []edge.StatsEdge, snapshot *TaskSnapshot) err
 	if err != nil {
 		return err
 	}
+	if et.tables != nil {
+		et.tables.Start()
+	}
 	et.stopping = make(chan struct{})
 	if et.Task.SnapshotInterval > 0 {
 		et.wg.Add(1)
@@ -232,6 +260,23 @@ func (et *ExecutingTask) start(ins []edge.StatsEdge, snapshot *TaskSnapshot) err
 	return nil
 }
 
+// snapshotValid reports whether the snapshot was taken from the pipeline of the task.
+func (et *ExecutingTask) snapshotValid(snapshot *TaskSnapshot) bool {
+	if snapshot == nil {
+		return false
+	}
+	// Snapshots taken before the pipeline hash was recorded are validated by node name only.
+	if snapshot.PipelineHash != "" && snapshot.PipelineHash != et.pipelineHash {
+		return false
+	}
+	for _, n := range et.nodes {
+		if _, ok := snapshot.NodeSnapshots[n.Name()]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (et *ExecutingTask) stop() (err error) {
 	close(et.stopping)
 	_ = et.walk(func(n Node) error {
@@ -474,6 +519,8 @@ func (et *ExecutingTask) createNode(p pipeline.Node, d NodeDiagnostic) (n Node,
 		n, err = newUnionNode(et, t, d)
 	case *pipeline.JoinNode:
 		n, err = newJoinNode(et, t, d)
+	case *pipeline.LookupJoinNode:
+		n, err = newLookupJoinNode(et, t, d)
 	case *pipeline.FlattenNode:
 		n, err = newFlattenNode(et, t, d)
 	case *pipeline.EvalNode:
@@ -498,6 +545,40 @@ func (et *ExecutingTask) createNode(p pipeline.Node, d NodeDiagnostic) (n Node,
 		n, err = newInfluxQLNode(et, t, d)
 	case *pipeline.LogNode:
 		n, err = newLogNode(et, t, d)
+	case *pipeline.TopKNode:
+		n, err = newTopKNode(et, t, d)
+	case *pipeline.PivotNode:
+		n, err = newPivotNode(et, t, d)
+	case *pipeline.UnpivotNode:
+		n, err = newUnpivotNode(et, t, d)
+	case *pipeline.RenameNode:
+		n, err = newRenameNode(et, t, d)
+	case *pipeline.FillNode:
+		n, err = newFillNode(et, t, d)
+	case *pipeline.SwitchNode:
+		n, err = newSwitchNode(et, t, d)
+	case *pipeline.BranchNode:
+		n, err = newBranchNode(et, t, d)
+	case *pipeline.ThrottleNode:
+		n, err = newThrottleNode(et, t, d)
+	case *pipeline.ScheduleNode:
+		n, err = newScheduleNode(et, t, d)
+	case *pipeline.RollupNode:
+		n, err = newRollupNode(et, t, d)
+	case *pipeline.SloNode:
+		n, err = newSloNode(et, t, d)
+	case *pipeline.ForecastNode:
+		n, err = newForecastNode(et, t, d)
+	case *pipeline.RateNode:
+		n, err = newRateNode(et, t, d)
+	case *pipeline.QuantilesNode:
+		n, err = newQuantilesNode(et, t, d)
+	case *pipeline.HistogramNode:
+		n, err = newHistogramNode(et, t, d)
+	case *pipeline.AnomalyNode:
+		n, err = newAnomalyNode(et, t, d)
+	case *pipeline.DedupNode:
+		n, err = newDedupNode(et, t, d)
 	case *pipeline.DefaultNode:
 		n, err = newDefaultNode(et, t, d)
 	case *pipeline.DeleteNode:
@@ -531,11 +612,14 @@ func (et *ExecutingTask) createNode(p pipeline.Node, d NodeDiagnostic) (n Node,
 
 type TaskSnapshot struct {
 	NodeSnapshots map[string][]byte
+	// PipelineHash identifies the version of the pipeline the snapshot was taken from.
+	PipelineHash string
 }
 
 func (et *ExecutingTask) Snapshot() (*TaskSnapshot, error) {
 	snapshot := &TaskSnapshot{
 		NodeSnapshots: make(map[string][]byte),
+		PipelineHash:  et.pipelineHash,
 	}
 	err := et.walk(func(n Node) error {
 		data, err := n.snapshot()
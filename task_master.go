@@ -23,6 +23,7 @@ import (
 	alertservice "github.com/influxdata/kapacitor/services/alert"
 	"github.com/influxdata/kapacitor/services/alerta"
 	"github.com/influxdata/kapacitor/services/bigpanda"
+	"github.com/influxdata/kapacitor/services/calendar"
 	"github.com/influxdata/kapacitor/services/discord"
 	ec2 "github.com/influxdata/kapacitor/services/ec2/client"
 	"github.com/influxdata/kapacitor/services/hipchat"
@@ -117,6 +118,7 @@ type TaskMaster struct {
 		alertservice.Events
 		alertservice.TopicPersister
 		alertservice.InhibitorLookup
+		alertservice.InhibitionUpdater
 	}
 	InfluxDBService interface {
 		NewNamedClient(name string) (influxdb.Client, error)
@@ -215,6 +217,11 @@ type TaskMaster struct {
 		Source(*httppost.Endpoint) (sideload.Source, error)
 	}
 
+	CalendarService interface {
+		Calendar(name string) (*calendar.Calendar, error)
+		Active(name string, t time.Time) (bool, error)
+	}
+
 	TeamsService interface {
 		Global() bool
 		StateChangesOnly() bool
@@ -335,6 +342,7 @@ func (tm *TaskMaster) New(id string) *TaskMaster {
 	n.K8sService = tm.K8sService
 	n.Commander = tm.Commander
 	n.SideloadService = tm.SideloadService
+	n.CalendarService = tm.CalendarService
 	n.TeamsService = tm.TeamsService
 	n.ServiceNowService = tm.ServiceNowService
 	n.ZenossService = tm.ZenossService
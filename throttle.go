@@ -0,0 +1,233 @@
+package kapacitor
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/expvar"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+const (
+	statsThrottleDropped = "dropped"
+	statsThrottleDelayed = "delayed"
+)
+
+type ThrottleNode struct {
+	node
+	t    *pipeline.ThrottleNode
+	dims models.Dimensions
+
+	// rate tokens are added every per nanoseconds
+	rate  float64
+	per   float64
+	burst float64
+
+	buckets map[models.GroupID]*tokenBucket
+	// the buckets of each group
+	groupBuckets map[models.GroupID]map[models.GroupID]bool
+	// full buckets are removed no earlier than this time
+	nextSweep time.Time
+	now       func() time.Time
+	closing   chan struct{}
+
+	// the batch being received
+	begin  edge.BeginBatchMessage
+	points []edge.BatchPointMessage
+
+	dropped *expvar.Int
+	delayed *expvar.Int
+}
+
+// Create a new ThrottleNode, which limits the rate of points with a token bucket per group.
+func newThrottleNode(et *ExecutingTask, n *pipeline.ThrottleNode, d NodeDiagnostic) (*ThrottleNode, error) {
+	tagNames := make([]string, len(n.Tags))
+	copy(tagNames, n.Tags)
+	sort.Strings(tagNames)
+	tn := &ThrottleNode{
+		node:         node{Node: n, et: et, diag: d},
+		t:            n,
+		dims:         models.Dimensions{TagNames: tagNames},
+		rate:         float64(n.Rate),
+		per:          float64(n.Per),
+		burst:        float64(n.BurstSize()),
+		buckets:      make(map[models.GroupID]*tokenBucket),
+		groupBuckets: make(map[models.GroupID]map[models.GroupID]bool),
+		now:          time.Now,
+		closing:      make(chan struct{}),
+		dropped:      new(expvar.Int),
+		delayed:      new(expvar.Int),
+	}
+	tn.node.runF = tn.runThrottle
+	tn.node.stopF = tn.stopThrottle
+	return tn, nil
+}
+
+func (n *ThrottleNode) runThrottle([]byte) error {
+	n.statMap.Set(statsThrottleDropped, n.dropped)
+	n.statMap.Set(statsThrottleDelayed, n.delayed)
+	consumer := edge.NewConsumerWithReceiver(
+		n.ins[0],
+		edge.NewReceiverFromForwardReceiverWithStats(
+			n.outs,
+			edge.NewTimedForwardReceiver(n.timer, n),
+		),
+	)
+	return consumer.Consume()
+}
+
+func (n *ThrottleNode) stopThrottle() {
+	close(n.closing)
+}
+
+// tokenBucket holds the tokens of a group.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	// the groups taking tokens from the bucket
+	groups map[models.GroupID]bool
+}
+
+// full reports whether the bucket has refilled to the burst at time now.
+func (b *tokenBucket) full(now time.Time, rate, per, burst float64) bool {
+	return b.tokens+float64(now.Sub(b.last))*rate/per >= burst
+}
+
+// take takes a token from the bucket at time now.
+// It returns how long to wait until a token is available if the bucket is empty.
+func (b *tokenBucket) take(now time.Time, rate, per, burst float64) time.Duration {
+	b.tokens = math.Min(burst, b.tokens+float64(now.Sub(b.last))*rate/per)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - b.tokens) * per / rate))
+}
+
+// allow reports whether a message of the group may pass, after waiting for a token in delay mode.
+func (n *ThrottleNode) allow(group models.GroupID, tags models.Tags) bool {
+	id := group
+	if len(n.dims.TagNames) > 0 {
+		id = models.ToGroupID("", tags, n.dims)
+	}
+	now := n.now()
+	n.sweep(now)
+	b, ok := n.buckets[id]
+	if !ok {
+		b = &tokenBucket{
+			tokens: n.burst,
+			last:   now,
+			groups: make(map[models.GroupID]bool),
+		}
+		n.buckets[id] = b
+	}
+	if !b.groups[group] {
+		b.groups[group] = true
+		if n.groupBuckets[group] == nil {
+			n.groupBuckets[group] = make(map[models.GroupID]bool)
+		}
+		n.groupBuckets[group][id] = true
+	}
+	wait := b.take(now, n.rate, n.per, n.burst)
+	if wait == 0 {
+		return true
+	}
+	if n.t.Mode != pipeline.ThrottleDelay {
+		n.dropped.Add(1)
+		return false
+	}
+	n.delayed.Add(1)
+	n.timer.Pause()
+	defer n.timer.Resume()
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-n.closing:
+		return false
+	}
+	// The token was available after the wait
+	b.take(n.now(), n.rate, n.per, n.burst)
+	return true
+}
+
+// sweep removes the buckets that have refilled to the burst, as a new bucket starts with the burst.
+// An empty bucket is full again after burst * per / rate, so the buckets are swept at most once in that interval.
+func (n *ThrottleNode) sweep(now time.Time) {
+	if now.Before(n.nextSweep) {
+		return
+	}
+	for id, b := range n.buckets {
+		if b.full(now, n.rate, n.per, n.burst) {
+			n.removeBucket(id, b)
+		}
+	}
+	n.nextSweep = now.Add(time.Duration(n.burst * n.per / n.rate))
+}
+
+// removeBucket removes a bucket and its references from the groups.
+func (n *ThrottleNode) removeBucket(id models.GroupID, b *tokenBucket) {
+	delete(n.buckets, id)
+	for group := range b.groups {
+		delete(n.groupBuckets[group], id)
+		if len(n.groupBuckets[group]) == 0 {
+			delete(n.groupBuckets, group)
+		}
+	}
+}
+
+func (n *ThrottleNode) Point(p edge.PointMessage) (edge.Message, error) {
+	if !n.allow(p.GroupID(), p.Tags()) {
+		return nil, nil
+	}
+	return p, nil
+}
+
+func (n *ThrottleNode) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	n.begin = begin
+	n.points = n.points[:0]
+	return nil, nil
+}
+
+func (n *ThrottleNode) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	n.points = append(n.points, bp)
+	return nil, nil
+}
+
+func (n *ThrottleNode) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	points := make([]edge.BatchPointMessage, len(n.points))
+	copy(points, n.points)
+	return n.BufferedBatch(edge.NewBufferedBatchMessage(n.begin, points, end))
+}
+
+func (n *ThrottleNode) BufferedBatch(batch edge.BufferedBatchMessage) (edge.Message, error) {
+	if !n.allow(batch.Begin().GroupID(), batch.Begin().Tags()) {
+		return nil, nil
+	}
+	return batch, nil
+}
+
+func (n *ThrottleNode) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	n.sweep(n.now())
+	return b, nil
+}
+
+func (n *ThrottleNode) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	group := d.GroupID()
+	for id := range n.groupBuckets[group] {
+		// Buckets keyed by tags may be shared with other groups
+		b := n.buckets[id]
+		delete(b.groups, group)
+		if len(b.groups) == 0 {
+			delete(n.buckets, id)
+		}
+	}
+	delete(n.groupBuckets, group)
+	return d, nil
+}
+
+func (n *ThrottleNode) Done() {}
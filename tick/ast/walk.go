@@ -49,6 +49,17 @@ func Walk(root Node, f func(n Node) (Node, error)) (Node, error) {
 			return nil, err
 		}
 		node.Right = r
+	case *ChainNode:
+		r, err := Walk(node.Left, f)
+		if err != nil {
+			return nil, err
+		}
+		node.Left = r
+		r, err = Walk(node.Right, f)
+		if err != nil {
+			return nil, err
+		}
+		node.Right = r
 	case *FunctionNode:
 		for i := range node.Args {
 			r, err := Walk(node.Args[i], f)
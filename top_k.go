@@ -0,0 +1,309 @@
+package kapacitor
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/expvar"
+	"github.com/influxdata/kapacitor/keyvalue"
+	"github.com/influxdata/kapacitor/models"
+	"github.com/influxdata/kapacitor/pipeline"
+)
+
+const (
+	statsTopKEvicted = "items_evicted"
+)
+
+type TopKNode struct {
+	node
+	t    *pipeline.TopKNode
+	dims models.Dimensions
+
+	// name and time of the window being ranked
+	name  string
+	time  time.Time
+	items map[models.GroupID]*topKItem
+	// min heap of the items, used to find the item to evict
+	heap topKHeap
+	// ranked reports whether the ranking of the window has been emitted
+	ranked bool
+	// closed is the set of groups that have no more batches for the window
+	closed map[models.GroupID]bool
+	// expected is the set of groups of the previous window,
+	// the window is ranked once all of them are closed
+	expected map[models.GroupID]bool
+
+	// the batch being received
+	begin  edge.BeginBatchMessage
+	points []edge.BatchPointMessage
+
+	evicted *expvar.Int
+}
+
+// topKItem is the aggregated value of a single item in a window.
+type topKItem struct {
+	id    models.GroupID
+	value float64
+	tags  models.Tags
+	time  time.Time
+	// index of the item in the heap
+	index int
+}
+
+// Create a new TopKNode, which ranks items across all groups of a window.
+func newTopKNode(et *ExecutingTask, n *pipeline.TopKNode, d NodeDiagnostic) (*TopKNode, error) {
+	tagNames := make([]string, len(n.Tags))
+	copy(tagNames, n.Tags)
+	sort.Strings(tagNames)
+	tn := &TopKNode{
+		node:    node{Node: n, et: et, diag: d},
+		t:       n,
+		dims:    models.Dimensions{TagNames: tagNames},
+		items:   make(map[models.GroupID]*topKItem),
+		closed:  make(map[models.GroupID]bool),
+		evicted: new(expvar.Int),
+	}
+	tn.node.runF = tn.runTopK
+	return tn, nil
+}
+
+func (n *TopKNode) runTopK([]byte) error {
+	if n.t.Capacity > 0 {
+		n.statMap.Set(statsTopKEvicted, n.evicted)
+	}
+	consumer := edge.NewConsumerWithReceiver(
+		n.ins[0],
+		edge.NewReceiverFromForwardReceiverWithStats(
+			n.outs,
+			edge.NewTimedForwardReceiver(n.timer, n),
+		),
+	)
+	return consumer.Consume()
+}
+
+func (n *TopKNode) BeginBatch(begin edge.BeginBatchMessage) (edge.Message, error) {
+	n.begin = begin
+	n.points = n.points[:0]
+	return nil, nil
+}
+
+func (n *TopKNode) BatchPoint(bp edge.BatchPointMessage) (edge.Message, error) {
+	n.points = append(n.points, bp)
+	return nil, nil
+}
+
+func (n *TopKNode) EndBatch(end edge.EndBatchMessage) (edge.Message, error) {
+	return nil, n.batch(n.begin, n.points)
+}
+
+func (n *TopKNode) BufferedBatch(batch edge.BufferedBatchMessage) (edge.Message, error) {
+	return nil, n.batch(batch.Begin(), batch.Points())
+}
+
+// batch adds the points of a batch to its window and emits the ranking of the window once it is closed.
+func (n *TopKNode) batch(begin edge.BeginBatchMessage, points []edge.BatchPointMessage) error {
+	if begin.Time().After(n.time) {
+		// A new window has started, the previous window is closed
+		if !n.ranked {
+			if err := n.emit(n.rank()); err != nil {
+				return err
+			}
+		}
+		n.name = begin.Name()
+		n.time = begin.Time()
+		n.ranked = false
+		n.expected = n.closed
+		n.closed = make(map[models.GroupID]bool, len(n.expected))
+	} else if n.ranked {
+		n.diag.Error("cannot rank batch", errors.New("the window has already been ranked"), keyvalue.KV("group", string(begin.GroupID())))
+		return nil
+	}
+	for _, bp := range points {
+		v, ok := numToFloat(bp.Fields()[n.t.Field])
+		if !ok {
+			n.diag.Error("cannot rank point", errors.New("field is missing or the wrong type"), keyvalue.KV("field", n.t.Field))
+			continue
+		}
+		id := begin.GroupID()
+		if len(n.dims.TagNames) > 0 {
+			id = models.ToGroupID("", bp.Tags(), n.dims)
+		}
+		n.add(id, v, bp.Tags(), bp.Time())
+	}
+	if begin.Time().Equal(n.time) {
+		n.closed[begin.GroupID()] = true
+	}
+	return n.rankIfClosed()
+}
+
+func (n *TopKNode) Point(p edge.PointMessage) (edge.Message, error) {
+	return nil, nil
+}
+
+// Barrier closes the window for the group of the barrier once the barrier is past the end of the window.
+func (n *TopKNode) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	if !n.ranked && !n.time.IsZero() && !b.Time().Before(n.time) {
+		n.closed[b.GroupID()] = true
+		if err := n.rankIfClosed(); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (n *TopKNode) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	delete(n.expected, d.GroupID())
+	if err := n.rankIfClosed(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Done emits the ranking of the last window.
+func (n *TopKNode) Done() {
+	if n.ranked {
+		return
+	}
+	if err := n.emit(n.rank()); err != nil {
+		n.diag.Error("failed to emit ranking of the last window", err)
+	}
+}
+
+// rankIfClosed emits the ranking of the window once all groups of the previous window are closed.
+// Without a previous window the groups are unknown and the window is ranked when the next window starts.
+func (n *TopKNode) rankIfClosed() error {
+	if n.ranked || len(n.expected) == 0 {
+		return nil
+	}
+	for id := range n.expected {
+		if !n.closed[id] {
+			return nil
+		}
+	}
+	n.ranked = true
+	return n.emit(n.rank())
+}
+
+func (n *TopKNode) emit(m edge.Message) error {
+	if m == nil {
+		return nil
+	}
+	n.timer.Pause()
+	err := edge.Forward(n.outs, m)
+	n.timer.Resume()
+	return err
+}
+
+// add adds the value of an item to the current window.
+func (n *TopKNode) add(id models.GroupID, v float64, tags models.Tags, t time.Time) {
+	if item, ok := n.items[id]; ok {
+		item.tags = tags
+		item.time = t
+		switch n.t.Method {
+		case pipeline.TopKSum:
+			item.value += v
+		default:
+			if v <= item.value {
+				return
+			}
+			item.value = v
+		}
+		heap.Fix(&n.heap, item.index)
+		return
+	}
+	if n.t.Capacity > 0 && int64(len(n.items)) >= n.t.Capacity {
+		min := n.heap[0]
+		switch n.t.Method {
+		case pipeline.TopKSum:
+			// Space-saving, the new item replaces the smallest and inherits its sum.
+			v += min.value
+		default:
+			if v <= min.value {
+				return
+			}
+		}
+		heap.Pop(&n.heap)
+		delete(n.items, min.id)
+		n.evicted.Add(1)
+	}
+	item := &topKItem{
+		id:    id,
+		value: v,
+		tags:  tags,
+		time:  t,
+	}
+	n.items[id] = item
+	heap.Push(&n.heap, item)
+}
+
+// rank returns a batch with the top k items of the current window and resets the window.
+func (n *TopKNode) rank() edge.Message {
+	if len(n.items) == 0 {
+		return nil
+	}
+	items := make([]*topKItem, 0, len(n.items))
+	for _, item := range n.items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].value != items[j].value {
+			return items[i].value > items[j].value
+		}
+		return items[i].id < items[j].id
+	})
+	if int64(len(items)) > n.t.K {
+		items = items[:n.t.K]
+	}
+	points := make([]edge.BatchPointMessage, len(items))
+	for i, item := range items {
+		points[i] = edge.NewBatchPointMessage(
+			models.Fields{
+				n.t.Field:  item.value,
+				n.t.RankAs: int64(i + 1),
+			},
+			item.tags,
+			item.time,
+		)
+	}
+	n.items = make(map[models.GroupID]*topKItem)
+	n.heap = n.heap[:0]
+	return edge.NewBufferedBatchMessage(
+		edge.NewBeginBatchMessage(n.name, nil, false, n.time, len(points)),
+		points,
+		edge.NewEndBatchMessage(),
+	)
+}
+
+// topKHeap is a min heap of items by value, ties are broken by the greater group ID.
+type topKHeap []*topKItem
+
+func (h topKHeap) Len() int { return len(h) }
+func (h topKHeap) Less(i, j int) bool {
+	if h[i].value != h[j].value {
+		return h[i].value < h[j].value
+	}
+	return h[i].id > h[j].id
+}
+func (h topKHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *topKHeap) Push(x interface{}) {
+	item := x.(*topKItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *topKHeap) Pop() interface{} {
+	old := *h
+	l := len(old)
+	x := old[l-1]
+	old[l-1] = nil
+	*h = old[:l-1]
+	return x
+}
@@ -0,0 +1,119 @@
+package kapacitor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/kapacitor/edge"
+	"github.com/influxdata/kapacitor/expvar"
+)
+
+const (
+	statsLatePoints = "late_points"
+)
+
+// taskWatermark tracks the progress of event time through a task.
+// It is the most recent point time seen by any from or window node of the task.
+type taskWatermark struct {
+	mu  sync.Mutex
+	max time.Time
+}
+
+func (w *taskWatermark) observe(t time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t.After(w.max) {
+		w.max = t
+	}
+}
+
+func (w *taskWatermark) time() time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.max
+}
+
+// watermark is the view of a node on the watermark of its task.
+// It is shared by all groups of the node and trails the most recent point time by the allowed lateness.
+type watermark struct {
+	lateness time.Duration
+	task     *taskWatermark
+	// max is the most recent time observed by the node itself.
+	max time.Time
+}
+
+func newWatermark(et *ExecutingTask, lateness time.Duration) watermark {
+	return watermark{
+		lateness: lateness,
+		task:     et.watermark,
+	}
+}
+
+// observe advances the watermark of the node and of its task if t is the most recent time seen.
+func (w *watermark) observe(t time.Time) {
+	if t.After(w.max) {
+		w.max = t
+	}
+	w.task.observe(t)
+}
+
+// time returns the current watermark time of the task, points before it are late.
+func (w *watermark) time() time.Time {
+	return w.task.time().Add(-w.lateness)
+}
+
+// received returns the watermark time up to the points received by the node.
+// Windows are emitted by it so that points still on their way from the from node are not late,
+// since the from node may already have advanced the watermark of the task past them.
+func (w *watermark) received() time.Time {
+	return w.max.Add(-w.lateness)
+}
+
+// lateOutput handles points that arrived after the watermark had passed them.
+// Late points are counted and optionally written back into Kapacitor.
+type lateOutput struct {
+	et              *ExecutingTask
+	database        string
+	retentionPolicy string
+	measurement     string
+
+	latePoints *expvar.Int
+
+	diag NodeDiagnostic
+}
+
+func newLateOutput(et *ExecutingTask, database, retentionPolicy, measurement string, d NodeDiagnostic) (*lateOutput, error) {
+	// Check that a loop has not been created within this task
+	if database != "" {
+		for _, dbrp := range et.Task.DBRPs {
+			if dbrp.Database == database && dbrp.RetentionPolicy == retentionPolicy {
+				return nil, fmt.Errorf("loop detected on late points dbrp: %v", dbrp)
+			}
+		}
+	}
+	return &lateOutput{
+		et:              et,
+		database:        database,
+		retentionPolicy: retentionPolicy,
+		measurement:     measurement,
+		latePoints:      new(expvar.Int),
+		diag:            d,
+	}, nil
+}
+
+func (o *lateOutput) collect(p edge.PointMessage) {
+	o.latePoints.Add(1)
+	if o.database == "" {
+		return
+	}
+	p = p.ShallowCopy()
+	p.SetDatabase(o.database)
+	p.SetRetentionPolicy(o.retentionPolicy)
+	if o.measurement != "" {
+		p.SetName(o.measurement)
+	}
+	if err := o.et.tm.WriteKapacitorPoint(p); err != nil {
+		o.diag.Error("failed to write late point", err)
+	}
+}
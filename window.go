@@ -3,6 +3,8 @@ package kapacitor
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/influxdata/kapacitor/edge"
@@ -13,24 +15,86 @@ import (
 type WindowNode struct {
 	node
 	w *pipeline.WindowNode
+
+	// mu protects the state of the windows so they can be snapshotted.
+	mu       sync.Mutex
+	windows  map[models.GroupID]window
+	restored map[models.GroupID]windowSnapshot
+
+	// watermark is shared by all windows and with the task, it is only used if allowed lateness is set.
+	watermark watermark
+	late      *lateOutput
+	// nextWatermarkEmit is no later than the earliest next emit time of the windows by event time,
+	// zero if it is unknown.
+	nextWatermarkEmit time.Time
+
+	// calendar is the interval of calendar windows, nil if the windows are not aligned to the calendar.
+	calendar *calendarInterval
+
+	// sessionTime is the most recent time seen across all groups of session windows.
+	sessionTime time.Time
+	// sessionExpiry is no later than the earliest time an open session expires, zero if there are no open sessions.
+	sessionExpiry time.Time
+}
+
+// window is the per group state of a WindowNode.
+type window interface {
+	edge.ForwardReceiver
+	snapshot() windowSnapshot
+	restore(windowSnapshot)
+}
+
+// windowSnapshot is the persisted state of a single window.
+type windowSnapshot struct {
+	// NextEmit is the next emit time of windows by time.
+	NextEmit time.Time
+	// NextEmitCount and Count are the next emit index and current count of windows by count.
+	NextEmitCount int
+	Count         int
+	// Points are the buffered points in the window, ordered by arrival.
+	Points []pointSnapshot
 }
 
 // Create a new  WindowNode, which windows data for a period of time and emits the window.
 func newWindowNode(et *ExecutingTask, n *pipeline.WindowNode, d NodeDiagnostic) (*WindowNode, error) {
-	if n.Period == 0 && n.PeriodCount == 0 {
-		return nil, errors.New("window node must have either a non zero period or non zero period count")
+	if n.Period == 0 && n.PeriodCount == 0 && n.SessionGap == 0 && n.Calendar == "" {
+		return nil, errors.New("window node must have either a non zero period, period count, session gap or a calendar")
 	}
 	wn := &WindowNode{
-		w:    n,
-		node: node{Node: n, et: et, diag: d},
+		w:       n,
+		node:    node{Node: n, et: et, diag: d},
+		windows: make(map[models.GroupID]window),
+	}
+	if n.Calendar != "" {
+		interval, err := newCalendarInterval(n.Calendar, n.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		wn.calendar = interval
 	}
 	wn.node.runF = wn.runWindow
+	if n.AllowedLateness != 0 {
+		late, err := newLateOutput(et, n.LateDatabase, n.LateRetentionPolicy, n.LateMeasurement, d)
+		if err != nil {
+			return nil, err
+		}
+		wn.watermark = newWatermark(et, n.AllowedLateness)
+		wn.late = late
+	}
 	return wn, nil
 }
 
-func (n *WindowNode) runWindow([]byte) (err error) {
+func (n *WindowNode) runWindow(snapshot []byte) (err error) {
+	if snapshot != nil {
+		if err := n.restore(snapshot); err != nil {
+			n.diag.Error("failed to restore window state from snapshot", err)
+		}
+	}
 	consumer := edge.NewGroupedConsumer(n.ins[0], n)
 	n.statMap.Set(statCardinalityGauge, consumer.CardinalityVar())
+	if n.late != nil {
+		n.statMap.Set(statsLatePoints, n.late.latePoints)
+	}
 	err = consumer.Consume()
 	return
 }
@@ -40,20 +104,145 @@ func (n *WindowNode) NewGroup(group edge.GroupInfo, first edge.PointMeta) (edge.
 	if err != nil {
 		return nil, err
 	}
+	n.mu.Lock()
+	if s, ok := n.restored[group.ID]; ok {
+		r.restore(s)
+		delete(n.restored, group.ID)
+	}
+	n.windows[group.ID] = r
+	if w, ok := r.(*windowByEventTime); ok {
+		n.updateNextWatermarkEmit(w.nextEmit)
+	}
+	n.mu.Unlock()
 	return edge.NewReceiverFromForwardReceiverWithStats(
 		n.outs,
-		edge.NewTimedForwardReceiver(n.timer, r),
+		edge.NewTimedForwardReceiver(n.timer, edge.NewLockedForwardReceiver(&n.mu, r)),
 	), nil
 }
 
+// DeleteGroup removes the window of the group.
+// The lock must be held by the caller.
 func (n *WindowNode) DeleteGroup(group models.GroupID) {
-	// Nothing to do
+	delete(n.windows, group)
+}
+
+// forward sends a message of any group to the children of the node.
+func (n *WindowNode) forward(msg edge.Message) error {
+	n.timer.Pause()
+	err := edge.Forward(n.outs, msg)
+	n.timer.Resume()
+	return err
+}
+
+// advanceSessions advances the time of the node to t and closes every session which has expired.
+// Sessions are closed by the time of the whole node so that a group which goes quiet still closes its session.
+// The lock must be held by the caller.
+func (n *WindowNode) advanceSessions(t time.Time) error {
+	if t.After(n.sessionTime) {
+		n.sessionTime = t
+	}
+	if n.sessionExpiry.IsZero() || n.sessionTime.Before(n.sessionExpiry) {
+		return nil
+	}
+	n.sessionExpiry = time.Time{}
+	for _, w := range n.windows {
+		s, ok := w.(*windowBySession)
+		if !ok || len(s.points) == 0 {
+			continue
+		}
+		if s.expired(n.sessionTime) {
+			if err := n.forward(s.batch()); err != nil {
+				return err
+			}
+			continue
+		}
+		n.updateSessionExpiry(s.last.Add(s.gap))
+	}
+	return nil
+}
+
+// emitWatermark emits the windows of every group that end at or before the watermark.
+// The lock must be held by the caller.
+func (n *WindowNode) emitWatermark() error {
+	wm := n.watermark.received()
+	if wm.Before(n.nextWatermarkEmit) {
+		return nil
+	}
+	n.nextWatermarkEmit = time.Time{}
+	for _, w := range n.windows {
+		ew, ok := w.(*windowByEventTime)
+		if !ok {
+			continue
+		}
+		if err := ew.emit(wm); err != nil {
+			return err
+		}
+		n.updateNextWatermarkEmit(ew.nextEmit)
+	}
+	return nil
 }
 
-func (n *WindowNode) newWindow(group edge.GroupInfo, first edge.PointMeta) (edge.ForwardReceiver, error) {
+// updateNextWatermarkEmit records that a window by event time is next emitted at t.
+func (n *WindowNode) updateNextWatermarkEmit(t time.Time) {
+	if n.nextWatermarkEmit.IsZero() || t.Before(n.nextWatermarkEmit) {
+		n.nextWatermarkEmit = t
+	}
+}
+
+// updateSessionExpiry records that an open session expires at t.
+func (n *WindowNode) updateSessionExpiry(t time.Time) {
+	if n.sessionExpiry.IsZero() || t.Before(n.sessionExpiry) {
+		n.sessionExpiry = t
+	}
+}
+
+func (n *WindowNode) snapshot() ([]byte, error) {
+	n.mu.Lock()
+	windows := make(map[models.GroupID]windowSnapshot, len(n.windows))
+	for id, w := range n.windows {
+		windows[id] = w.snapshot()
+	}
+	n.mu.Unlock()
+	return encodeNodeSnapshot(windows)
+}
+
+func (n *WindowNode) restore(data []byte) error {
+	windows := make(map[models.GroupID]windowSnapshot)
+	if err := decodeNodeSnapshot(data, &windows); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	n.restored = windows
+	n.mu.Unlock()
+	return nil
+}
+
+func (n *WindowNode) newWindow(group edge.GroupInfo, first edge.PointMeta) (window, error) {
 	switch {
+	case n.calendar != nil:
+		return newWindowByCalendar(
+			n,
+			first.Name(),
+			first.Time(),
+			group,
+			n.calendar,
+			n.w.FillPeriodFlag,
+			n.diag,
+		), nil
+	case n.w.Period != 0 && n.w.AllowedLateness != 0:
+		return newWindowByEventTime(
+			n,
+			first.Name(),
+			first.Time(),
+			group,
+			n.w.Period,
+			n.w.Every,
+			n.w.AlignFlag,
+			n.w.FillPeriodFlag,
+		), nil
 	case n.w.Period != 0:
 		return newWindowByTime(
+			n,
 			first.Name(),
 			first.Time(),
 			group,
@@ -65,6 +254,7 @@ func (n *WindowNode) newWindow(group edge.GroupInfo, first edge.PointMeta) (edge
 		), nil
 	case n.w.PeriodCount != 0:
 		return newWindowByCount(
+			n,
 			first.Name(),
 			group,
 			int(n.w.PeriodCount),
@@ -72,12 +262,21 @@ func (n *WindowNode) newWindow(group edge.GroupInfo, first edge.PointMeta) (edge
 			n.w.FillPeriodFlag,
 			n.diag,
 		), nil
+	case n.w.SessionGap != 0:
+		return newWindowBySession(
+			n,
+			first.Name(),
+			group,
+			n.w.SessionGap,
+			n.diag,
+		), nil
 	default:
-		return nil, errors.New("unreachable code, window node should have a non-zero period or period count")
+		return nil, errors.New("unreachable code, window node should have a non-zero period, period count, session gap or a calendar")
 	}
 }
 
 type windowByTime struct {
+	n     *WindowNode
 	name  string
 	group edge.GroupInfo
 
@@ -95,6 +294,7 @@ type windowByTime struct {
 }
 
 func newWindowByTime(
+	n *WindowNode,
 	name string,
 	t time.Time,
 	group edge.GroupInfo,
@@ -105,7 +305,22 @@ func newWindowByTime(
 	d NodeDiagnostic,
 
 ) *windowByTime {
-	// Determine nextEmit time.
+	return &windowByTime{
+		n:          n,
+		name:       name,
+		group:      group,
+		nextEmit:   firstEmit(t, period, every, align, fillPeriod),
+		buf:        &windowTimeBuffer{diag: d},
+		align:      align,
+		fillPeriod: fillPeriod,
+		period:     period,
+		every:      every,
+		diag:       d,
+	}
+}
+
+// firstEmit determines the first emit time of a window by time based on the time of the first point.
+func firstEmit(t time.Time, period, every time.Duration, align, fillPeriod bool) time.Time {
 	var nextEmit time.Time
 	if fillPeriod {
 		nextEmit = t.Add(period)
@@ -124,17 +339,7 @@ func newWindowByTime(
 			nextEmit = nextEmit.Truncate(every)
 		}
 	}
-	return &windowByTime{
-		name:       name,
-		group:      group,
-		nextEmit:   nextEmit,
-		buf:        &windowTimeBuffer{diag: d},
-		align:      align,
-		fillPeriod: fillPeriod,
-		period:     period,
-		every:      every,
-		diag:       d,
-	}
+	return nextEmit
 }
 
 func (w *windowByTime) BeginBatch(edge.BeginBatchMessage) (edge.Message, error) {
@@ -181,10 +386,28 @@ func (w *windowByTime) Barrier(b edge.BarrierMessage) (msg edge.Message, err err
 	return
 }
 func (w *windowByTime) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	w.n.DeleteGroup(d.GroupID())
 	return d, nil
 }
 func (w *windowByTime) Done() {}
 
+func (w *windowByTime) snapshot() windowSnapshot {
+	s := windowSnapshot{
+		NextEmit: w.nextEmit,
+	}
+	w.buf.each(func(p edge.PointMessage) {
+		s.Points = append(s.Points, newPointSnapshot(p))
+	})
+	return s
+}
+
+func (w *windowByTime) restore(s windowSnapshot) {
+	w.nextEmit = s.NextEmit
+	for _, p := range s.Points {
+		w.buf.insert(p.pointMessage(w.name, w.group.Dimensions))
+	}
+}
+
 func (w *windowByTime) Point(p edge.PointMessage) (msg edge.Message, err error) {
 	if w.every == 0 {
 		// Insert point before.
@@ -329,6 +552,25 @@ func (b *windowTimeBuffer) purge(oldest time.Time, inclusive bool) {
 	}
 }
 
+// Calls f for each point in the buffer in order.
+func (b *windowTimeBuffer) each(f func(p edge.PointMessage)) {
+	if b.size == 0 {
+		return
+	}
+	if b.stop > b.start {
+		for _, p := range b.window[b.start:b.stop] {
+			f(p)
+		}
+	} else {
+		for _, p := range b.window[b.start:] {
+			f(p)
+		}
+		for _, p := range b.window[:b.stop] {
+			f(p)
+		}
+	}
+}
+
 // Returns a copy of the current buffer.
 // TODO(nathanielc): Optimize this function use buffered vs unbuffered batch messages.
 func (b *windowTimeBuffer) points() []edge.BatchPointMessage {
@@ -358,6 +600,7 @@ func (b *windowTimeBuffer) points() []edge.BatchPointMessage {
 }
 
 type windowByCount struct {
+	n     *WindowNode
 	name  string
 	group edge.GroupInfo
 
@@ -374,6 +617,7 @@ type windowByCount struct {
 }
 
 func newWindowByCount(
+	n *WindowNode,
 	name string,
 	group edge.GroupInfo,
 	period,
@@ -387,6 +631,7 @@ func newWindowByCount(
 		nextEmit = period
 	}
 	return &windowByCount{
+		n:        n,
 		name:     name,
 		group:    group,
 		buf:      make([]edge.BatchPointMessage, period),
@@ -410,18 +655,32 @@ func (w *windowByCount) Barrier(b edge.BarrierMessage) (edge.Message, error) {
 	return b, nil
 }
 func (w *windowByCount) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	w.n.DeleteGroup(d.GroupID())
 	return d, nil
 }
 func (w *windowByCount) Done() {}
 
-func (w *windowByCount) Point(p edge.PointMessage) (msg edge.Message, err error) {
-	w.buf[w.stop] = edge.BatchPointFromPoint(p)
-	w.stop = (w.stop + 1) % w.period
-	if w.size == w.period {
-		w.start = (w.start + 1) % w.period
-	} else {
-		w.size++
+func (w *windowByCount) snapshot() windowSnapshot {
+	s := windowSnapshot{
+		NextEmitCount: w.nextEmit,
+		Count:         w.count,
+	}
+	for _, p := range w.points() {
+		s.Points = append(s.Points, newBatchPointSnapshot(p))
+	}
+	return s
+}
+
+func (w *windowByCount) restore(s windowSnapshot) {
+	for _, p := range s.Points {
+		w.insert(p.batchPointMessage())
 	}
+	w.count = s.Count
+	w.nextEmit = s.NextEmitCount
+}
+
+func (w *windowByCount) Point(p edge.PointMessage) (msg edge.Message, err error) {
+	w.insert(edge.BatchPointFromPoint(p))
 	w.count++
 	//Check if its time to emit
 	if w.count == w.nextEmit {
@@ -431,6 +690,17 @@ func (w *windowByCount) Point(p edge.PointMessage) (msg edge.Message, err error)
 	return
 }
 
+// insert adds the point to the buffer, overwriting the oldest point once the buffer is full.
+func (w *windowByCount) insert(p edge.BatchPointMessage) {
+	w.buf[w.stop] = p
+	w.stop = (w.stop + 1) % w.period
+	if w.size == w.period {
+		w.start = (w.start + 1) % w.period
+	} else {
+		w.size++
+	}
+}
+
 func (w *windowByCount) batch() edge.BufferedBatchMessage {
 	points := w.points()
 	return edge.NewBufferedBatchMessage(
@@ -468,3 +738,390 @@ func (w *windowByCount) points() []edge.BatchPointMessage {
 	}
 	return points
 }
+
+// windowByCalendar emits tumbling windows aligned to calendar days, weeks, months or years.
+type windowByCalendar struct {
+	n     *WindowNode
+	name  string
+	group edge.GroupInfo
+
+	interval *calendarInterval
+
+	// nextEmit is the end of the current window.
+	nextEmit time.Time
+
+	buf *windowTimeBuffer
+}
+
+func newWindowByCalendar(
+	n *WindowNode,
+	name string,
+	t time.Time,
+	group edge.GroupInfo,
+	interval *calendarInterval,
+	fillPeriod bool,
+	d NodeDiagnostic,
+) *windowByCalendar {
+	nextEmit := interval.next(t)
+	if fillPeriod && !interval.start(t).Equal(t) {
+		// Skip the partial first window, its points are purged when the next window is emitted.
+		nextEmit = interval.next(nextEmit)
+	}
+	return &windowByCalendar{
+		n:        n,
+		name:     name,
+		group:    group,
+		interval: interval,
+		nextEmit: nextEmit,
+		buf:      &windowTimeBuffer{diag: d},
+	}
+}
+
+func (w *windowByCalendar) BeginBatch(edge.BeginBatchMessage) (edge.Message, error) {
+	return nil, errors.New("window does not support batch data")
+}
+func (w *windowByCalendar) BatchPoint(edge.BatchPointMessage) (edge.Message, error) {
+	return nil, errors.New("window does not support batch data")
+}
+func (w *windowByCalendar) EndBatch(edge.EndBatchMessage) (edge.Message, error) {
+	return nil, errors.New("window does not support batch data")
+}
+func (w *windowByCalendar) Barrier(b edge.BarrierMessage) (msg edge.Message, err error) {
+	if !b.Time().Before(w.nextEmit) {
+		msg = w.emit(b.Time())
+	}
+	return
+}
+func (w *windowByCalendar) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	w.n.DeleteGroup(d.GroupID())
+	return d, nil
+}
+func (w *windowByCalendar) Done() {}
+
+func (w *windowByCalendar) Point(p edge.PointMessage) (msg edge.Message, err error) {
+	// Since more points can arrive with the same time we need to use a left aligned window [start, end).
+	if !p.Time().Before(w.nextEmit) {
+		msg = w.emit(p.Time())
+	}
+	w.buf.insert(p)
+	return
+}
+
+// emit returns the current window as a batch message and moves to the window containing t.
+func (w *windowByCalendar) emit(t time.Time) edge.BufferedBatchMessage {
+	// purge the points of previous windows
+	start := w.interval.start(w.nextEmit.Add(-1))
+	w.buf.purge(start, true)
+
+	points := w.buf.points()
+	msg := edge.NewBufferedBatchMessage(
+		edge.NewBeginBatchMessage(
+			w.name,
+			w.group.Tags,
+			w.group.Dimensions.ByName,
+			w.nextEmit,
+			len(points),
+		),
+		points,
+		edge.NewEndBatchMessage(),
+	)
+	w.nextEmit = w.interval.next(t)
+	return msg
+}
+
+func (w *windowByCalendar) snapshot() windowSnapshot {
+	s := windowSnapshot{
+		NextEmit: w.nextEmit,
+	}
+	w.buf.each(func(p edge.PointMessage) {
+		s.Points = append(s.Points, newPointSnapshot(p))
+	})
+	return s
+}
+
+func (w *windowByCalendar) restore(s windowSnapshot) {
+	w.nextEmit = s.NextEmit
+	for _, p := range s.Points {
+		w.buf.insert(p.pointMessage(w.name, w.group.Dimensions))
+	}
+}
+
+// windowBySession buffers points until no point has arrived for the duration of the gap.
+type windowBySession struct {
+	n     *WindowNode
+	name  string
+	group edge.GroupInfo
+
+	gap    time.Duration
+	points []edge.BatchPointMessage
+	// Time of the most recent point in the session
+	last time.Time
+
+	diag NodeDiagnostic
+}
+
+func newWindowBySession(
+	n *WindowNode,
+	name string,
+	group edge.GroupInfo,
+	gap time.Duration,
+	d NodeDiagnostic,
+) *windowBySession {
+	return &windowBySession{
+		n:     n,
+		name:  name,
+		group: group,
+		gap:   gap,
+		diag:  d,
+	}
+}
+
+func (w *windowBySession) BeginBatch(edge.BeginBatchMessage) (edge.Message, error) {
+	return nil, errors.New("window does not support batch data")
+}
+func (w *windowBySession) BatchPoint(edge.BatchPointMessage) (edge.Message, error) {
+	return nil, errors.New("window does not support batch data")
+}
+func (w *windowBySession) EndBatch(edge.EndBatchMessage) (edge.Message, error) {
+	return nil, errors.New("window does not support batch data")
+}
+
+// Barrier closes the sessions of all groups for which the gap has elapsed since their last point.
+func (w *windowBySession) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	return nil, w.n.advanceSessions(b.Time())
+}
+func (w *windowBySession) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	w.n.DeleteGroup(d.GroupID())
+	return d, nil
+}
+
+// Done emits the open session.
+func (w *windowBySession) Done() {
+	if len(w.points) == 0 {
+		return
+	}
+	if err := edge.Forward(w.n.outs, w.batch()); err != nil {
+		w.diag.Error("failed to emit open session", err)
+	}
+}
+
+func (w *windowBySession) Point(p edge.PointMessage) (edge.Message, error) {
+	// Close the expired sessions, including the current session, before adding the point of the next session.
+	if err := w.n.advanceSessions(p.Time()); err != nil {
+		return nil, err
+	}
+	w.insert(edge.BatchPointFromPoint(p))
+	return nil, nil
+}
+
+func (w *windowBySession) insert(p edge.BatchPointMessage) {
+	w.points = append(w.points, p)
+	if p.Time().After(w.last) {
+		w.last = p.Time()
+	}
+	w.n.updateSessionExpiry(w.last.Add(w.gap))
+}
+
+// expired reports whether the current session has been closed by time t.
+func (w *windowBySession) expired(t time.Time) bool {
+	return len(w.points) > 0 && t.Sub(w.last) >= w.gap
+}
+
+// batch returns the current session as a batch message and starts a new session.
+func (w *windowBySession) batch() edge.BufferedBatchMessage {
+	points := w.points
+	w.points = nil
+	return edge.NewBufferedBatchMessage(
+		edge.NewBeginBatchMessage(
+			w.name,
+			w.group.Tags,
+			w.group.Dimensions.ByName,
+			w.last,
+			len(points),
+		),
+		points,
+		edge.NewEndBatchMessage(),
+	)
+}
+
+func (w *windowBySession) snapshot() windowSnapshot {
+	s := windowSnapshot{}
+	for _, p := range w.points {
+		s.Points = append(s.Points, newBatchPointSnapshot(p))
+	}
+	return s
+}
+
+func (w *windowBySession) restore(s windowSnapshot) {
+	for _, p := range s.Points {
+		w.insert(p.batchPointMessage())
+	}
+}
+
+// windowByEventTime emits windows by time once the watermark of the node has passed the end of the window.
+// Points are kept ordered by time so that out of order points land in the correct window.
+type windowByEventTime struct {
+	n     *WindowNode
+	name  string
+	group edge.GroupInfo
+
+	nextEmit time.Time
+
+	// points ordered by time
+	points []edge.PointMessage
+
+	align bool
+
+	period time.Duration
+	every  time.Duration
+}
+
+func newWindowByEventTime(
+	n *WindowNode,
+	name string,
+	t time.Time,
+	group edge.GroupInfo,
+	period,
+	every time.Duration,
+	align,
+	fillPeriod bool,
+) *windowByEventTime {
+	return &windowByEventTime{
+		n:        n,
+		name:     name,
+		group:    group,
+		nextEmit: firstEmit(t, period, every, align, fillPeriod),
+		align:    align,
+		period:   period,
+		every:    every,
+	}
+}
+
+func (w *windowByEventTime) BeginBatch(edge.BeginBatchMessage) (edge.Message, error) {
+	return nil, errors.New("window does not support batch data")
+}
+func (w *windowByEventTime) BatchPoint(edge.BatchPointMessage) (edge.Message, error) {
+	return nil, errors.New("window does not support batch data")
+}
+func (w *windowByEventTime) EndBatch(edge.EndBatchMessage) (edge.Message, error) {
+	return nil, errors.New("window does not support batch data")
+}
+
+// Barrier advances the watermark to the time of the barrier.
+func (w *windowByEventTime) Barrier(b edge.BarrierMessage) (edge.Message, error) {
+	w.n.watermark.observe(b.Time())
+	return nil, w.n.emitWatermark()
+}
+func (w *windowByEventTime) DeleteGroup(d edge.DeleteGroupMessage) (edge.Message, error) {
+	w.n.DeleteGroup(d.GroupID())
+	return d, nil
+}
+func (w *windowByEventTime) Done() {}
+
+func (w *windowByEventTime) Point(p edge.PointMessage) (edge.Message, error) {
+	// The point is late if all windows it belongs to have already been emitted.
+	if p.Time().Before(w.nextEmit.Add(-1 * w.period)) {
+		w.n.late.collect(p)
+		return nil, nil
+	}
+	w.insert(p)
+	w.n.watermark.observe(p.Time())
+	return nil, w.n.emitWatermark()
+}
+
+// insert adds the point to the buffer after any points with the same time.
+func (w *windowByEventTime) insert(p edge.PointMessage) {
+	i := sort.Search(len(w.points), func(i int) bool {
+		return w.points[i].Time().After(p.Time())
+	})
+	w.points = append(w.points, nil)
+	copy(w.points[i+1:], w.points[i:])
+	w.points[i] = p
+}
+
+// emit forwards all windows that end at or before the watermark wm.
+func (w *windowByEventTime) emit(wm time.Time) error {
+	for !wm.Before(w.nextEmit) {
+		// Since more points can arrive with the same time we need to use a left aligned window [oldest, nextEmit).
+		if b, ok := w.batch(w.nextEmit.Add(-1*w.period), w.nextEmit); ok {
+			if err := w.n.forward(b); err != nil {
+				return err
+			}
+		}
+		w.advance(wm)
+	}
+	return nil
+}
+
+// advance determines the next emit time and purges points that are no longer part of any window.
+func (w *windowByEventTime) advance(wm time.Time) {
+	w.nextEmit = w.nextEmit.Add(w.every)
+	i := sort.Search(len(w.points), func(i int) bool {
+		return !w.points[i].Time().Before(w.nextEmit.Add(-1 * w.period))
+	})
+	w.points = w.points[i:]
+
+	if len(w.points) == 0 {
+		// Without any points there are no windows to emit until after the watermark.
+		if !wm.Before(w.nextEmit) {
+			w.nextEmit = wm.Add(w.every)
+			if w.align {
+				w.nextEmit = w.nextEmit.Truncate(w.every)
+			}
+		}
+		return
+	}
+	// Skip windows that do not contain any points.
+	if oldest := w.points[0].Time(); oldest.Sub(w.nextEmit) >= 0 {
+		skip := oldest.Sub(w.nextEmit)/w.every + 1
+		w.nextEmit = w.nextEmit.Add(skip * w.every)
+	}
+}
+
+// batch returns the points in the range [start, stop) as a batch message.
+// If no points are in the range, false is returned.
+func (w *windowByEventTime) batch(start, stop time.Time) (edge.BufferedBatchMessage, bool) {
+	i := sort.Search(len(w.points), func(i int) bool {
+		return !w.points[i].Time().Before(start)
+	})
+	j := sort.Search(len(w.points), func(i int) bool {
+		return !w.points[i].Time().Before(stop)
+	})
+	if i == j {
+		return nil, false
+	}
+	points := make([]edge.BatchPointMessage, 0, j-i)
+	for _, p := range w.points[i:j] {
+		points = append(points, edge.BatchPointFromPoint(p))
+	}
+	return edge.NewBufferedBatchMessage(
+		edge.NewBeginBatchMessage(
+			w.name,
+			w.group.Tags,
+			w.group.Dimensions.ByName,
+			stop,
+			len(points),
+		),
+		points,
+		edge.NewEndBatchMessage(),
+	), true
+}
+
+func (w *windowByEventTime) snapshot() windowSnapshot {
+	s := windowSnapshot{
+		NextEmit: w.nextEmit,
+	}
+	for _, p := range w.points {
+		s.Points = append(s.Points, newPointSnapshot(p))
+	}
+	return s
+}
+
+func (w *windowByEventTime) restore(s windowSnapshot) {
+	w.nextEmit = s.NextEmit
+	for _, p := range s.Points {
+		p := p.pointMessage(w.name, w.group.Dimensions)
+		w.insert(p)
+		w.n.watermark.observe(p.Time())
+	}
+}
@@ -122,6 +122,7 @@ func TestWindowBufferByCount(t *testing.T) {
 	for _, tc := range testCases {
 		t.Logf("Starting test size %d period %d every %d", tc.size, tc.period, tc.every)
 		w := newWindowByCount(
+			nil,
 			"test",
 			edge.GroupInfo{},
 			tc.period,
@@ -195,3 +196,64 @@ func TestWindowBufferByCount(t *testing.T) {
 		}
 	}
 }
+
+func TestWindowByCalendar(t *testing.T) {
+	interval, err := newCalendarInterval("1d", "Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	berlin := interval.location
+	point := func(t time.Time) edge.PointMessage {
+		return edge.NewPointMessage(
+			"name", "db", "rp",
+			models.Dimensions{},
+			models.Fields{"value": 1.0},
+			nil,
+			t,
+		)
+	}
+	// The first day is cut short by daylight saving time.
+	times := []time.Time{
+		time.Date(2026, 3, 29, 0, 0, 0, 0, berlin),
+		time.Date(2026, 3, 29, 12, 0, 0, 0, berlin),
+		time.Date(2026, 3, 29, 23, 59, 0, 0, berlin),
+	}
+	w := newWindowByCalendar(nil, "name", times[0], edge.GroupInfo{}, interval, false, &nodeDiagnostic{})
+	for _, tm := range times {
+		msg, err := w.Point(point(tm))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg != nil {
+			t.Fatalf("unexpected message for point at %v: %v", tm, msg)
+		}
+	}
+
+	msg, err := w.Point(point(time.Date(2026, 3, 30, 0, 0, 0, 0, berlin)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, ok := msg.(edge.BufferedBatchMessage)
+	if !ok {
+		t.Fatalf("unexpected message type %T", msg)
+	}
+	if got, exp := len(b.Points()), 3; got != exp {
+		t.Fatalf("unexpected number of points got %d exp %d", got, exp)
+	}
+	if got, exp := b.Begin().Time(), time.Date(2026, 3, 30, 0, 0, 0, 0, berlin); !got.Equal(exp) {
+		t.Errorf("unexpected batch time got %v exp %v", got, exp)
+	}
+
+	// A barrier at the end of the second day emits it.
+	msg, err = w.Barrier(edge.NewBarrierMessage(edge.GroupInfo{}, time.Date(2026, 3, 31, 0, 0, 0, 0, berlin)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, ok = msg.(edge.BufferedBatchMessage)
+	if !ok {
+		t.Fatalf("unexpected message type %T", msg)
+	}
+	if got, exp := len(b.Points()), 1; got != exp {
+		t.Fatalf("unexpected number of points got %d exp %d", got, exp)
+	}
+}